@@ -1,18 +1,23 @@
 package tbot
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
 	"net/url"
 	"os"
+	"strings"
+	"time"
 )
 
 type responseParameters struct {
-	MigrateToChatID int `json:"migrate_to_chat_id"`
-	ReplyAfter      int `json:"retry_after"`
+	MigrateToChatID int64 `json:"migrate_to_chat_id"`
+	ReplyAfter      int   `json:"retry_after"`
 }
 
 type apiResponse struct {
@@ -23,53 +28,186 @@ type apiResponse struct {
 	Parameters  *responseParameters `json:"parameters"`
 }
 
+func (r *apiResponse) code() int {
+	if r.OK {
+		return http.StatusOK
+	}
+	return r.ErrorCode
+}
+
+// startRequest starts tracing of the Bot API call, returned func must be called when the call is finished
+func (c *Client) startRequest(method string) (context.Context, func(code int, err error)) {
+	start := time.Now()
+	ctx, span := c.tracer.StartRequest(c.context(), method)
+	span.SetAttribute("tbot.method", method)
+	return ctx, func(code int, err error) {
+		latency := time.Since(start)
+		c.metrics.ObserveRequest(method, code, latency)
+		span.SetAttribute("tbot.response_code", code)
+		if err != nil {
+			span.SetError(err)
+			c.logger.warn("bot api call failed", "method", method, "code", code, "latency", latency, "error", err)
+		} else {
+			c.logger.debug("bot api call", "method", method, "code", code, "latency", latency)
+		}
+		span.End()
+	}
+}
+
+// redactToken hides the bot token in err. *url.Error keeps its type, so Timeout and Temporary still work.
+func redactToken(err error, token string) error {
+	if err == nil || token == "" {
+		return err
+	}
+	if ue, ok := err.(*url.Error); ok {
+		return &url.Error{
+			Op:  ue.Op,
+			URL: strings.Replace(ue.URL, token, redacted, -1),
+			Err: redactToken(ue.Err, token),
+		}
+	}
+	if !strings.Contains(err.Error(), token) {
+		return err
+	}
+	return errors.New(strings.Replace(err.Error(), token, redacted, -1))
+}
+
+// doRequest calls the method without files, requests with nested parameters set by setJSON are sent as JSON
 func (c *Client) doRequest(method string, request url.Values, response interface{}) error {
+	if len(request[jsonParams]) > 0 || len(request[encodeErrors]) > 0 {
+		return c.doJSONRequest(method, nil, request, response)
+	}
+	var body []byte
+	if request != nil {
+		body = []byte(request.Encode())
+	}
+	return c.call(method, "application/x-www-form-urlencoded", body, response)
+}
+
+// doJSONRequest calls the method with JSON body made of request, a typed request struct, and values set by options
+func (c *Client) doJSONRequest(method string, request interface{}, values url.Values, response interface{}) error {
+	body, err := encodeRequest(request, values)
+	if err != nil {
+		return fmt.Errorf("unable to encode %s request: %v", method, err)
+	}
+	return c.call(method, "application/json", body, response)
+}
+
+func (c *Client) call(method, contentType string, body []byte, response interface{}) (err error) {
+	if c.dryRun != nil && c.dryRun.intercepts(method) {
+		return c.dryRun.respond(c.logger, method, contentType, body, nil, response)
+	}
+	ctx, finish := c.startRequest(method)
+	code := 0
+	defer func() {
+		err = redactToken(err, c.token)
+		finish(code, err)
+	}()
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+	err = c.limiter.wait(ctx)
+	if err != nil {
+		return err
+	}
+	code, err = c.post(ctx, method, contentType, body, response)
+	return err
+}
+
+// post calls the method with encoded request body, returns API result code
+func (c *Client) post(ctx context.Context, method, contentType string, request []byte, response interface{}) (int, error) {
 	endpoint := fmt.Sprintf(c.url, method)
-	var resp *http.Response
-	var err error
-	if request == nil {
-		resp, err = c.httpClient.Post(endpoint, "application/x-www-form-urlencoded", nil)
-	} else {
-		resp, err = c.httpClient.PostForm(endpoint, request)
+	var body io.Reader
+	if request != nil {
+		body = bytes.NewReader(request)
+	}
+	req, err := http.NewRequest(http.MethodPost, endpoint, body)
+	if err != nil {
+		return 0, err
 	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", contentType)
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("unable to send message: %v", err)
+		return 0, fmt.Errorf("unable to send message: %v", err)
 	}
 
 	apiResp := &apiResponse{}
 	err = json.NewDecoder(resp.Body).Decode(&apiResp)
 	if err != nil {
-		return fmt.Errorf("unable to decode sendMessage response: %v", err)
+		return 0, fmt.Errorf("unable to decode sendMessage response: %v", err)
 	}
 	err = resp.Body.Close()
 	if err != nil {
-		c.logger.Errorf("unable to close response body: %v", err)
+		c.logger.warn("unable to close response body", "method", method, "error", err)
 	}
 	if !apiResp.OK {
-		return fmt.Errorf(apiResp.Description)
+		return apiResp.code(), fmt.Errorf(apiResp.Description)
 	}
-	return json.Unmarshal(apiResp.Result, response)
+	return apiResp.code(), json.Unmarshal(apiResp.Result, response)
 }
 
-func (c *Client) doRequestWithFiles(method string, request url.Values, response interface{}, files ...inputFile) error {
+func (c *Client) doRequestWithFiles(method string, request url.Values, response interface{}, files ...inputFile) (err error) {
+	if c.dryRun != nil && c.dryRun.intercepts(method) {
+		form, err := formValues(request)
+		if err != nil {
+			return fmt.Errorf("unable to encode %s request: %v", method, err)
+		}
+		return c.dryRun.respond(c.logger, method, "application/x-www-form-urlencoded", []byte(form.Encode()), files, response)
+	}
+	ctx, finish := c.startRequest(method)
+	code := 0
+	defer func() {
+		err = redactToken(err, c.token)
+		finish(code, err)
+	}()
+	if c.uploadTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.uploadTimeout)
+		defer cancel()
+	}
+	request, err = formValues(request)
+	if err != nil {
+		return fmt.Errorf("unable to encode %s request: %v", method, err)
+	}
+	err = c.limiter.wait(ctx)
+	if err != nil {
+		return err
+	}
+	err = c.checkUploadSize(files)
+	if err != nil {
+		return err
+	}
+	if c.localMode {
+		// local Bot API server reads files from the disk itself
+		form := localFileValues(request, files)
+		code, err = c.post(ctx, method, "application/x-www-form-urlencoded", []byte(form.Encode()), response)
+		return err
+	}
 	endpoint := fmt.Sprintf(c.url, method)
 	r, w := io.Pipe()
 
 	done := make(chan struct{})
 	var resp *http.Response
-	var err error
+	var respErr error
 
 	mw := multipart.NewWriter(w)
+	req, err := http.NewRequest(http.MethodPost, endpoint, r)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
 
 	go func() {
 		defer close(done)
-		req, err := http.NewRequest(http.MethodPost, endpoint, r)
-		if err != nil {
-			c.logger.Error(err)
-			return
+		resp, respErr = c.uploadClient.Do(req)
+		if respErr != nil {
+			// unblock the multipart writer
+			r.CloseWithError(respErr)
 		}
-		req.Header.Set("Content-Type", mw.FormDataContentType())
-		resp, err = c.httpClient.Do(req)
 	}()
 
 	for k := range request {
@@ -78,10 +216,15 @@ func (c *Client) doRequestWithFiles(method string, request url.Values, response
 	for _, file := range files {
 		f, err := os.Open(file.name)
 		if err != nil {
+			w.CloseWithError(err)
+			<-done
 			return err
 		}
 		fileWriter, err := mw.CreateFormFile(file.field, file.name)
 		if err != nil {
+			f.Close()
+			w.CloseWithError(err)
+			<-done
 			return err
 		}
 
@@ -93,10 +236,11 @@ func (c *Client) doRequestWithFiles(method string, request url.Values, response
 	w.Close()
 
 	<-done // post request is done
-	if err != nil {
-		return err
+	if respErr != nil {
+		return respErr
 	}
 	if resp.StatusCode != http.StatusOK {
+		code = resp.StatusCode
 		return fmt.Errorf("unexpected status code: %s", resp.Status)
 	}
 	apiResp := &apiResponse{}
@@ -106,8 +250,9 @@ func (c *Client) doRequestWithFiles(method string, request url.Values, response
 	}
 	err = resp.Body.Close()
 	if err != nil {
-		c.logger.Errorf("unable to close response body: %v", err)
+		c.logger.warn("unable to close response body", "method", method, "error", err)
 	}
+	code = apiResp.code()
 	if !apiResp.OK {
 		return fmt.Errorf(apiResp.Description)
 	}
@@ -0,0 +1,686 @@
+package tbot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Limits checked by message builders, lengths are in UTF-16 code units
+const (
+	MaxPollQuestionLength       = 300
+	MaxPollOptionLength         = 100
+	MinPollOptions              = 2
+	MaxPollOptions              = 10
+	MaxInvoiceTitleLength       = 32
+	MaxInvoiceDescriptionLength = 255
+	MaxInvoicePayloadLength     = 128 // in bytes
+)
+
+// problems are validation errors of a request
+type problems []string
+
+func (p *problems) add(format string, args ...interface{}) {
+	*p = append(*p, fmt.Sprintf(format, args...))
+}
+
+func (p problems) err(method string) error {
+	if len(p) == 0 {
+		return nil
+	}
+	return errors.New("invalid " + method + " request: " + strings.Join(p, "; "))
+}
+
+// builder holds options shared by message builders
+type builder struct {
+	client    *Client
+	chatID    ChatID
+	parseMode string
+	entities  bool
+	markup    string   // name of the builder method which set reply markup
+	conflicts problems // found by builder methods, reported by Send
+	opts      []Option
+}
+
+func (b *builder) add(opt option) {
+	b.opts = append(b.opts, Option(opt.apply))
+}
+
+// problems returns a copy of problems found by builder methods and problems of the attached keyboard,
+// Send adds its own checks to it
+func (b *builder) problems() problems {
+	p := append(problems(nil), b.conflicts...)
+	b.checkMarkup(&p)
+	return p
+}
+
+// checkMarkup validates the keyboard attached by Keyboard, ReplyKeyboard or With
+func (b *builder) checkMarkup(p *problems) {
+	v := url.Values{}
+	b.apply(v)
+	data := []byte(v.Get("reply_markup"))
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(data, &fields) != nil {
+		return
+	}
+	var kb interface{ Validate() error }
+	switch {
+	case fields["inline_keyboard"] != nil:
+		kb = &InlineKeyboardMarkup{}
+	case fields["keyboard"] != nil:
+		kb = &ReplyKeyboardMarkup{}
+	default:
+		return
+	}
+	if json.Unmarshal(data, kb) != nil {
+		return
+	}
+	if err := kb.Validate(); err != nil {
+		p.add("%v", err)
+	}
+}
+
+func (b *builder) setParseMode(mode string, opt option) {
+	b.parseMode = mode
+	b.add(opt)
+}
+
+// with adds an option passed to With, options setting parse mode, entities or reply markup
+// are tracked like the ones added by builder methods, so Send checks them
+func (b *builder) with(opt option) {
+	v := url.Values{}
+	opt.apply(v)
+	if v.Get("entities") != "" || v.Get("caption_entities") != "" {
+		b.entities = true
+	}
+	switch {
+	case v.Get("reply_markup") != "":
+		if mode := v.Get("parse_mode"); mode != "" {
+			b.parseMode = mode
+		}
+		b.setMarkup("With(reply_markup)", opt)
+	case v.Get("parse_mode") != "":
+		b.setParseMode(v.Get("parse_mode"), opt)
+	default:
+		b.add(opt)
+	}
+}
+
+// setMarkup adds reply markup option, only one of inline keyboard, reply keyboard,
+// keyboard removal and force reply can be attached to a message
+func (b *builder) setMarkup(name string, opt option) {
+	if b.markup != "" && b.markup != name {
+		b.conflicts.add("%s and %s are mutually exclusive", b.markup, name)
+		return
+	}
+	b.markup = name
+	b.add(opt)
+}
+
+// checkText validates text or caption against limit, with parse mode applied
+func (b *builder) checkText(p *problems, name, text string, limit int) {
+	if b.entities && b.parseMode != "" {
+		p.add("%s entities and parse mode are mutually exclusive", name)
+	}
+	if n := textLen(text, b.parseMode); n > limit {
+		p.add("%s is %d characters long, the limit is %d", name, n, limit)
+	}
+}
+
+// apply sets all options of the builder, it's passed to Client methods as a single Option
+func (b *builder) apply(v url.Values) {
+	for _, opt := range b.opts {
+		opt.apply(v)
+	}
+}
+
+/*
+MessageBuilder builds a text message, it's created by Client.NewMessage.
+Builder methods can be chained, Send validates the message and sends it:
+
+	msg, err := client.NewMessage(m.Chat.ID).
+		Text("<b>Order</b> is ready").HTML().
+		Keyboard(kb).ReplyTo(m.MessageID).Silent().
+		Send(ctx)
+*/
+type MessageBuilder struct {
+	builder
+	text string
+}
+
+// NewMessage starts building a text message to the chat
+func (c *Client) NewMessage(chatID ChatID) *MessageBuilder {
+	return &MessageBuilder{builder: builder{client: c, chatID: chatID}}
+}
+
+// Text sets message text
+func (b *MessageBuilder) Text(text string) *MessageBuilder {
+	b.text = text
+	return b
+}
+
+// HTML parses message text as HTML
+func (b *MessageBuilder) HTML() *MessageBuilder {
+	b.setParseMode("HTML", OptParseModeHTML)
+	return b
+}
+
+// Markdown parses message text as MarkdownV2
+func (b *MessageBuilder) Markdown() *MessageBuilder {
+	b.setParseMode("MarkdownV2", OptParseModeMarkdown)
+	return b
+}
+
+// Entities sets entities of plain message text
+func (b *MessageBuilder) Entities(entities []*MessageEntity) *MessageBuilder {
+	b.entities = true
+	b.add(OptEntities(entities))
+	return b
+}
+
+// NoPreview disables link preview
+func (b *MessageBuilder) NoPreview() *MessageBuilder {
+	b.add(OptDisableWebPagePreview)
+	return b
+}
+
+// Keyboard attaches inline keyboard
+func (b *MessageBuilder) Keyboard(kb *InlineKeyboardMarkup) *MessageBuilder {
+	b.setMarkup("Keyboard", OptInlineKeyboardMarkup(kb))
+	return b
+}
+
+// ReplyKeyboard shows custom reply keyboard
+func (b *MessageBuilder) ReplyKeyboard(kb *ReplyKeyboardMarkup) *MessageBuilder {
+	b.setMarkup("ReplyKeyboard", OptReplyKeyboardMarkup(kb))
+	return b
+}
+
+// RemoveKeyboard removes custom reply keyboard
+func (b *MessageBuilder) RemoveKeyboard() *MessageBuilder {
+	b.setMarkup("RemoveKeyboard", OptReplyKeyboardRemove)
+	return b
+}
+
+// ForceReply shows reply interface to the user
+func (b *MessageBuilder) ForceReply() *MessageBuilder {
+	b.setMarkup("ForceReply", OptForceReply)
+	return b
+}
+
+// ReplyTo sends message as a reply to the message
+func (b *MessageBuilder) ReplyTo(messageID int) *MessageBuilder {
+	b.add(OptReplyToMessageID(messageID))
+	return b
+}
+
+// Silent sends message without notification
+func (b *MessageBuilder) Silent() *MessageBuilder {
+	b.add(OptDisableNotification)
+	return b
+}
+
+// With adds SendMessage options which have no builder method
+func (b *MessageBuilder) With(opts ...SendMessageOption) *MessageBuilder {
+	for _, opt := range opts {
+		b.with(opt)
+	}
+	return b
+}
+
+// Send validates and sends the message
+func (b *MessageBuilder) Send(ctx context.Context) (*Message, error) {
+	p := b.problems()
+	if strings.TrimSpace(b.text) == "" {
+		p.add("text is empty")
+	}
+	b.checkText(&p, "text", b.text, MaxMessageLength)
+	if err := p.err("sendMessage"); err != nil {
+		return nil, err
+	}
+	return b.client.WithContext(ctx).SendMessage(b.chatID, b.text, Option(b.apply))
+}
+
+// PhotoBuilder builds a photo message, it's created by Client.NewPhoto or Client.NewPhotoFile
+type PhotoBuilder struct {
+	builder
+	photo   string
+	upload  bool
+	caption string
+}
+
+// NewPhoto starts building a photo message with photo file ID or URL
+func (c *Client) NewPhoto(chatID ChatID, fileID string) *PhotoBuilder {
+	return &PhotoBuilder{builder: builder{client: c, chatID: chatID}, photo: fileID}
+}
+
+// NewPhotoFile starts building a photo message with photo uploaded from the file
+func (c *Client) NewPhotoFile(chatID ChatID, filename string) *PhotoBuilder {
+	return &PhotoBuilder{builder: builder{client: c, chatID: chatID}, photo: filename, upload: true}
+}
+
+// Caption sets photo caption
+func (b *PhotoBuilder) Caption(caption string) *PhotoBuilder {
+	b.caption = caption
+	b.add(OptCaption(caption))
+	return b
+}
+
+// HTML parses caption as HTML
+func (b *PhotoBuilder) HTML() *PhotoBuilder {
+	b.setParseMode("HTML", OptParseModeHTML)
+	return b
+}
+
+// Markdown parses caption as MarkdownV2
+func (b *PhotoBuilder) Markdown() *PhotoBuilder {
+	b.setParseMode("MarkdownV2", OptParseModeMarkdown)
+	return b
+}
+
+// Entities sets entities of plain caption
+func (b *PhotoBuilder) Entities(entities []*MessageEntity) *PhotoBuilder {
+	b.entities = true
+	b.add(OptCaptionEntities(entities))
+	return b
+}
+
+// Keyboard attaches inline keyboard
+func (b *PhotoBuilder) Keyboard(kb *InlineKeyboardMarkup) *PhotoBuilder {
+	b.setMarkup("Keyboard", OptInlineKeyboardMarkup(kb))
+	return b
+}
+
+// ReplyKeyboard shows custom reply keyboard
+func (b *PhotoBuilder) ReplyKeyboard(kb *ReplyKeyboardMarkup) *PhotoBuilder {
+	b.setMarkup("ReplyKeyboard", OptReplyKeyboardMarkup(kb))
+	return b
+}
+
+// RemoveKeyboard removes custom reply keyboard
+func (b *PhotoBuilder) RemoveKeyboard() *PhotoBuilder {
+	b.setMarkup("RemoveKeyboard", OptReplyKeyboardRemove)
+	return b
+}
+
+// ForceReply shows reply interface to the user
+func (b *PhotoBuilder) ForceReply() *PhotoBuilder {
+	b.setMarkup("ForceReply", OptForceReply)
+	return b
+}
+
+// ReplyTo sends photo as a reply to the message
+func (b *PhotoBuilder) ReplyTo(messageID int) *PhotoBuilder {
+	b.add(OptReplyToMessageID(messageID))
+	return b
+}
+
+// Silent sends photo without notification
+func (b *PhotoBuilder) Silent() *PhotoBuilder {
+	b.add(OptDisableNotification)
+	return b
+}
+
+// With adds SendPhoto options which have no builder method
+func (b *PhotoBuilder) With(opts ...SendPhotoOption) *PhotoBuilder {
+	for _, opt := range opts {
+		b.with(opt)
+	}
+	return b
+}
+
+// Send validates and sends the photo
+func (b *PhotoBuilder) Send(ctx context.Context) (*Message, error) {
+	p := b.problems()
+	if b.photo == "" {
+		p.add("photo is empty")
+	}
+	b.checkText(&p, "caption", b.caption, MaxCaptionLength)
+	if err := p.err("sendPhoto"); err != nil {
+		return nil, err
+	}
+	client := b.client.WithContext(ctx)
+	if b.upload {
+		return client.SendPhotoFile(b.chatID, b.photo, Option(b.apply))
+	}
+	return client.SendPhoto(b.chatID, b.photo, Option(b.apply))
+}
+
+// DocumentBuilder builds a document message, it's created by Client.NewDocument or Client.NewDocumentFile
+type DocumentBuilder struct {
+	builder
+	document string
+	upload   bool
+	caption  string
+}
+
+// NewDocument starts building a document message with document file ID or URL
+func (c *Client) NewDocument(chatID ChatID, fileID string) *DocumentBuilder {
+	return &DocumentBuilder{builder: builder{client: c, chatID: chatID}, document: fileID}
+}
+
+// NewDocumentFile starts building a document message with document uploaded from the file
+func (c *Client) NewDocumentFile(chatID ChatID, filename string) *DocumentBuilder {
+	return &DocumentBuilder{builder: builder{client: c, chatID: chatID}, document: filename, upload: true}
+}
+
+// Caption sets document caption
+func (b *DocumentBuilder) Caption(caption string) *DocumentBuilder {
+	b.caption = caption
+	b.add(OptCaption(caption))
+	return b
+}
+
+// HTML parses caption as HTML
+func (b *DocumentBuilder) HTML() *DocumentBuilder {
+	b.setParseMode("HTML", OptParseModeHTML)
+	return b
+}
+
+// Markdown parses caption as MarkdownV2
+func (b *DocumentBuilder) Markdown() *DocumentBuilder {
+	b.setParseMode("MarkdownV2", OptParseModeMarkdown)
+	return b
+}
+
+// Entities sets entities of plain caption
+func (b *DocumentBuilder) Entities(entities []*MessageEntity) *DocumentBuilder {
+	b.entities = true
+	b.add(OptCaptionEntities(entities))
+	return b
+}
+
+// Keyboard attaches inline keyboard
+func (b *DocumentBuilder) Keyboard(kb *InlineKeyboardMarkup) *DocumentBuilder {
+	b.setMarkup("Keyboard", OptInlineKeyboardMarkup(kb))
+	return b
+}
+
+// ReplyKeyboard shows custom reply keyboard
+func (b *DocumentBuilder) ReplyKeyboard(kb *ReplyKeyboardMarkup) *DocumentBuilder {
+	b.setMarkup("ReplyKeyboard", OptReplyKeyboardMarkup(kb))
+	return b
+}
+
+// RemoveKeyboard removes custom reply keyboard
+func (b *DocumentBuilder) RemoveKeyboard() *DocumentBuilder {
+	b.setMarkup("RemoveKeyboard", OptReplyKeyboardRemove)
+	return b
+}
+
+// ForceReply shows reply interface to the user
+func (b *DocumentBuilder) ForceReply() *DocumentBuilder {
+	b.setMarkup("ForceReply", OptForceReply)
+	return b
+}
+
+// ReplyTo sends document as a reply to the message
+func (b *DocumentBuilder) ReplyTo(messageID int) *DocumentBuilder {
+	b.add(OptReplyToMessageID(messageID))
+	return b
+}
+
+// Silent sends document without notification
+func (b *DocumentBuilder) Silent() *DocumentBuilder {
+	b.add(OptDisableNotification)
+	return b
+}
+
+// With adds SendDocument options which have no builder method
+func (b *DocumentBuilder) With(opts ...SendDocumentOption) *DocumentBuilder {
+	for _, opt := range opts {
+		b.with(opt)
+	}
+	return b
+}
+
+// Send validates and sends the document
+func (b *DocumentBuilder) Send(ctx context.Context) (*Message, error) {
+	p := b.problems()
+	if b.document == "" {
+		p.add("document is empty")
+	}
+	b.checkText(&p, "caption", b.caption, MaxCaptionLength)
+	if err := p.err("sendDocument"); err != nil {
+		return nil, err
+	}
+	client := b.client.WithContext(ctx)
+	if b.upload {
+		return client.SendDocumentFile(b.chatID, b.document, Option(b.apply))
+	}
+	return client.SendDocument(b.chatID, b.document, Option(b.apply))
+}
+
+/*
+PollBuilder builds a poll, it's created by Client.NewPoll:
+
+	msg, err := client.NewPoll(chatID, "2 + 2 = ?").
+		Options("3", "4", "5").Quiz(1).
+		Send(ctx)
+*/
+type PollBuilder struct {
+	builder
+	question string
+	options  []string
+	quiz     bool
+	correct  int
+	multiple bool
+}
+
+// NewPoll starts building a poll with the question
+func (c *Client) NewPoll(chatID ChatID, question string) *PollBuilder {
+	return &PollBuilder{builder: builder{client: c, chatID: chatID}, question: question}
+}
+
+// Options adds answer options
+func (b *PollBuilder) Options(options ...string) *PollBuilder {
+	b.options = append(b.options, options...)
+	return b
+}
+
+// Quiz makes the poll a quiz with the correct option, index of Options starting from 0
+func (b *PollBuilder) Quiz(correctOptionID int) *PollBuilder {
+	b.quiz = true
+	b.correct = correctOptionID
+	b.add(OptPollType(PollTypeQuiz))
+	b.add(OptCorrectOptionID(correctOptionID))
+	return b
+}
+
+// MultipleAnswers allows multiple answers, not supported by quizzes
+func (b *PollBuilder) MultipleAnswers() *PollBuilder {
+	b.multiple = true
+	b.add(OptAllowMultipleAnswers)
+	return b
+}
+
+// NotAnonymous makes the poll non-anonymous
+func (b *PollBuilder) NotAnonymous() *PollBuilder {
+	b.add(OptNotAnonymous)
+	return b
+}
+
+// Closed sends the poll closed
+func (b *PollBuilder) Closed() *PollBuilder {
+	b.add(OptClosedPoll)
+	return b
+}
+
+// Keyboard attaches inline keyboard
+func (b *PollBuilder) Keyboard(kb *InlineKeyboardMarkup) *PollBuilder {
+	b.setMarkup("Keyboard", OptInlineKeyboardMarkup(kb))
+	return b
+}
+
+// ReplyKeyboard shows custom reply keyboard
+func (b *PollBuilder) ReplyKeyboard(kb *ReplyKeyboardMarkup) *PollBuilder {
+	b.setMarkup("ReplyKeyboard", OptReplyKeyboardMarkup(kb))
+	return b
+}
+
+// RemoveKeyboard removes custom reply keyboard
+func (b *PollBuilder) RemoveKeyboard() *PollBuilder {
+	b.setMarkup("RemoveKeyboard", OptReplyKeyboardRemove)
+	return b
+}
+
+// ForceReply shows reply interface to the user
+func (b *PollBuilder) ForceReply() *PollBuilder {
+	b.setMarkup("ForceReply", OptForceReply)
+	return b
+}
+
+// ReplyTo sends poll as a reply to the message
+func (b *PollBuilder) ReplyTo(messageID int) *PollBuilder {
+	b.add(OptReplyToMessageID(messageID))
+	return b
+}
+
+// Silent sends poll without notification
+func (b *PollBuilder) Silent() *PollBuilder {
+	b.add(OptDisableNotification)
+	return b
+}
+
+// With adds SendPoll options which have no builder method
+func (b *PollBuilder) With(opts ...SendPollOption) *PollBuilder {
+	for _, opt := range opts {
+		b.with(opt)
+	}
+	return b
+}
+
+// Send validates and sends the poll
+func (b *PollBuilder) Send(ctx context.Context) (*Message, error) {
+	p := b.problems()
+	if n := utf16Len(b.question); n == 0 || n > MaxPollQuestionLength {
+		p.add("question must be 1-%d characters long, got %d", MaxPollQuestionLength, n)
+	}
+	if n := len(b.options); n < MinPollOptions || n > MaxPollOptions {
+		p.add("poll must have %d-%d options, got %d", MinPollOptions, MaxPollOptions, n)
+	}
+	for i, option := range b.options {
+		if n := utf16Len(option); n == 0 || n > MaxPollOptionLength {
+			p.add("option %d must be 1-%d characters long, got %d", i, MaxPollOptionLength, n)
+		}
+	}
+	if b.quiz && (b.correct < 0 || b.correct >= len(b.options)) {
+		p.add("correct option %d is out of range", b.correct)
+	}
+	if b.quiz && b.multiple {
+		p.add("Quiz and MultipleAnswers are mutually exclusive")
+	}
+	if err := p.err("sendPoll"); err != nil {
+		return nil, err
+	}
+	return b.client.WithContext(ctx).SendPoll(b.chatID, b.question, b.options, Option(b.apply))
+}
+
+/*
+InvoiceBuilder builds an invoice, it's created by Client.NewInvoice:
+
+	msg, err := client.NewInvoice(chatID, "order-42", providerToken, "USD").
+		Title("Coffee").Description("Large cappuccino").
+		Price("Cappuccino", 450).Price("Tips", 50).
+		Send(ctx)
+*/
+type InvoiceBuilder struct {
+	builder
+	payload       string
+	providerToken string
+	invoice       Invoice
+	prices        []LabeledPrice
+}
+
+// NewInvoice starts building an invoice with payload, provider token and three-letter ISO 4217 currency code
+func (c *Client) NewInvoice(chatID ChatID, payload, providerToken, currency string) *InvoiceBuilder {
+	return &InvoiceBuilder{
+		builder:       builder{client: c, chatID: chatID},
+		payload:       payload,
+		providerToken: providerToken,
+		invoice:       Invoice{Currency: currency},
+	}
+}
+
+// Title sets product name
+func (b *InvoiceBuilder) Title(title string) *InvoiceBuilder {
+	b.invoice.Title = title
+	return b
+}
+
+// Description sets product description
+func (b *InvoiceBuilder) Description(description string) *InvoiceBuilder {
+	b.invoice.Description = description
+	return b
+}
+
+// StartParameter sets deep-linking parameter of the invoice
+func (b *InvoiceBuilder) StartParameter(param string) *InvoiceBuilder {
+	b.invoice.StartParameter = param
+	return b
+}
+
+// Price adds price component in the smallest units of the currency
+func (b *InvoiceBuilder) Price(label string, amount int) *InvoiceBuilder {
+	b.prices = append(b.prices, LabeledPrice{Label: label, Amount: amount})
+	return b
+}
+
+// Photo sets URL of the product photo
+func (b *InvoiceBuilder) Photo(u string) *InvoiceBuilder {
+	b.add(OptPhotoURL(u))
+	return b
+}
+
+// Keyboard attaches inline keyboard, its first button must be a Pay button
+func (b *InvoiceBuilder) Keyboard(kb *InlineKeyboardMarkup) *InvoiceBuilder {
+	b.setMarkup("Keyboard", OptInlineKeyboardMarkup(kb))
+	return b
+}
+
+// ReplyTo sends invoice as a reply to the message
+func (b *InvoiceBuilder) ReplyTo(messageID int) *InvoiceBuilder {
+	b.add(OptReplyToMessageID(messageID))
+	return b
+}
+
+// Silent sends invoice without notification
+func (b *InvoiceBuilder) Silent() *InvoiceBuilder {
+	b.add(OptDisableNotification)
+	return b
+}
+
+// With adds SendInvoice options which have no builder method, e.g. OptNeedShippingAddress
+func (b *InvoiceBuilder) With(opts ...SendInvoiceOption) *InvoiceBuilder {
+	for _, opt := range opts {
+		b.with(opt)
+	}
+	return b
+}
+
+// Send validates and sends the invoice
+func (b *InvoiceBuilder) Send(ctx context.Context) (*Message, error) {
+	p := b.problems()
+	if n := utf16Len(b.invoice.Title); n == 0 || n > MaxInvoiceTitleLength {
+		p.add("title must be 1-%d characters long, got %d", MaxInvoiceTitleLength, n)
+	}
+	if n := utf16Len(b.invoice.Description); n == 0 || n > MaxInvoiceDescriptionLength {
+		p.add("description must be 1-%d characters long, got %d", MaxInvoiceDescriptionLength, n)
+	}
+	if n := len(b.payload); n == 0 || n > MaxInvoicePayloadLength {
+		p.add("payload must be 1-%d bytes long, got %d", MaxInvoicePayloadLength, n)
+	}
+	if len(b.invoice.Currency) != 3 {
+		p.add("currency must be three-letter ISO 4217 code, got %q", b.invoice.Currency)
+	}
+	if len(b.prices) == 0 {
+		p.add("invoice has no prices")
+	}
+	if err := p.err("sendInvoice"); err != nil {
+		return nil, err
+	}
+	return b.client.WithContext(ctx).SendInvoice(b.chatID, b.payload, b.providerToken, &b.invoice, b.prices, Option(b.apply))
+}
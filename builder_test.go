@@ -0,0 +1,135 @@
+package tbot_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/yanzay/tbot/v2"
+	"github.com/yanzay/tbot/v2/tbottest"
+)
+
+func TestMessageBuilder(t *testing.T) {
+	api := tbottest.NewServer(token)
+	defer api.Close()
+	c := api.Client()
+
+	kb := &tbot.InlineKeyboardMarkup{InlineKeyboard: [][]tbot.InlineKeyboardButton{{{Text: "ok", CallbackData: "ok"}}}}
+	text := "<b>" + strings.Repeat("a", tbot.MaxMessageLength) + "</b>"
+	_, err := c.NewMessage("1").Text(text).HTML().Keyboard(kb).ReplyTo(5).Silent().Send(context.Background())
+	if err != nil {
+		t.Fatalf("unable to send message: %v", err)
+	}
+	params := api.CallsTo("sendMessage")[0].Params
+	if params.Get("text") != text || params.Get("parse_mode") != "HTML" ||
+		params.Get("reply_to_message_id") != "5" || params.Get("disable_notification") != "true" ||
+		params.Get("reply_markup") != `{"inline_keyboard":[[{"text":"ok","callback_data":"ok"}]]}` {
+		t.Errorf("unexpected params %v", params)
+	}
+
+	_, err = c.NewPoll("1", "2 + 2 = ?").Options("3", "4", "5").Quiz(1).NotAnonymous().Send(context.Background())
+	if err != nil {
+		t.Fatalf("unable to send poll: %v", err)
+	}
+	params = api.CallsTo("sendPoll")[0].Params
+	if params.Get("options") != `["3","4","5"]` || params.Get("type") != "quiz" ||
+		params.Get("correct_option_id") != "1" || params.Get("is_anonymous") != "false" {
+		t.Errorf("unexpected poll params %v", params)
+	}
+
+	_, err = c.NewPhoto("1", "AgAD1").Caption("cat").ForceReply().Send(context.Background())
+	if err != nil {
+		t.Fatalf("unable to send photo: %v", err)
+	}
+	params = api.CallsTo("sendPhoto")[0].Params
+	if params.Get("photo") != "AgAD1" || params.Get("caption") != "cat" || params.Get("reply_markup") != `{"force_reply":true,"selective":false}` {
+		t.Errorf("unexpected photo params %v", params)
+	}
+	// parse mode set by With applies, only the text of a MarkdownV2 link counts
+	text = strings.Repeat("a", tbot.MaxMessageLength-4) + "[link](https://example.com/a/long/path)"
+	_, err = c.NewMessage("1").Text(text).With(tbot.OptParseModeMarkdown).Send(context.Background())
+	if err != nil {
+		t.Fatalf("unable to send markdown message: %v", err)
+	}
+}
+
+func TestBuilderValidation(t *testing.T) {
+	api := tbottest.NewServer(token)
+	defer api.Close()
+	c := api.Client()
+	ctx := context.Background()
+	kb := &tbot.InlineKeyboardMarkup{}
+
+	tt := []struct {
+		name string
+		send func() error
+		want string
+	}{
+		{"long text", func() error {
+			_, err := c.NewMessage("1").Text(strings.Repeat("я", tbot.MaxMessageLength+1)).Send(ctx)
+			return err
+		}, "text is 4097 characters long, the limit is 4096"},
+		{"empty text", func() error {
+			_, err := c.NewMessage("1").Send(ctx)
+			return err
+		}, "text is empty"},
+		{"markups", func() error {
+			_, err := c.NewMessage("1").Text("hi").Keyboard(kb).RemoveKeyboard().Send(ctx)
+			return err
+		}, "Keyboard and RemoveKeyboard are mutually exclusive"},
+		{"invalid keyboard", func() error {
+			bad := &tbot.InlineKeyboardMarkup{InlineKeyboard: [][]tbot.InlineKeyboardButton{{{Text: "no action"}}}}
+			_, err := c.NewMessage("1").Text("hi").Keyboard(bad).Send(ctx)
+			return err
+		}, `button "no action" must have exactly one action, got 0`},
+		{"invalid reply keyboard", func() error {
+			bad := &tbot.ReplyKeyboardMarkup{Keyboard: [][]tbot.KeyboardButton{{{Text: "both", RequestContact: true, RequestLocation: true}}}}
+			_, err := c.NewPhoto("1", "AgAD1").ReplyKeyboard(bad).Send(ctx)
+			return err
+		}, `invalid sendPhoto request: button "both"`},
+		{"markups with With", func() error {
+			_, err := c.NewMessage("1").Text("hi").Keyboard(kb).With(tbot.OptForceReply).Send(ctx)
+			return err
+		}, "Keyboard and With(reply_markup) are mutually exclusive"},
+		{"entities with With", func() error {
+			_, err := c.NewMessage("1").Text("hi").With(tbot.OptParseModeHTML, tbot.OptEntities(nil)).Send(ctx)
+			return err
+		}, "text entities and parse mode are mutually exclusive"},
+		{"long text with With", func() error {
+			text := "<b>" + strings.Repeat("a", tbot.MaxMessageLength) + "</b>!"
+			_, err := c.NewMessage("1").Text(text).With(tbot.OptParseModeHTML).Send(ctx)
+			return err
+		}, "text is 4097 characters long"},
+		{"entities with parse mode", func() error {
+			_, err := c.NewMessage("1").Text("hi").HTML().Entities(nil).Send(ctx)
+			return err
+		}, "text entities and parse mode are mutually exclusive"},
+		{"long caption", func() error {
+			_, err := c.NewDocument("1", "BQAD1").Caption(strings.Repeat("a", tbot.MaxCaptionLength+1)).Send(ctx)
+			return err
+		}, "caption is 1025 characters long"},
+		{"poll options", func() error {
+			_, err := c.NewPoll("1", "?").Options("yes").Send(ctx)
+			return err
+		}, "poll must have 2-10 options, got 1"},
+		{"quiz", func() error {
+			_, err := c.NewPoll("1", "?").Options("a", "b").Quiz(2).MultipleAnswers().Send(ctx)
+			return err
+		}, "correct option 2 is out of range; Quiz and MultipleAnswers are mutually exclusive"},
+		{"invoice", func() error {
+			_, err := c.NewInvoice("1", "order-1", "TOKEN", "USD").Title("Coffee").Description("Cappuccino").Send(ctx)
+			return err
+		}, "invalid sendInvoice request: invoice has no prices"},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.send()
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("expected error %q, got %v", tc.want, err)
+			}
+		})
+	}
+	if calls := api.Calls(); len(calls) != 0 {
+		t.Errorf("invalid requests must not be sent, got %v", calls)
+	}
+}
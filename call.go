@@ -0,0 +1,160 @@
+package tbot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+)
+
+// InputFile is a file on the disk uploaded with Call
+type InputFile struct {
+	Path string
+}
+
+// CallForm is Call params sent as a form: values and files uploaded along with them
+type CallForm struct {
+	Values url.Values
+	Files  map[string]InputFile // field name to file
+}
+
+/*
+Call calls Bot API method which is not wrapped by Client yet and decodes its result into result,
+which can be nil if the result is not needed.
+Params can be nil, url.Values, CallForm, map[string]string, map[string]interface{} or a struct (or pointer to it)
+encoded according to its json tags. url.Values and CallForm are sent as a form as is, maps and structs are sent
+as a JSON object. InputFile values of maps and structs and files of CallForm are uploaded in a multipart form
+along with the other params, which are sent JSON-encoded unless they are strings:
+
+	var name struct {
+		Name string `json:"name"`
+	}
+	err := client.Call(ctx, "getMyName", map[string]interface{}{
+		"language_code": "en",
+	}, &name)
+
+	err = client.Call(ctx, "sendPhoto", tbot.CallForm{
+		Values: url.Values{"chat_id": {"42"}},
+		Files:  map[string]tbot.InputFile{"photo": {Path: "cat.png"}},
+	}, &msg)
+
+Calls are rate limited, logged and traced the same way as other Client methods.
+*/
+func (c *Client) Call(ctx context.Context, method string, params interface{}, result interface{}) error {
+	request, files, err := callValues(params)
+	if err != nil {
+		return fmt.Errorf("unable to encode %s params: %v", method, err)
+	}
+	if result == nil {
+		result = &json.RawMessage{}
+	}
+	client := c.WithContext(ctx)
+	if len(files) > 0 {
+		return client.doRequestWithFiles(method, request, result, files...)
+	}
+	switch params.(type) {
+	case nil, url.Values, CallForm, *CallForm:
+		return client.doRequest(method, request, result)
+	}
+	return client.doJSONRequest(method, nil, request, result)
+}
+
+// callValues converts Call params to request values and files,
+// values of maps and structs which are not strings are set as JSON
+func callValues(params interface{}) (url.Values, []inputFile, error) {
+	request := url.Values{}
+	var files []inputFile
+	add := func(name string, value interface{}) error {
+		switch v := value.(type) {
+		case InputFile:
+			files = append(files, inputFile{field: name, name: v.Path})
+			return nil
+		case *InputFile:
+			files = append(files, inputFile{field: name, name: v.Path})
+			return nil
+		case string:
+			request.Set(name, v)
+			return nil
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+		if string(data) == "null" {
+			return nil
+		}
+		var s string
+		if json.Unmarshal(data, &s) == nil {
+			request.Set(name, s)
+			return nil
+		}
+		setRawJSON(request, name, data)
+		return nil
+	}
+	form := func(values url.Values) {
+		// the caller's values are copied, the request must not change them
+		for name, v := range values {
+			request[name] = append([]string(nil), v...)
+		}
+	}
+
+	switch p := params.(type) {
+	case nil:
+		return nil, nil, nil
+	case url.Values:
+		form(p)
+		return request, nil, nil
+	case *CallForm:
+		if p == nil {
+			return nil, nil, nil
+		}
+		return callValues(*p)
+	case CallForm:
+		form(p.Values)
+		var names []string
+		for name := range p.Files {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			files = append(files, inputFile{field: name, name: p.Files[name].Path})
+		}
+		return request, files, nil
+	case map[string]string:
+		for name, value := range p {
+			request.Set(name, value)
+		}
+		return request, nil, nil
+	case map[string]interface{}:
+		for name, value := range p {
+			err := add(name, value)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		return request, files, nil
+	}
+
+	v := reflect.ValueOf(params)
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, nil, nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, nil, fmt.Errorf("unsupported params type %T", params)
+	}
+	for name, field := range jsonFields(v.Type()) {
+		if field.omitted(v) {
+			continue
+		}
+		err := add(name, v.FieldByIndex(field.index).Interface())
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return request, files, nil
+}
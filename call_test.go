@@ -0,0 +1,112 @@
+package tbot_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/yanzay/tbot/v2"
+	"github.com/yanzay/tbot/v2/tbottest"
+)
+
+func TestCall(t *testing.T) {
+	api := tbottest.NewServer(token)
+	defer api.Close()
+	api.Handle("createForumTopic", func(call tbottest.Call) (interface{}, *tbottest.APIError) {
+		return map[string]interface{}{"message_thread_id": 7, "name": call.Params.Get("name")}, nil
+	})
+	c := api.Client()
+
+	type params struct {
+		ChatID    tbot.ChatID `json:"chat_id"`
+		Name      string      `json:"name"`
+		IconColor int         `json:"icon_color,omitempty"`
+		Silent    bool        `json:"silent"`
+		Keyboard  *tbot.InlineKeyboardMarkup
+	}
+	var topic struct {
+		MessageThreadID int    `json:"message_thread_id"`
+		Name            string `json:"name"`
+	}
+	err := c.Call(context.Background(), "createForumTopic", &params{
+		ChatID:   tbot.NewChatID(-100123),
+		Name:     "Support",
+		Keyboard: &tbot.InlineKeyboardMarkup{},
+	}, &topic)
+	if err != nil {
+		t.Fatalf("unable to call createForumTopic: %v", err)
+	}
+	if topic.MessageThreadID != 7 || topic.Name != "Support" {
+		t.Errorf("unexpected result %+v", topic)
+	}
+	want := url.Values{
+		"chat_id":  {"-100123"},
+		"name":     {"Support"},
+		"silent":   {"false"},
+		"Keyboard": {`{"inline_keyboard":null}`},
+	}
+	if got := api.CallsTo("createForumTopic")[0].Params; got.Encode() != want.Encode() {
+		t.Errorf("unexpected params %v", got)
+	}
+	if body := api.CallsTo("createForumTopic")[0].Body; string(body) != `{"Keyboard":{"inline_keyboard":null},"chat_id":-100123,"name":"Support","silent":false}` {
+		t.Errorf("struct params must be sent as JSON, got %s", body)
+	}
+
+	api.FailNext("closeForumTopic", &tbottest.APIError{Code: 400, Description: "Bad Request: TOPIC_NOT_MODIFIED"})
+	values := url.Values{"chat_id": {"1"}}
+	err = c.Call(context.Background(), "closeForumTopic", values, nil)
+	if err == nil || err.Error() != "Bad Request: TOPIC_NOT_MODIFIED" {
+		t.Errorf("expected API error, got %v", err)
+	}
+	if call := api.CallsTo("closeForumTopic")[0]; call.Body != nil || call.Params.Get("chat_id") != "1" {
+		t.Errorf("url.Values must be sent as a form, got %+v", call)
+	}
+	if err := c.Call(context.Background(), "getMe", 42, nil); err == nil {
+		t.Errorf("expected error for unsupported params")
+	}
+}
+
+func TestCallWithFiles(t *testing.T) {
+	api := tbottest.NewServer(token)
+	defer api.Close()
+	dir, err := ioutil.TempDir("", "tbot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	photo := filepath.Join(dir, "photo.png")
+	ioutil.WriteFile(photo, []byte("png"), 0644)
+
+	msg := &tbot.Message{}
+	err = api.Client().Call(context.Background(), "sendPhoto", map[string]interface{}{
+		"chat_id": tbot.NewChatID(10),
+		"photo":   tbot.InputFile{Path: photo},
+		"caption": "hello",
+	}, msg)
+	if err != nil {
+		t.Fatalf("unable to call sendPhoto: %v", err)
+	}
+	call := api.CallsTo("sendPhoto")[0]
+	if msg.Chat.ID != "10" || call.Params.Get("caption") != "hello" || call.Files["photo"] != "photo.png" {
+		t.Errorf("unexpected call %+v, message %+v", call, msg)
+	}
+
+	values := url.Values{"chat_id": {"11"}}
+	err = api.Client().Call(context.Background(), "sendDocument", tbot.CallForm{
+		Values: values,
+		Files:  map[string]tbot.InputFile{"document": {Path: photo}, "thumbnail": {Path: photo}},
+	}, msg)
+	if err != nil {
+		t.Fatalf("unable to call sendDocument: %v", err)
+	}
+	call = api.CallsTo("sendDocument")[0]
+	if msg.Chat.ID != "11" || call.Files["document"] != "photo.png" || call.Files["thumbnail"] != "photo.png" {
+		t.Errorf("unexpected call %+v, message %+v", call, msg)
+	}
+	if len(values) != 1 {
+		t.Errorf("caller's values are changed: %v", values)
+	}
+}
@@ -0,0 +1,159 @@
+package tbot
+
+import (
+	"context"
+	"io"
+)
+
+/*
+Messaging sends, edits and deletes messages.
+
+Capability interfaces Messaging, ChatAdmin, Files, Inline and Payments group Client methods,
+handlers can depend on the groups they use instead of *Client and be tested with a mock,
+e.g. tbotmock.Client:
+
+	type greeter struct {
+		client tbot.Messaging
+	}
+
+	func (g *greeter) handle(m *tbot.Message) {
+		g.client.SendMessage(m.Chat.ID, "hello")
+	}
+*/
+type Messaging interface {
+	SendMessage(chatID ChatID, text string, opts ...SendMessageOption) (*Message, error)
+	SendLongMessage(chatID ChatID, text string, opts ...SendMessageOption) ([]*Message, error)
+	ForwardMessage(chatID, fromChatID ChatID, messageID int, opts ...ForwardMessageOption) (*Message, error)
+	ForwardMessages(chatID, fromChatID ChatID, messageIDs []int, opts ...ForwardMessagesOption) ([]*MessageID, error)
+	ForwardMessagesBulk(chatID, fromChatID ChatID, messageIDs []int, opts ...ForwardMessagesOption) ([]BulkResult, error)
+	CopyMessage(chatID, fromChatID ChatID, messageID int, opts ...CopyMessageOption) (*MessageID, error)
+	CopyMessages(chatID, fromChatID ChatID, messageIDs []int, opts ...CopyMessagesOption) ([]*MessageID, error)
+	CopyMessagesBulk(chatID, fromChatID ChatID, messageIDs []int, opts ...CopyMessagesOption) ([]BulkResult, error)
+	SendAudio(chatID ChatID, fileID string, opts ...SendAudioOption) (*Message, error)
+	SendAudioFile(chatID ChatID, filename string, opts ...SendAudioOption) (*Message, error)
+	SendPhoto(chatID ChatID, fileID string, opts ...SendPhotoOption) (*Message, error)
+	SendPhotoFile(chatID ChatID, filename string, opts ...SendPhotoOption) (*Message, error)
+	SendDocument(chatID ChatID, fileID string, opts ...SendDocumentOption) (*Message, error)
+	SendDocumentFile(chatID ChatID, filename string, opts ...SendDocumentOption) (*Message, error)
+	SendVideo(chatID ChatID, fileID string, opts ...SendVideoOption) (*Message, error)
+	SendVideoFile(chatID ChatID, filename string, opts ...SendVideoOption) (*Message, error)
+	SendAnimation(chatID ChatID, fileID string, opts ...SendAnimationOption) (*Message, error)
+	SendAnimationFile(chatID ChatID, filename string, opts ...SendAnimationOption) (*Message, error)
+	SendVoice(chatID ChatID, fileID string, opts ...SendVoiceOption) (*Message, error)
+	SendVoiceFile(chatID ChatID, filename string, opts ...SendVoiceOption) (*Message, error)
+	SendVideoNote(chatID ChatID, fileID string, opts ...SendVideoNoteOption) (*Message, error)
+	SendVideoNoteFile(chatID ChatID, filename string, opts ...SendVideoNoteOption) (*Message, error)
+	SendMediaGroup(chatID ChatID, media []InputMedia, opts ...SendMediaGroupOption) ([]*Message, error)
+	SendLocation(chatID ChatID, latitude, longitude float64, opts ...SendLocationOption) (*Message, error)
+	EditMessageLiveLocation(chatID ChatID, messageID int, latitude, longitude float64, opts ...LiveLocationOption) (*Message, error)
+	StopMessageLiveLocation(chatID ChatID, messageID int, opts ...LiveLocationOption) (*Message, error)
+	SendVenue(chatID ChatID, latitude, longitude float64, title, address string, opts ...SendVenueOption) (*Message, error)
+	SendContact(chatID ChatID, phoneNumber, firstName string, opts ...SendContactOption) (*Message, error)
+	SendSticker(chatID ChatID, fileID string, opts ...SendStickerOption) (*Message, error)
+	SendStickerFile(chatID ChatID, filename string, opts ...SendStickerOption) (*Message, error)
+	SendPoll(chatID ChatID, question string, options []string, opts ...SendPollOption) (*Message, error)
+	StopPoll(chatID ChatID, messageID int, opts ...StopPollOption) (*Poll, error)
+	SendDice(chatID ChatID, emoji string, opts ...SendDiceOption) (*Dice, error)
+	SendGame(chatID ChatID, gameShortName string, opts ...SendGameOption) (*Message, error)
+	SetGameScore(chatID ChatID, messageID int, userID int64, score int, opts ...SetGameScoreOption) (*Message, error)
+	GetGameHighScores(chatID ChatID, messageID int, userID int64) ([]*GameHighScore, error)
+	SendChatAction(chatID ChatID, action ChatAction) error
+	EditMessageText(chatID ChatID, messageID int, text string, opts ...EditMessageTextOption) (*Message, error)
+	EditMessageCaption(chatID ChatID, messageID int, caption string, opts ...EditMessageCaptionOption) (*Message, error)
+	EditMessageReplyMarkup(chatID ChatID, messageID int, opts ...EditMessageReplyMarkupOption) (*Message, error)
+	DeleteMessage(chatID ChatID, messageID int) error
+	DeleteMessages(chatID ChatID, messageIDs []int) error
+	DeleteMessagesBulk(chatID ChatID, messageIDs []int) ([]BulkResult, error)
+}
+
+// ChatAdmin reads chat information and manages chats, their members and forum topics
+type ChatAdmin interface {
+	GetChat(chatID ChatID) (*Chat, error)
+	GetChatAdministrators(chatID ChatID) ([]*ChatMember, error)
+	GetChatMembersCount(chatID ChatID) (int, error)
+	GetChatMemberCount(chatID ChatID) (int, error)
+	GetChatMember(chatID ChatID, userID int64) (*ChatMember, error)
+	KickChatMember(chatID ChatID, userID int64, opts ...KickChatMemberOption) error
+	BanChatMember(chatID ChatID, userID int64, opts ...BanChatMemberOption) error
+	UnbanChatMember(chatID ChatID, userID int64) error
+	RestrictChatMember(chatID ChatID, userID int64, perm *ChatPermissions, opts ...RestrictChatMemberOption) error
+	PromoteChatMember(chatID ChatID, userID int64, p *Promotions) error
+	SetChatAdministratorCustomTitle(chatID ChatID, userID int64, customTitle string) error
+	SetChatPermissions(chatID ChatID, permissions *ChatPermissions) error
+	BanChatSenderChat(chatID ChatID, senderChatID int64) error
+	UnbanChatSenderChat(chatID ChatID, senderChatID int64) error
+	ApproveChatJoinRequest(chatID ChatID, userID int64) error
+	DeclineChatJoinRequest(chatID ChatID, userID int64) error
+	ExportChatInviteLink(chatID ChatID) (string, error)
+	SetChatPhoto(chatID ChatID, filename string) error
+	DeleteChatPhoto(chatID ChatID) error
+	SetChatTitle(chatID ChatID, title string) error
+	SetChatDescription(chatID ChatID, description string) error
+	PinChatMessage(chatID ChatID, messageID int, opts ...PinChatMessageOption) error
+	UnpinChatMessage(chatID ChatID) error
+	UnpinAllChatMessages(chatID ChatID) error
+	SetChatStickerSet(chatID ChatID, stickerSetName string) error
+	DeleteChatStickerSet(chatID ChatID) error
+	LeaveChat(chatID ChatID) error
+	GetForumTopicIconStickers() ([]*Sticker, error)
+	CreateForumTopic(chatID ChatID, name string, opts ...CreateForumTopicOption) (*ForumTopic, error)
+	EditForumTopic(chatID ChatID, messageThreadID int, opts ...EditForumTopicOption) error
+	CloseForumTopic(chatID ChatID, messageThreadID int) error
+	ReopenForumTopic(chatID ChatID, messageThreadID int) error
+	DeleteForumTopic(chatID ChatID, messageThreadID int) error
+	UnpinAllForumTopicMessages(chatID ChatID, messageThreadID int) error
+}
+
+// Files downloads files and manages sticker sets
+type Files interface {
+	GetFile(fileID string) (*File, error)
+	FileURL(file *File) string
+	DownloadFile(fileID string) (io.ReadCloser, error)
+	GetUserProfilePhotos(userID int64, opts ...GetUserProfilePhotosOption) (*UserProfilePhotos, error)
+	GetStickerSet(name string) (*StickerSet, error)
+	UploadStickerFile(userID int64, filename string) (*File, error)
+	CreateNewStickerSet(userID int64, name, title, fileID, emojis string, opts ...CreateNewStickerSetOption) error
+	CreateNewStickerSetFile(userID int64, name, title, stickerFilename, emojis string, opts ...CreateNewStickerSetOption) error
+	AddStickerToSet(userID int64, name, fileID, emojis string, opts ...AddStickerToSetOption) error
+	AddStickerToSetFile(userID int64, name, filename, emojis string, opts ...AddStickerToSetOption) error
+	SetStickerPositionInSet(fileID string, pos int) error
+	DeleteStickerFromSet(fileID string) error
+	SetStickerSetThumb(userID int64, name, thumb string) error
+	SetStickerSetThumbFile(userID int64, name, thumbnailFilename string) error
+}
+
+// Inline answers callback and inline queries and edits messages sent via inline mode
+type Inline interface {
+	AnswerCallbackQuery(callbackQueryID string, opts ...AnswerCallbackQueryOption) error
+	AnswerInlineQuery(inlineQueryID string, results []InlineQueryResult, opts ...AnswerInlineQueryOption) error
+	EditInlineMessageText(inlineMessageID, text string, opts ...EditMessageTextOption) error
+	EditInlineMessageCaption(inlineMessageID, caption string, opts ...EditMessageCaptionOption) error
+	EditInlineMessageReplyMarkup(inlineMessageID string, opts ...EditMessageReplyMarkupOption) error
+	EditInlineMessageLiveLocation(inlineMessageID string, latitude, longitude float64, opts ...LiveLocationOption) error
+	StopInlineMessageLiveLocation(inlineMessageID string, opts ...LiveLocationOption) error
+	SetInlineGameScore(inlineMessageID string, userID int64, score int, opts ...SetGameScoreOption) error
+	GetInlineGameHighScores(inlineMessageID string, userID int64) ([]*GameHighScore, error)
+}
+
+// Payments sends invoices and answers shipping and pre-checkout queries
+type Payments interface {
+	SendInvoice(chatID ChatID, payload, providerToken string, invoice *Invoice, prices []LabeledPrice, opts ...SendInvoiceOption) (*Message, error)
+	AnswerShippingQuery(shippingQueryID string, ok bool, opts ...AnswerShippingQueryOption) error
+	AnswerPreCheckoutQuery(preCheckoutQueryID string, ok bool, opts ...AnswerPreCheckoutQueryOption) error
+}
+
+// API is the whole Client surface available to handlers
+type API interface {
+	Messaging
+	ChatAdmin
+	Files
+	Inline
+	Payments
+	GetMe() (*User, error)
+	GetMyCommands() (*[]BotCommand, error)
+	SetMyCommands(commands []BotCommand) error
+	SetPassportDataErrors(userID int64, errors []PassportElementError) error
+	Call(ctx context.Context, method string, params interface{}, result interface{}) error
+}
+
+var _ API = (*Client)(nil)
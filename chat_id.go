@@ -0,0 +1,76 @@
+package tbot
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+/*
+ChatID is unique identifier of a chat or username of a channel or supergroup in format @channelusername.
+Chat.ID of received messages can be passed to Client methods as is, numeric IDs are created with NewChatID:
+
+	client.SendMessage(m.Chat.ID, "hello")
+	client.SendMessage(tbot.NewChatID(-1001234567890), "hello")
+	client.SendMessage(tbot.ChannelUsername("mychannel"), "hello")
+*/
+type ChatID string
+
+// NewChatID returns ChatID of the chat with given numeric identifier
+func NewChatID(id int64) ChatID {
+	return ChatID(strconv.FormatInt(id, 10))
+}
+
+// ChannelUsername returns ChatID of the channel or supergroup with given username, with or without leading @
+func ChannelUsername(username string) ChatID {
+	if strings.HasPrefix(username, "@") {
+		return ChatID(username)
+	}
+	return ChatID("@" + username)
+}
+
+// Int64 returns numeric identifier of the chat, false if ChatID is a username
+func (id ChatID) Int64() (int64, bool) {
+	n, err := strconv.ParseInt(string(id), 10, 64)
+	return n, err == nil
+}
+
+// IsUsername reports whether ChatID is a channel username
+func (id ChatID) IsUsername() bool {
+	return strings.HasPrefix(string(id), "@")
+}
+
+func (id ChatID) String() string {
+	return string(id)
+}
+
+// MarshalJSON implements json.Marshaler, numeric IDs are encoded as numbers
+func (id ChatID) MarshalJSON() ([]byte, error) {
+	if n, ok := id.Int64(); ok {
+		return json.Marshal(n)
+	}
+	return json.Marshal(string(id))
+}
+
+// UnmarshalJSON implements json.Unmarshaler, it accepts both numbers and strings
+func (id *ChatID) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		err := json.Unmarshal(data, &s)
+		if err != nil {
+			return err
+		}
+		*id = ChatID(s)
+		return nil
+	}
+	var n int64
+	err := json.Unmarshal(data, &n)
+	if err != nil {
+		return err
+	}
+	*id = NewChatID(n)
+	return nil
+}
@@ -1,10 +1,11 @@
 package tbot
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
 	"time"
 )
@@ -16,52 +17,81 @@ type Client struct {
 	url           string
 	httpClient    *http.Client
 	nextOffset    int
-	logger        Logger
+	logger        *libLogger
 	bufferSize    int
-	timeout       int
+	timeout       time.Duration
+	uploadTimeout time.Duration
+	uploadClient  *http.Client
 	updatesParams url.Values
+	metrics       Metrics
+	tracer        Tracer
+	limiter       *rateLimiter
+	localMode     bool
+	dryRun        *dryRun
+	ctx           context.Context
 }
 
 // NewClient creates new Telegram API client
 func NewClient(token string, httpClient *http.Client, baseURL string) *Client {
 	return &Client{
-		token:      token,
-		httpClient: httpClient,
-		baseURL:    baseURL,
-		url:        fmt.Sprintf("%s/bot%s/", baseURL, token) + "%s",
+		token:        token,
+		httpClient:   httpClient,
+		uploadClient: httpClient,
+		baseURL:      baseURL,
+		url:          fmt.Sprintf("%s/bot%s/", baseURL, token) + "%s",
+		metrics:      nopMetrics{},
+		tracer:       nopTracer{},
 	}
 }
 
+// WithContext returns shallow copy of the client making Bot API calls with ctx,
+// e.g. client.WithContext(m.Context()).SendMessage(m.Chat.ID, "hi") from a message handler
+func (c *Client) WithContext(ctx context.Context) *Client {
+	cc := *c
+	cc.ctx = ctx
+	return &cc
+}
+
+func (c *Client) context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
 type inputFile struct {
 	field string
 	name  string
 }
 
-type sendOption func(url.Values)
-
 // Generic message options
 var (
-	OptParseModeHTML = func(r url.Values) {
+	OptParseModeHTML parseModeOption = func(r url.Values) {
 		r.Set("parse_mode", "HTML")
 	}
-	OptParseModeMarkdown = func(r url.Values) {
+	OptParseModeMarkdown parseModeOption = func(r url.Values) {
 		r.Set("parse_mode", "MarkdownV2")
 	}
-	OptDisableNotification = func(r url.Values) {
+	OptDisableNotification notificationOption = func(r url.Values) {
 		r.Set("disable_notification", "true")
 	}
-	OptReplyToMessageID = func(id int) sendOption {
+	OptReplyToMessageID = func(id int) replyOption {
 		return func(r url.Values) {
 			r.Set("reply_to_message_id", strconv.Itoa(id))
 		}
 	}
+	OptEntities = func(entities []*MessageEntity) textOption {
+		return func(r url.Values) {
+			setJSON(r, "entities", entities)
+		}
+	}
+	OptCaptionEntities = func(entities []*MessageEntity) captionOption {
+		return func(r url.Values) {
+			setJSON(r, "caption_entities", entities)
+		}
+	}
 )
 
-func structString(s interface{}) string {
-	str, _ := json.Marshal(s)
-	return string(str)
-}
-
 // GetMe returns info about bot as a User object
 func (c *Client) GetMe() (*User, error) {
 	me := &User{}
@@ -69,12 +99,29 @@ func (c *Client) GetMe() (*User, error) {
 	return me, err
 }
 
-type forceReply struct {
-	ForceReply bool `json:"force_reply"`
-	Selective  bool `json:"selective"`
+// LogOut logs out from the cloud Bot API server before launching the bot with a local server.
+// The bot can't log in back to the cloud server for 10 minutes after the call.
+func (c *Client) LogOut() error {
+	var ok bool
+	return c.doRequest("logOut", nil, &ok)
+}
+
+// Close closes the bot instance before moving it from one local Bot API server to another.
+// Delete the webhook before calling it. The server returns error 429 in the first 10 minutes after the bot is launched.
+func (c *Client) Close() error {
+	var ok bool
+	return c.doRequest("close", nil, &ok)
 }
 
-type replyKeyboardRemove struct {
+// ForceReply makes Telegram clients display a reply interface to the user
+type ForceReply struct {
+	ForceReply            bool   `json:"force_reply"`
+	InputFieldPlaceholder string `json:"input_field_placeholder,omitempty"`
+	Selective             bool   `json:"selective"`
+}
+
+// ReplyKeyboardRemove makes Telegram clients remove the current custom keyboard
+type ReplyKeyboardRemove struct {
 	RemoveKeyboard bool `json:"remove_keyboard"`
 	Selective      bool `json:"selective"`
 }
@@ -86,14 +133,20 @@ type InlineKeyboardMarkup struct {
 
 // InlineKeyboardButton represents one button of an inline keyboard
 type InlineKeyboardButton struct {
-	Text                         string    `json:"text"`
-	URL                          string    `json:"url,omitempty"`
-	LoginURL                     *LoginURL `json:"login_url,omitempty"`
-	CallbackData                 string    `json:"callback_data,omitempty"`
-	SwitchInlineQuery            *string   `json:"switch_inline_query,omitempty"`
-	SwitchInlineQueryCurrentChat *string   `json:"switch_inline_query_current_chat,omitempty"`
+	Text                         string        `json:"text"`
+	URL                          string        `json:"url,omitempty"`
+	LoginURL                     *LoginURL     `json:"login_url,omitempty"`
+	CallbackData                 string        `json:"callback_data,omitempty"`
+	SwitchInlineQuery            *string       `json:"switch_inline_query,omitempty"`
+	SwitchInlineQueryCurrentChat *string       `json:"switch_inline_query_current_chat,omitempty"`
+	CallbackGame                 *CallbackGame `json:"callback_game,omitempty"`
+	Pay                          bool          `json:"pay,omitempty"`
+	WebApp                       *WebAppInfo   `json:"web_app,omitempty"`
 }
 
+// CallbackGame is a placeholder for the game button, currently holds no information
+type CallbackGame struct{}
+
 // LoginURL is a property of InlineKeyboardButton for Seamless Login feature
 type LoginURL struct {
 	URL                string  `json:"url"`
@@ -104,29 +157,22 @@ type LoginURL struct {
 
 // ReplyKeyboardMarkup represents a custom keyboard with reply options
 type ReplyKeyboardMarkup struct {
-	Keyboard        [][]KeyboardButton `json:"keyboard"`
-	ResizeKeyboard  bool               `json:"resize_keyboard"`
-	OneTimeKeyboard bool               `json:"one_time_keyboard"`
-	Selective       bool               `json:"selective"`
-}
-
-// KeyboardButton represents one button of the reply keyboard
-type KeyboardButton struct {
-	Text            string                  `json:"text"`
-	RequestContact  bool                    `json:"request_contact"`
-	RequestLocation bool                    `json:"request_location"`
-	RequestPoll     *KeyboardButtonPollType `json:"request_poll,omitempty"`
+	Keyboard              [][]KeyboardButton `json:"keyboard"`
+	ResizeKeyboard        bool               `json:"resize_keyboard"`
+	OneTimeKeyboard       bool               `json:"one_time_keyboard"`
+	InputFieldPlaceholder string             `json:"input_field_placeholder,omitempty"`
+	Selective             bool               `json:"selective"`
 }
 
-// KeyboardButtonPollType represents type of a poll,
-// which is allowed to be created and sent when the corresponding button is pressed
-type KeyboardButtonPollType struct {
-	Type string `json:"type"`
-}
-
-func (c *Client) setWebhook(webhookURL string) error {
+func (c *Client) setWebhook(webhookURL string, allowedUpdates []string, secretToken string) error {
 	req := url.Values{}
 	req.Set("url", webhookURL)
+	if len(allowedUpdates) > 0 {
+		setJSON(req, "allowed_updates", allowedUpdates)
+	}
+	if secretToken != "" {
+		req.Set("secret_token", secretToken)
+	}
 	var set bool
 	return c.doRequest("setWebhook", req, &set)
 }
@@ -138,30 +184,40 @@ func (c *Client) deleteWebhook() error {
 
 // SendMessage options
 var (
-	OptDisableWebPagePreview = func(r url.Values) {
+	OptDisableWebPagePreview textOption = func(r url.Values) {
 		r.Set("disable_web_page_preview", "true")
 	}
-	OptInlineKeyboardMarkup = func(markup *InlineKeyboardMarkup) sendOption {
+	OptInlineKeyboardMarkup = func(markup *InlineKeyboardMarkup) inlineKeyboardOption {
 		return func(r url.Values) {
-			r.Set("reply_markup", structString(markup))
+			setJSON(r, "reply_markup", markup)
 		}
 	}
-	OptReplyKeyboardMarkup = func(markup *ReplyKeyboardMarkup) sendOption {
+	OptReplyKeyboardMarkup = func(markup *ReplyKeyboardMarkup) replyMarkupOption {
 		return func(r url.Values) {
-			r.Set("reply_markup", structString(markup))
+			setJSON(r, "reply_markup", markup)
 		}
 	}
-	OptReplyKeyboardRemove = func(r url.Values) {
-		r.Set("reply_markup", structString(&replyKeyboardRemove{RemoveKeyboard: true}))
+	OptReplyKeyboardRemove replyMarkupOption = func(r url.Values) {
+		setJSON(r, "reply_markup", &ReplyKeyboardRemove{RemoveKeyboard: true})
 	}
-	OptReplyKeyboardRemoveSelective = func(r url.Values) {
-		r.Set("reply_markup", structString(&replyKeyboardRemove{RemoveKeyboard: true, Selective: true}))
+	OptReplyKeyboardRemoveSelective replyMarkupOption = func(r url.Values) {
+		setJSON(r, "reply_markup", &ReplyKeyboardRemove{RemoveKeyboard: true, Selective: true})
 	}
-	OptForceReply = func(r url.Values) {
-		r.Set("reply_markup", structString(&forceReply{ForceReply: true}))
+	OptForceReply replyMarkupOption = func(r url.Values) {
+		setJSON(r, "reply_markup", &ForceReply{ForceReply: true})
 	}
-	OptForceReplySelective = func(r url.Values) {
-		r.Set("reply_markup", structString(&forceReply{ForceReply: true, Selective: true}))
+	OptForceReplySelective replyMarkupOption = func(r url.Values) {
+		setJSON(r, "reply_markup", &ForceReply{ForceReply: true, Selective: true})
+	}
+	OptReplyKeyboardRemoveMarkup = func(markup *ReplyKeyboardRemove) replyMarkupOption {
+		return func(r url.Values) {
+			setJSON(r, "reply_markup", markup)
+		}
+	}
+	OptForceReplyMarkup = func(markup *ForceReply) replyMarkupOption {
+		return func(r url.Values) {
+			setJSON(r, "reply_markup", markup)
+		}
 	}
 )
 
@@ -169,6 +225,7 @@ var (
 SendMessage sends message to telegram chat. Available options:
 	- OptParseModeHTML
 	- OptParseModeMarkdown
+	- OptEntities(entities []*MessageEntity)
 	- OptDisableWebPagePreview
 	- OptDisableNotification
 	- OptReplyToMessageID(id int)
@@ -178,13 +235,15 @@ SendMessage sends message to telegram chat. Available options:
 	- OptReplyKeyboardRemoveSelective
 	- OptForceReply
 	- OptForceReplySelective
+	- OptReplyKeyboardRemoveMarkup(markup *ReplyKeyboardRemove)
+	- OptForceReplyMarkup(markup *ForceReply)
 */
-func (c *Client) SendMessage(chatID string, text string, opts ...sendOption) (*Message, error) {
+func (c *Client) SendMessage(chatID ChatID, text string, opts ...SendMessageOption) (*Message, error) {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	req.Set("text", text)
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	msg := &Message{}
 	err := c.doRequest("sendMessage", req, msg)
@@ -195,32 +254,109 @@ func (c *Client) SendMessage(chatID string, text string, opts ...sendOption) (*M
 ForwardMessage forwards message from one chat to another. Available options:
 	- OptDisableNotification
 */
-func (c *Client) ForwardMessage(chatID, fromChatID string, messageID int, opts ...sendOption) (*Message, error) {
+func (c *Client) ForwardMessage(chatID, fromChatID ChatID, messageID int, opts ...ForwardMessageOption) (*Message, error) {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
-	req.Set("from_chat_id", fromChatID)
+	req.Set("chat_id", string(chatID))
+	req.Set("from_chat_id", string(fromChatID))
 	req.Set("message_id", strconv.Itoa(messageID))
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	msg := &Message{}
 	err := c.doRequest("forwardMessage", req, msg)
 	return msg, err
 }
 
+// MaxMessageIDs is the number of messages copied, forwarded or deleted by one request,
+// CopyMessagesBulk, ForwardMessagesBulk and DeleteMessagesBulk split longer lists into several requests
+const MaxMessageIDs = 100
+
+// CopyMessages options
+var (
+	OptRemoveCaption copyOption = func(r url.Values) {
+		r.Set("remove_caption", "true")
+	}
+)
+
+// BulkResult is the result of one request made by CopyMessagesBulk, ForwardMessagesBulk or DeleteMessagesBulk
+type BulkResult struct {
+	IDs  []int        // IDs of messages passed to the request
+	Sent []*MessageID // IDs of copied or forwarded messages
+	Err  error        // error of the request, messages of a failed request aren't processed
+}
+
+/*
+CopyMessagesBulk copies any number of messages with CopyMessages, MaxMessageIDs per request,
+in the order of their IDs. Messages which can't be found or copied are skipped.
+Requests stop at the first error, results of the requests made are returned along with the error,
+so a job can resume with messages of the failed request and those which aren't in the results.
+*/
+func (c *Client) CopyMessagesBulk(chatID, fromChatID ChatID, messageIDs []int, opts ...CopyMessagesOption) ([]BulkResult, error) {
+	return bulk(messageIDs, func(chunk []int) ([]*MessageID, error) {
+		return c.CopyMessages(chatID, fromChatID, chunk, opts...)
+	})
+}
+
+/*
+ForwardMessagesBulk forwards any number of messages with ForwardMessages, MaxMessageIDs per request,
+in the order of their IDs. Messages which can't be found or forwarded are skipped.
+Requests stop at the first error, results of the requests made are returned along with the error.
+*/
+func (c *Client) ForwardMessagesBulk(chatID, fromChatID ChatID, messageIDs []int, opts ...ForwardMessagesOption) ([]BulkResult, error) {
+	return bulk(messageIDs, func(chunk []int) ([]*MessageID, error) {
+		return c.ForwardMessages(chatID, fromChatID, chunk, opts...)
+	})
+}
+
+// bulk makes request for every chunk of message IDs until it fails
+func bulk(messageIDs []int, request func(chunk []int) ([]*MessageID, error)) ([]BulkResult, error) {
+	var results []BulkResult
+	for _, chunk := range chunkMessageIDs(messageIDs) {
+		sent, err := request(chunk)
+		results = append(results, BulkResult{IDs: chunk, Sent: sent, Err: err})
+		if err != nil {
+			return results, err
+		}
+	}
+	return results, nil
+}
+
+// chunkMessageIDs sorts and deduplicates message IDs, as Bot API requires, and splits them into chunks of MaxMessageIDs
+func chunkMessageIDs(messageIDs []int) [][]int {
+	sorted := make([]int, len(messageIDs))
+	copy(sorted, messageIDs)
+	sort.Ints(sorted)
+	var chunks [][]int
+	var chunk []int
+	for i, id := range sorted {
+		if i > 0 && id == sorted[i-1] {
+			continue
+		}
+		if len(chunk) == MaxMessageIDs {
+			chunks = append(chunks, chunk)
+			chunk = nil
+		}
+		chunk = append(chunk, id)
+	}
+	if len(chunk) > 0 {
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
 // SendAudio options
 var (
-	OptDuration = func(duration int) sendOption {
+	OptDuration = func(duration int) durationOption {
 		return func(r url.Values) {
 			r.Set("duration", strconv.Itoa(duration))
 		}
 	}
-	OptPerformer = func(performer string) sendOption {
+	OptPerformer = func(performer string) audioOption {
 		return func(r url.Values) {
 			r.Set("performer", performer)
 		}
 	}
-	OptTitle = func(title string) sendOption {
+	OptTitle = func(title string) audioOption {
 		return func(r url.Values) {
 			r.Set("title", title)
 		}
@@ -244,12 +380,12 @@ SendAudio sends pre-uploaded audio to the chat. Pass fileID of the uploaded file
 	- OptForceReply
 	- OptForceReplySelective
 */
-func (c *Client) SendAudio(chatID string, fileID string, opts ...sendOption) (*Message, error) {
+func (c *Client) SendAudio(chatID ChatID, fileID string, opts ...SendAudioOption) (*Message, error) {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	req.Set("audio", fileID)
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	msg := &Message{}
 	err := c.doRequest("sendAudio", req, msg)
@@ -273,11 +409,11 @@ SendAudioFile sends file contents as an audio to the chat. Pass filename to send
 	- OptForceReply
 	- OptForceReplySelective
 */
-func (c *Client) SendAudioFile(chatID string, filename string, opts ...sendOption) (*Message, error) {
+func (c *Client) SendAudioFile(chatID ChatID, filename string, opts ...SendAudioOption) (*Message, error) {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	msg := &Message{}
 	err := c.doRequestWithFiles("sendAudio", req, msg, inputFile{field: "audio", name: filename})
@@ -286,7 +422,7 @@ func (c *Client) SendAudioFile(chatID string, filename string, opts ...sendOptio
 
 // SendPhoto options
 var (
-	OptCaption = func(caption string) sendOption {
+	OptCaption = func(caption string) captionOption {
 		return func(r url.Values) {
 			r.Set("caption", caption)
 		}
@@ -296,6 +432,7 @@ var (
 /*
 SendPhoto sends pre-uploaded photo to the chat. Pass fileID of the photo. Available options:
 	- OptCaption(caption string)
+	- OptCaptionEntities(entities []*MessageEntity)
 	- OptParseModeHTML
 	- OptParseModeMarkdown
 	- OptDisableNotification
@@ -307,12 +444,12 @@ SendPhoto sends pre-uploaded photo to the chat. Pass fileID of the photo. Availa
 	- OptForceReply
 	- OptForceReplySelective
 */
-func (c *Client) SendPhoto(chatID string, fileID string, opts ...sendOption) (*Message, error) {
+func (c *Client) SendPhoto(chatID ChatID, fileID string, opts ...SendPhotoOption) (*Message, error) {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	req.Set("photo", fileID)
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	msg := &Message{}
 	err := c.doRequest("sendPhoto", req, msg)
@@ -322,6 +459,7 @@ func (c *Client) SendPhoto(chatID string, fileID string, opts ...sendOption) (*M
 /*
 SendPhotoFile sends photo file contents to the chat. Pass filename to send. Available options:
 	- OptCaption(caption string)
+	- OptCaptionEntities(entities []*MessageEntity)
 	- OptParseModeHTML
 	- OptParseModeMarkdown
 	- OptDisableNotification
@@ -333,11 +471,11 @@ SendPhotoFile sends photo file contents to the chat. Pass filename to send. Avai
 	- OptForceReply
 	- OptForceReplySelective
 */
-func (c *Client) SendPhotoFile(chatID string, filename string, opts ...sendOption) (*Message, error) {
+func (c *Client) SendPhotoFile(chatID ChatID, filename string, opts ...SendPhotoOption) (*Message, error) {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	msg := &Message{}
 	err := c.doRequestWithFiles("sendPhoto", req, msg, inputFile{field: "photo", name: filename})
@@ -358,12 +496,12 @@ SendDocument sends document to the chat. Pass fileID of the document. Available
 	- OptForceReply
 	- OptForceReplySelective
 */
-func (c *Client) SendDocument(chatID string, fileID string, opts ...sendOption) (*Message, error) {
+func (c *Client) SendDocument(chatID ChatID, fileID string, opts ...SendDocumentOption) (*Message, error) {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	req.Set("document", fileID)
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	msg := &Message{}
 	err := c.doRequest("sendDocument", req, msg)
@@ -384,11 +522,11 @@ SendDocumentFile sends document file contents to the chat. Pass filename to send
 	- OptForceReply
 	- OptForceReplySelective
 */
-func (c *Client) SendDocumentFile(chatID string, filename string, opts ...sendOption) (*Message, error) {
+func (c *Client) SendDocumentFile(chatID ChatID, filename string, opts ...SendDocumentOption) (*Message, error) {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	msg := &Message{}
 	err := c.doRequestWithFiles("sendDocument", req, msg, inputFile{field: "document", name: filename})
@@ -397,17 +535,17 @@ func (c *Client) SendDocumentFile(chatID string, filename string, opts ...sendOp
 
 // SendVideo options
 var (
-	OptWidth = func(width int) sendOption {
+	OptWidth = func(width int) sizeOption {
 		return func(r url.Values) {
 			r.Set("width", strconv.Itoa(width))
 		}
 	}
-	OptHeight = func(height int) sendOption {
+	OptHeight = func(height int) sizeOption {
 		return func(r url.Values) {
 			r.Set("height", strconv.Itoa(height))
 		}
 	}
-	OptSupportsStreaming = func(r url.Values) {
+	OptSupportsStreaming videoOption = func(r url.Values) {
 		r.Set("supports_streaming", "true")
 	}
 )
@@ -430,12 +568,12 @@ SendVideo sends pre-uploaded video to chat. Pass fileID of the uploaded video. A
 	- OptForceReply
 	- OptForceReplySelective
 */
-func (c *Client) SendVideo(chatID string, fileID string, opts ...sendOption) (*Message, error) {
+func (c *Client) SendVideo(chatID ChatID, fileID string, opts ...SendVideoOption) (*Message, error) {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	req.Set("video", fileID)
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	msg := &Message{}
 	err := c.doRequest("sendVideo", req, msg)
@@ -460,11 +598,11 @@ SendVideoFile sends video file contents to the chat. Pass filename to send. Avai
 	- OptForceReply
 	- OptForceReplySelective
 */
-func (c *Client) SendVideoFile(chatID string, filename string, opts ...sendOption) (*Message, error) {
+func (c *Client) SendVideoFile(chatID ChatID, filename string, opts ...SendVideoOption) (*Message, error) {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	msg := &Message{}
 	err := c.doRequestWithFiles("sendVideo", req, msg, inputFile{field: "video", name: filename})
@@ -473,7 +611,7 @@ func (c *Client) SendVideoFile(chatID string, filename string, opts ...sendOptio
 
 // SendAnimation options
 var (
-	OptThumb = func(filename string) sendOption {
+	OptThumb = func(filename string) thumbOption {
 		return func(v url.Values) {
 			v.Set("thumb", filename)
 		}
@@ -498,12 +636,12 @@ SendAnimation sends animation to chat. Pass fileID to send. Available options:
 	- OptForceReply
 	- OptForceReplySelective
 */
-func (c *Client) SendAnimation(chatID string, fileID string, opts ...sendOption) (*Message, error) {
+func (c *Client) SendAnimation(chatID ChatID, fileID string, opts ...SendAnimationOption) (*Message, error) {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	req.Set("animation", fileID)
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	msg := &Message{}
 	var err error
@@ -535,11 +673,11 @@ SendAnimationFile sends animation file contents to the chat. Pass filename to se
 	- OptForceReply
 	- OptForceReplySelective
 */
-func (c *Client) SendAnimationFile(chatID string, filename string, opts ...sendOption) (*Message, error) {
+func (c *Client) SendAnimationFile(chatID ChatID, filename string, opts ...SendAnimationOption) (*Message, error) {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	msg := &Message{}
 	files := []inputFile{{field: "animation", name: filename}}
@@ -567,12 +705,12 @@ SendVoice sends audio file as a voice message. Pass file_id of previously upload
 	- OptForceReply
 	- OptForceReplySelective
 */
-func (c *Client) SendVoice(chatID string, fileID string, opts ...sendOption) (*Message, error) {
+func (c *Client) SendVoice(chatID ChatID, fileID string, opts ...SendVoiceOption) (*Message, error) {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	req.Set("voice", fileID)
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	msg := &Message{}
 	err := c.doRequest("sendVoice", req, msg)
@@ -594,11 +732,11 @@ SendVoiceFile sends the audio file as a voice message. Pass filename to send. Av
 	- OptForceReply
 	- OptForceReplySelective
 */
-func (c *Client) SendVoiceFile(chatID string, filename string, opts ...sendOption) (*Message, error) {
+func (c *Client) SendVoiceFile(chatID ChatID, filename string, opts ...SendVoiceOption) (*Message, error) {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	msg := &Message{}
 	err := c.doRequestWithFiles("sendVoice", req, msg, inputFile{field: "voice", name: filename})
@@ -607,7 +745,7 @@ func (c *Client) SendVoiceFile(chatID string, filename string, opts ...sendOptio
 
 // SendVideoNote options
 var (
-	OptLength = func(length int) sendOption {
+	OptLength = func(length int) videoNoteOption {
 		return func(v url.Values) {
 			v.Set("length", fmt.Sprint(length))
 		}
@@ -628,12 +766,12 @@ SendVideoNote sends video note. Pass fileID of previously uploaded video note. A
 	- OptForceReply
 	- OptForceReplySelective
 */
-func (c *Client) SendVideoNote(chatID string, fileID string, opts ...sendOption) (*Message, error) {
+func (c *Client) SendVideoNote(chatID ChatID, fileID string, opts ...SendVideoNoteOption) (*Message, error) {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	req.Set("video_note", fileID)
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	msg := &Message{}
 	var err error
@@ -661,11 +799,11 @@ SendVideoNoteFile sends video note to chat. Pass filename to upload. Available o
 	- OptForceReply
 	- OptForceReplySelective
 */
-func (c *Client) SendVideoNoteFile(chatID string, filename string, opts ...sendOption) (*Message, error) {
+func (c *Client) SendVideoNoteFile(chatID ChatID, filename string, opts ...SendVideoNoteOption) (*Message, error) {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	files := []inputFile{{field: "video_note", name: filename}}
 	if len(req.Get("thumb")) > 0 {
@@ -713,23 +851,26 @@ type InputMediaVideo struct {
 
 func (InputMediaVideo) inputMedia() {}
 
+type sendMediaGroupRequest struct {
+	ChatID ChatID       `json:"chat_id"`
+	Media  []InputMedia `json:"media"`
+}
+
 // SendMediaGroup send a group of photos or videos as an album
-func (c *Client) SendMediaGroup(chatID string, media []InputMedia, opts ...sendOption) ([]*Message, error) {
-	req := url.Values{}
-	req.Set("chat_id", chatID)
-	m, _ := json.Marshal(media)
-	req.Set("media", string(m))
+func (c *Client) SendMediaGroup(chatID ChatID, media []InputMedia, opts ...SendMediaGroupOption) ([]*Message, error) {
+	req := &sendMediaGroupRequest{ChatID: chatID, Media: media}
+	values := url.Values{}
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(values)
 	}
 	var msgs []*Message
-	err := c.doRequest("sendMediaGroup", req, &msgs)
+	err := c.doJSONRequest("sendMediaGroup", req, values, &msgs)
 	return msgs, err
 }
 
 // SendLocation options
 var (
-	OptLivePeriod = func(period int) sendOption {
+	OptLivePeriod = func(period int) locationOption {
 		return func(v url.Values) {
 			v.Set("live_period", fmt.Sprint(period))
 		}
@@ -748,13 +889,13 @@ SendLocation sends point on the map to chat. Available options:
 	- OptForceReply
 	- OptForceReplySelective
 */
-func (c *Client) SendLocation(chatID string, latitude, longitude float64, opts ...sendOption) (*Message, error) {
+func (c *Client) SendLocation(chatID ChatID, latitude, longitude float64, opts ...SendLocationOption) (*Message, error) {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	req.Set("latitude", fmt.Sprint(latitude))
 	req.Set("longitude", fmt.Sprint(longitude))
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	msg := &Message{}
 	err := c.doRequest("sendLocation", req, msg)
@@ -765,14 +906,14 @@ func (c *Client) SendLocation(chatID string, latitude, longitude float64, opts .
 EditMessageLiveLocation edits location in message sent by the bot. Available options:
 	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
 */
-func (c *Client) EditMessageLiveLocation(chatID string, messageID int, latitude, longitude float64, opts ...sendOption) (*Message, error) {
+func (c *Client) EditMessageLiveLocation(chatID ChatID, messageID int, latitude, longitude float64, opts ...LiveLocationOption) (*Message, error) {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	req.Set("message_id", fmt.Sprint(messageID))
 	req.Set("latitude", fmt.Sprint(latitude))
 	req.Set("longitude", fmt.Sprint(longitude))
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	msg := &Message{}
 	err := c.doRequest("editMessageLiveLocation", req, msg)
@@ -783,13 +924,13 @@ func (c *Client) EditMessageLiveLocation(chatID string, messageID int, latitude,
 EditInlineMessageLiveLocation edits location in message sent via the bot (using inline mode). Available options:
 	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
 */
-func (c *Client) EditInlineMessageLiveLocation(inlineMessageID string, latitude, longitude float64, opts ...sendOption) error {
+func (c *Client) EditInlineMessageLiveLocation(inlineMessageID string, latitude, longitude float64, opts ...LiveLocationOption) error {
 	req := url.Values{}
 	req.Set("inline_message_id", inlineMessageID)
 	req.Set("latitude", fmt.Sprint(latitude))
 	req.Set("longitude", fmt.Sprint(longitude))
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	var edited bool
 	err := c.doRequest("editMessageLiveLocation", req, &edited)
@@ -800,12 +941,12 @@ func (c *Client) EditInlineMessageLiveLocation(inlineMessageID string, latitude,
 StopMessageLiveLocation stop updating a live location message sent by the bot. Available options:
 	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
 */
-func (c *Client) StopMessageLiveLocation(chatID string, messageID int, opts ...sendOption) (*Message, error) {
+func (c *Client) StopMessageLiveLocation(chatID ChatID, messageID int, opts ...LiveLocationOption) (*Message, error) {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	req.Set("message_id", fmt.Sprint(messageID))
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	msg := &Message{}
 	err := c.doRequest("stopMessageLiveLocation", req, msg)
@@ -816,11 +957,11 @@ func (c *Client) StopMessageLiveLocation(chatID string, messageID int, opts ...s
 StopInlineMessageLiveLocation stop updating a live location message sent via the bot (using inline mode). Available options:
 	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
 */
-func (c *Client) StopInlineMessageLiveLocation(inlineMessageID string, opts ...sendOption) error {
+func (c *Client) StopInlineMessageLiveLocation(inlineMessageID string, opts ...LiveLocationOption) error {
 	req := url.Values{}
 	req.Set("inline_message_id", inlineMessageID)
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	var stopped bool
 	return c.doRequest("stopMessageLiveLocation", req, &stopped)
@@ -828,12 +969,12 @@ func (c *Client) StopInlineMessageLiveLocation(inlineMessageID string, opts ...s
 
 // SendVenue options
 var (
-	OptFoursquareID = func(foursquareID string) sendOption {
+	OptFoursquareID = func(foursquareID string) venueOption {
 		return func(v url.Values) {
 			v.Set("foursquare_id", foursquareID)
 		}
 	}
-	OptFoursquareType = func(foursquareType string) sendOption {
+	OptFoursquareType = func(foursquareType string) venueOption {
 		return func(v url.Values) {
 			v.Set("foursquare_type", foursquareType)
 		}
@@ -853,15 +994,15 @@ SendVenue sends information about a venue. Available options:
 	- OptForceReply
 	- OptForceReplySelective
 */
-func (c *Client) SendVenue(chatID string, latitude, longitude float64, title, address string, opts ...sendOption) (*Message, error) {
+func (c *Client) SendVenue(chatID ChatID, latitude, longitude float64, title, address string, opts ...SendVenueOption) (*Message, error) {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	req.Set("latitude", fmt.Sprint(latitude))
 	req.Set("longitude", fmt.Sprint(longitude))
 	req.Set("title", title)
 	req.Set("address", address)
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	msg := &Message{}
 	err := c.doRequest("sendVenue", req, msg)
@@ -870,12 +1011,12 @@ func (c *Client) SendVenue(chatID string, latitude, longitude float64, title, ad
 
 // SendContact options
 var (
-	OptLastName = func(lastName string) sendOption {
+	OptLastName = func(lastName string) contactOption {
 		return func(v url.Values) {
 			v.Set("last_name", lastName)
 		}
 	}
-	OptVCard = func(vCard string) sendOption {
+	OptVCard = func(vCard string) contactOption {
 		return func(v url.Values) {
 			v.Set("vcard", vCard)
 		}
@@ -895,33 +1036,34 @@ SendContact sends phone contact. Available options:
 	- OptForceReply
 	- OptForceReplySelective
 */
-func (c *Client) SendContact(chatID, phoneNumber, firstName string, opts ...sendOption) (*Message, error) {
+func (c *Client) SendContact(chatID ChatID, phoneNumber, firstName string, opts ...SendContactOption) (*Message, error) {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	req.Set("phone_number", phoneNumber)
 	req.Set("first_name", firstName)
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	msg := &Message{}
 	err := c.doRequest("sendContact", req, msg)
 	return msg, err
 }
 
-type chatAction string
+// ChatAction is an action shown to users by SendChatAction
+type ChatAction string
 
 // Actions for SendChatAction
 const (
-	ActionTyping          chatAction = "typing"
-	ActionUploadPhoto     chatAction = "upload_photo"
-	ActionRecordVideo     chatAction = "record_video"
-	ActionUploadVideo     chatAction = "upload_video"
-	ActionRecordAudio     chatAction = "record_audio"
-	ActionUploadAudio     chatAction = "upload_audio"
-	ActionUploadDocument  chatAction = "upload_document"
-	ActionFindLocation    chatAction = "find_location"
-	ActionRecordVideoNote chatAction = "record_video_note"
-	ActionUploadVideoNote chatAction = "upload_video_note"
+	ActionTyping          ChatAction = "typing"
+	ActionUploadPhoto     ChatAction = "upload_photo"
+	ActionRecordVideo     ChatAction = "record_video"
+	ActionUploadVideo     ChatAction = "upload_video"
+	ActionRecordAudio     ChatAction = "record_audio"
+	ActionUploadAudio     ChatAction = "upload_audio"
+	ActionUploadDocument  ChatAction = "upload_document"
+	ActionFindLocation    ChatAction = "find_location"
+	ActionRecordVideoNote ChatAction = "record_video_note"
+	ActionUploadVideoNote ChatAction = "upload_video_note"
 )
 
 /*
@@ -937,9 +1079,9 @@ SendChatAction sends bot chat action. Available actions:
 	- ActionRecordVideoNote
 	- ActionUploadVideoNote
 */
-func (c *Client) SendChatAction(chatID string, action chatAction) error {
+func (c *Client) SendChatAction(chatID ChatID, action ChatAction) error {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	req.Set("action", string(action))
 	var sent bool
 	return c.doRequest("sendChatAction", req, &sent)
@@ -953,12 +1095,12 @@ type UserProfilePhotos struct {
 
 // GetUserProfilePhotos options
 var (
-	OptOffset = func(offset int) sendOption {
+	OptOffset = func(offset int) profilePhotosOption {
 		return func(v url.Values) {
 			v.Set("offset", fmt.Sprint(offset))
 		}
 	}
-	OptLimit = func(limit int) sendOption {
+	OptLimit = func(limit int) profilePhotosOption {
 		return func(v url.Values) {
 			v.Set("limit", fmt.Sprint(limit))
 		}
@@ -970,11 +1112,11 @@ GetUserProfilePhotos returs user's profile pictures. Available options:
 	- OptOffset(offset int)
 	- OptLimit(limit int)
 */
-func (c *Client) GetUserProfilePhotos(userID int, opts ...sendOption) (*UserProfilePhotos, error) {
+func (c *Client) GetUserProfilePhotos(userID int64, opts ...GetUserProfilePhotosOption) (*UserProfilePhotos, error) {
 	req := url.Values{}
 	req.Set("user_id", fmt.Sprint(userID))
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	photos := &UserProfilePhotos{}
 	err := c.doRequest("getUserProfilePhotos", req, photos)
@@ -991,6 +1133,7 @@ type File struct {
 
 /*
 GetFile returns File object by fileID.
+In local mode FilePath is an absolute path on the disk of the Bot API server.
 */
 func (c *Client) GetFile(fileID string) (*File, error) {
 	req := url.Values{}
@@ -1002,7 +1145,7 @@ func (c *Client) GetFile(fileID string) (*File, error) {
 
 // KickChatMember options
 var (
-	OptUntilDate = func(date time.Time) sendOption {
+	OptUntilDate = func(date time.Time) untilDateOption {
 		return func(v url.Values) {
 			v.Set("until_date", fmt.Sprint(date.Unix()))
 		}
@@ -1013,12 +1156,12 @@ var (
 KickChatMember kicks user from group, supergroup or channel. Available options:
 	- OptUntilDate(date time.Time)
 */
-func (c *Client) KickChatMember(chatID string, userID int, opts ...sendOption) error {
+func (c *Client) KickChatMember(chatID ChatID, userID int64, opts ...KickChatMemberOption) error {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	req.Set("user_id", fmt.Sprint(userID))
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	var kicked bool
 	return c.doRequest("kickChatMember", req, &kicked)
@@ -1027,29 +1170,32 @@ func (c *Client) KickChatMember(chatID string, userID int, opts ...sendOption) e
 /*
 UnbanChatMember unban a previously kicked user in a supergroup or channel
 */
-func (c *Client) UnbanChatMember(chatID string, userID int) error {
+func (c *Client) UnbanChatMember(chatID ChatID, userID int64) error {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	req.Set("user_id", fmt.Sprint(userID))
 	var unbanned bool
 	return c.doRequest("unbanChatMember", req, &unbanned)
 }
 
+type restrictChatMemberRequest struct {
+	ChatID      ChatID           `json:"chat_id"`
+	UserID      int64            `json:"user_id"`
+	Permissions *ChatPermissions `json:"permissions"`
+}
+
 /*
 RestrictChatMember restrict a user in a supergroup. Available options:
 	- OptUntilDate(date time.Time)
 */
-func (c *Client) RestrictChatMember(chatID string, userID int, perm *ChatPermissions, opts ...sendOption) error {
-	req := url.Values{}
-	req.Set("chat_id", chatID)
-	req.Set("user_id", fmt.Sprint(userID))
-	marshalledPermissions, _ := json.Marshal(perm)
-	req.Set("permissions", string(marshalledPermissions))
+func (c *Client) RestrictChatMember(chatID ChatID, userID int64, perm *ChatPermissions, opts ...RestrictChatMemberOption) error {
+	req := &restrictChatMemberRequest{ChatID: chatID, UserID: userID, Permissions: perm}
+	values := url.Values{}
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(values)
 	}
 	var restricted bool
-	return c.doRequest("restrictChatMember", req, &restricted)
+	return c.doJSONRequest("restrictChatMember", req, values, &restricted)
 }
 
 // Promotions give user permitions in a supergroup or channel.
@@ -1067,9 +1213,9 @@ type Promotions struct {
 /*
 PromoteChatMember promote or demote a user in a supergroup or a channel
 */
-func (c *Client) PromoteChatMember(chatID string, userID int, p *Promotions) error {
+func (c *Client) PromoteChatMember(chatID ChatID, userID int64, p *Promotions) error {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	req.Set("user_id", fmt.Sprint(userID))
 	req.Set("can_change_info", fmt.Sprint(p.CanChangeInfo))
 	req.Set("can_post_messages", fmt.Sprint(p.CanPostMessages))
@@ -1086,9 +1232,9 @@ func (c *Client) PromoteChatMember(chatID string, userID int, p *Promotions) err
 /*
 ExportChatInviteLink generate a new invite link for a chat; any previously generated link is revoked
 */
-func (c *Client) ExportChatInviteLink(chatID string) (string, error) {
+func (c *Client) ExportChatInviteLink(chatID ChatID) (string, error) {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	var link string
 	err := c.doRequest("exportChatInviteLink", req, &link)
 	return link, err
@@ -1097,9 +1243,9 @@ func (c *Client) ExportChatInviteLink(chatID string) (string, error) {
 /*
 SetChatPhoto set a new profile photo for the chat
 */
-func (c *Client) SetChatPhoto(chatID string, filename string) error {
+func (c *Client) SetChatPhoto(chatID ChatID, filename string) error {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	var updated bool
 	return c.doRequestWithFiles("setChatPhoto", req, &updated, inputFile{field: "photo", name: filename})
 }
@@ -1107,9 +1253,9 @@ func (c *Client) SetChatPhoto(chatID string, filename string) error {
 /*
 DeleteChatPhoto deleta a chat photo
 */
-func (c *Client) DeleteChatPhoto(chatID string) error {
+func (c *Client) DeleteChatPhoto(chatID ChatID) error {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	var deleted bool
 	return c.doRequest("deleteChatPhoto", req, &deleted)
 }
@@ -1117,9 +1263,9 @@ func (c *Client) DeleteChatPhoto(chatID string) error {
 /*
 SetChatTitle change the title of the chat
 */
-func (c *Client) SetChatTitle(chatID, title string) error {
+func (c *Client) SetChatTitle(chatID ChatID, title string) error {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	req.Set("title", title)
 	var set bool
 	return c.doRequest("setChatTitle", req, &set)
@@ -1128,9 +1274,9 @@ func (c *Client) SetChatTitle(chatID, title string) error {
 /*
 SetChatDescription change the description of a supergroup or a channel
 */
-func (c *Client) SetChatDescription(chatID, description string) error {
+func (c *Client) SetChatDescription(chatID ChatID, description string) error {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	req.Set("description", description)
 	var set bool
 	return c.doRequest("setChatDescription", req, &set)
@@ -1140,12 +1286,12 @@ func (c *Client) SetChatDescription(chatID, description string) error {
 PinChatMessage pin a message in a supergroup or a channel. Available options:
 	- OptDisableNotification
 */
-func (c *Client) PinChatMessage(chatID string, messageID int, opts ...sendOption) error {
+func (c *Client) PinChatMessage(chatID ChatID, messageID int, opts ...PinChatMessageOption) error {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	req.Set("message_id", fmt.Sprint(messageID))
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	var pinned bool
 	return c.doRequest("pinChatMessage", req, &pinned)
@@ -1154,9 +1300,9 @@ func (c *Client) PinChatMessage(chatID string, messageID int, opts ...sendOption
 /*
 UnpinChatMessage unpin a message in a supergroup or a channel
 */
-func (c *Client) UnpinChatMessage(chatID string) error {
+func (c *Client) UnpinChatMessage(chatID ChatID) error {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	var unpinned bool
 	return c.doRequest("unpinChatMessage", req, &unpinned)
 }
@@ -1164,9 +1310,9 @@ func (c *Client) UnpinChatMessage(chatID string) error {
 /*
 LeaveChat leave a group, supergroup or channel
 */
-func (c *Client) LeaveChat(chatID string) error {
+func (c *Client) LeaveChat(chatID ChatID) error {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	var left bool
 	return c.doRequest("leaveChat", req, &left)
 }
@@ -1174,43 +1320,20 @@ func (c *Client) LeaveChat(chatID string) error {
 /*
 GetChat get up to date information about the chat
 */
-func (c *Client) GetChat(chatID string) (*Chat, error) {
+func (c *Client) GetChat(chatID ChatID) (*Chat, error) {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	chat := &Chat{}
 	err := c.doRequest("getChat", req, chat)
 	return chat, err
 }
 
-// ChatMember contains information about one member of a chat
-type ChatMember struct {
-	User                  User   `json:"user"`
-	Status                string `json:"status"`
-	CustomTitle           string `json:"custom_title"`
-	UntilDate             int    `json:"until_date"`
-	CanBeEdited           bool   `json:"can_be_edited"`
-	CanChangeInfo         bool   `json:"can_change_info"`
-	CanPostMessages       bool   `json:"can_post_messages"`
-	CanEditMessages       bool   `json:"can_edit_messages"`
-	CanDeleteMessages     bool   `json:"can_delete_messages"`
-	CanInviteUsers        bool   `json:"can_invite_users"`
-	CanRestrictMembers    bool   `json:"can_restrict_members"`
-	CanPinMessages        bool   `json:"can_pin_messages"`
-	CanPromoteMembers     bool   `json:"can_promote_members"`
-	IsMember              bool   `json:"is_member"`
-	CanSendMessages       bool   `json:"can_send_messages"`
-	CanSendMediaMessages  bool   `json:"can_send_media_messages"`
-	CanSendOtherMessages  bool   `json:"can_send_other_messages"`
-	CanAddWebPagePreviews bool   `json:"can_add_web_page_previews"`
-	CanSendPolls          bool   `json:"can_send_polls"`
-}
-
 /*
 GetChatAdministrators get a list of administrators in a chat
 */
-func (c *Client) GetChatAdministrators(chatID string) ([]*ChatMember, error) {
+func (c *Client) GetChatAdministrators(chatID ChatID) ([]*ChatMember, error) {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	members := []*ChatMember{}
 	err := c.doRequest("getChatAdministrators", req, &members)
 	return members, err
@@ -1219,9 +1342,9 @@ func (c *Client) GetChatAdministrators(chatID string) ([]*ChatMember, error) {
 /*
 GetChatMembersCount returns the number of members in chat
 */
-func (c *Client) GetChatMembersCount(chatID string) (int, error) {
+func (c *Client) GetChatMembersCount(chatID ChatID) (int, error) {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	var count int
 	err := c.doRequest("getChatMembersCount", req, &count)
 	return count, err
@@ -1230,9 +1353,9 @@ func (c *Client) GetChatMembersCount(chatID string) (int, error) {
 /*
 GetChatMember get information about a member of a chat
 */
-func (c *Client) GetChatMember(chatID string, userID int) (*ChatMember, error) {
+func (c *Client) GetChatMember(chatID ChatID, userID int64) (*ChatMember, error) {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	req.Set("user_id", fmt.Sprint(userID))
 	member := &ChatMember{}
 	err := c.doRequest("getChatMember", req, member)
@@ -1242,9 +1365,9 @@ func (c *Client) GetChatMember(chatID string, userID int) (*ChatMember, error) {
 /*
 SetChatStickerSet set a new group sticker set for a supergroup
 */
-func (c *Client) SetChatStickerSet(chatID, stickerSetName string) error {
+func (c *Client) SetChatStickerSet(chatID ChatID, stickerSetName string) error {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	req.Set("sticker_set_name", stickerSetName)
 	var set bool
 	return c.doRequest("setChatStickerSet", req, &set)
@@ -1253,29 +1376,29 @@ func (c *Client) SetChatStickerSet(chatID, stickerSetName string) error {
 /*
 DeleteChatStickerSet delete a group sticker set from a supergroup
 */
-func (c *Client) DeleteChatStickerSet(chatID string) error {
+func (c *Client) DeleteChatStickerSet(chatID ChatID) error {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	var deleted bool
 	return c.doRequest("deleteChatStickerSet", req, &deleted)
 }
 
 // Options for AnswerCallbackQuery
 var (
-	OptText = func(text string) sendOption {
+	OptText = func(text string) callbackAnswerOption {
 		return func(v url.Values) {
 			v.Set("text", text)
 		}
 	}
-	OptShowAlert = func(v url.Values) {
+	OptShowAlert callbackAnswerOption = func(v url.Values) {
 		v.Set("show_alert", "true")
 	}
-	OptURL = func(u string) sendOption {
+	OptURL = func(u string) callbackAnswerOption {
 		return func(v url.Values) {
 			v.Set("url", u)
 		}
 	}
-	OptCacheTime = func(d time.Duration) sendOption {
+	OptCacheTime = func(d time.Duration) cacheTimeOption {
 		return func(v url.Values) {
 			v.Set("cache_time", fmt.Sprint(int(d.Seconds())))
 		}
@@ -1289,11 +1412,11 @@ AnswerCallbackQuery send answer to callback query sent from inline keyboard. Ava
 	- OptURL(url string)
 	- OptCacheTime(d time.Duration)
 */
-func (c *Client) AnswerCallbackQuery(callbackQueryID string, opts ...sendOption) error {
+func (c *Client) AnswerCallbackQuery(callbackQueryID string, opts ...AnswerCallbackQueryOption) error {
 	req := url.Values{}
 	req.Set("callback_query_id", callbackQueryID)
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	var success bool
 	return c.doRequest("answerCallbackQuery", req, &success)
@@ -1314,31 +1437,34 @@ func (c *Client) GetMyCommands() (*[]BotCommand, error) {
 	return botCommands, err
 }
 
+type setMyCommandsRequest struct {
+	Commands []BotCommand `json:"commands"`
+}
+
 /*
 SetMyCommands set the list of bot commands.
 */
 func (c *Client) SetMyCommands(commands []BotCommand) error {
-	req := url.Values{}
-	cmd, _ := json.Marshal(commands)
-	req.Set("commands", string(cmd))
+	req := &setMyCommandsRequest{Commands: commands}
 	var set bool
-	return c.doRequest("setMyCommands", req, &set)
+	return c.doJSONRequest("setMyCommands", req, nil, &set)
 }
 
 /*
 EditMessageText edit text and game messages sent by the bot. Available options:
 	- OptParseModeHTML
 	- OptParseModeMarkdown
+	- OptEntities(entities []*MessageEntity)
 	- OptDisableWebPagePreview
 	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
 */
-func (c *Client) EditMessageText(chatID string, messageID int, text string, opts ...sendOption) (*Message, error) {
+func (c *Client) EditMessageText(chatID ChatID, messageID int, text string, opts ...EditMessageTextOption) (*Message, error) {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	req.Set("message_id", fmt.Sprint(messageID))
 	req.Set("text", text)
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	msg := &Message{}
 	err := c.doRequest("editMessageText", req, msg)
@@ -1352,12 +1478,12 @@ EditInlineMessageText edit text and game messages sent via the bot (for inline b
 	- OptDisableWebPagePreview
 	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
 */
-func (c *Client) EditInlineMessageText(inlineMessageID, text string, opts ...sendOption) error {
+func (c *Client) EditInlineMessageText(inlineMessageID, text string, opts ...EditMessageTextOption) error {
 	req := url.Values{}
 	req.Set("inline_message_id", inlineMessageID)
 	req.Set("text", text)
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	var edited bool
 	return c.doRequest("editMessageText", req, &edited)
@@ -1369,13 +1495,13 @@ EditMessageCaption edit message caption sent by the bot. Available options:
 	- OptParseModeMarkdown
 	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
 */
-func (c *Client) EditMessageCaption(chatID string, messageID int, caption string, opts ...sendOption) (*Message, error) {
+func (c *Client) EditMessageCaption(chatID ChatID, messageID int, caption string, opts ...EditMessageCaptionOption) (*Message, error) {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	req.Set("message_id", fmt.Sprint(messageID))
 	req.Set("caption", caption)
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	msg := &Message{}
 	err := c.doRequest("editMessageCaption", req, msg)
@@ -1388,12 +1514,12 @@ EditInlineMessageCaption edit message caption sent via the bot (for inline bots)
 	- OptParseModeMarkdown
 	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
 */
-func (c *Client) EditInlineMessageCaption(inlineMessageID, caption string, opts ...sendOption) error {
+func (c *Client) EditInlineMessageCaption(inlineMessageID, caption string, opts ...EditMessageCaptionOption) error {
 	req := url.Values{}
 	req.Set("inline_message_id", inlineMessageID)
 	req.Set("caption", caption)
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	var edited bool
 	return c.doRequest("editMessageCaption", req, &edited)
@@ -1403,12 +1529,12 @@ func (c *Client) EditInlineMessageCaption(inlineMessageID, caption string, opts
 EditMessageReplyMarkup edit only the reply markup of messages sent by the bot. Available options:
 	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
 */
-func (c *Client) EditMessageReplyMarkup(chatID string, messageID int, opts ...sendOption) (*Message, error) {
+func (c *Client) EditMessageReplyMarkup(chatID ChatID, messageID int, opts ...EditMessageReplyMarkupOption) (*Message, error) {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	req.Set("message_id", fmt.Sprint(messageID))
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	msg := &Message{}
 	err := c.doRequest("editMessageReplyMarkup", req, msg)
@@ -1419,11 +1545,11 @@ func (c *Client) EditMessageReplyMarkup(chatID string, messageID int, opts ...se
 EditInlineMessageReplyMarkup edit only the reply markup of messages sent by the bot. Available options:
 	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
 */
-func (c *Client) EditInlineMessageReplyMarkup(inlineMessageID string, opts ...sendOption) error {
+func (c *Client) EditInlineMessageReplyMarkup(inlineMessageID string, opts ...EditMessageReplyMarkupOption) error {
 	req := url.Values{}
 	req.Set("inline_message_id", inlineMessageID)
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	var edited bool
 	return c.doRequest("editMessageReplyMarkup", req, &edited)
@@ -1432,14 +1558,25 @@ func (c *Client) EditInlineMessageReplyMarkup(inlineMessageID string, opts ...se
 /*
 DeleteMessage delete a message, including service messages
 */
-func (c *Client) DeleteMessage(chatID string, messageID int) error {
+func (c *Client) DeleteMessage(chatID ChatID, messageID int) error {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	req.Set("message_id", fmt.Sprint(messageID))
 	var deleted bool
 	return c.doRequest("deleteMessage", req, &deleted)
 }
 
+/*
+DeleteMessagesBulk deletes any number of messages with DeleteMessages, MaxMessageIDs per request.
+Messages which can't be found are skipped. Requests stop at the first error, results of the requests made
+are returned along with the error: messages of results without Err are deleted.
+*/
+func (c *Client) DeleteMessagesBulk(chatID ChatID, messageIDs []int) ([]BulkResult, error) {
+	return bulk(messageIDs, func(chunk []int) ([]*MessageID, error) {
+		return nil, c.DeleteMessages(chatID, chunk)
+	})
+}
+
 /*
 SendStickerFile send .webp file sticker. Available options:
 	- OptDisableNotification
@@ -1451,11 +1588,11 @@ SendStickerFile send .webp file sticker. Available options:
 	- OptForceReply
 	- OptForceReplySelective
 */
-func (c *Client) SendStickerFile(chatID string, filename string, opts ...sendOption) (*Message, error) {
+func (c *Client) SendStickerFile(chatID ChatID, filename string, opts ...SendStickerOption) (*Message, error) {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	msg := &Message{}
 	err := c.doRequestWithFiles("sendSticker", req, msg, inputFile{field: "sticker", name: filename})
@@ -1473,12 +1610,12 @@ SendSticker send previously uploaded sticker. Available options:
 	- OptForceReply
 	- OptForceReplySelective
 */
-func (c *Client) SendSticker(chatID, fileID string, opts ...sendOption) (*Message, error) {
+func (c *Client) SendSticker(chatID ChatID, fileID string, opts ...SendStickerOption) (*Message, error) {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	req.Set("sticker", fileID)
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	msg := &Message{}
 	err := c.doRequest("sendSticker", req, msg)
@@ -1509,7 +1646,7 @@ func (c *Client) GetStickerSet(name string) (*StickerSet, error) {
 /*
 UploadStickerFile upload a .png file with a sticker for later use in CreateNewStickerSet and AddStickerToSet
 */
-func (c *Client) UploadStickerFile(userID int, filename string) (*File, error) {
+func (c *Client) UploadStickerFile(userID int64, filename string) (*File, error) {
 	req := url.Values{}
 	req.Set("user_id", fmt.Sprint(userID))
 	file := &File{}
@@ -1519,16 +1656,15 @@ func (c *Client) UploadStickerFile(userID int, filename string) (*File, error) {
 
 // CreateNewStickerSet options
 var (
-	OptContainsMasks = func(v url.Values) {
+	OptContainsMasks stickerSetOption = func(v url.Values) {
 		v.Set("contains_masks", "true")
 	}
-	OptMaskPosition = func(pos *MaskPosition) sendOption {
+	OptMaskPosition = func(pos *MaskPosition) stickerOption {
 		return func(v url.Values) {
-			p, _ := json.Marshal(pos)
-			v.Set("mask_position", string(p))
+			setJSON(v, "mask_position", pos)
 		}
 	}
-	OptAnimatedSticker = func(v url.Values) {
+	OptAnimatedSticker stickerOption = func(v url.Values) {
 		v.Set("tgs_sticker", "true")
 	}
 )
@@ -1539,14 +1675,14 @@ CreateNewStickerSetFile creates new sticker set with sticker file. Available opt
 	- OptMaskPosition(pos *MaskPosition)
 	- OptAnimatedSticker
 */
-func (c *Client) CreateNewStickerSetFile(userID int, name, title, stickerFilename, emojis string, opts ...sendOption) error {
+func (c *Client) CreateNewStickerSetFile(userID int64, name, title, stickerFilename, emojis string, opts ...CreateNewStickerSetOption) error {
 	req := url.Values{}
 	req.Set("user_id", fmt.Sprint(userID))
 	req.Set("name", name)
 	req.Set("title", title)
 	req.Set("emojis", emojis)
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	stickerFile := inputFile{name: stickerFilename}
 	if len(req.Get("tgs_sticker")) > 0 {
@@ -1564,7 +1700,7 @@ CreateNewStickerSet creates new sticker set with previously uploaded file. Avail
 	- OptContainsMasks
 	- OptMaskPosition(pos *MaskPosition)
 */
-func (c *Client) CreateNewStickerSet(userID int, name, title, fileID, emojis string, opts ...sendOption) error {
+func (c *Client) CreateNewStickerSet(userID int64, name, title, fileID, emojis string, opts ...CreateNewStickerSetOption) error {
 	req := url.Values{}
 	req.Set("user_id", fmt.Sprint(userID))
 	req.Set("name", name)
@@ -1572,7 +1708,7 @@ func (c *Client) CreateNewStickerSet(userID int, name, title, fileID, emojis str
 	req.Set("png_sticker", fileID)
 	req.Set("emojis", emojis)
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	var created bool
 	return c.doRequest("createNewStickerSet", req, &created)
@@ -1583,13 +1719,13 @@ AddStickerToSetFile add a new sticker file to a set created by the bot. Availabl
 	- OptMaskPosition(pos *MaskPosition)
 	- OptAnimatedSticker
 */
-func (c *Client) AddStickerToSetFile(userID int, name, filename, emojis string, opts ...sendOption) error {
+func (c *Client) AddStickerToSetFile(userID int64, name, filename, emojis string, opts ...AddStickerToSetOption) error {
 	req := url.Values{}
 	req.Set("user_id", fmt.Sprint(userID))
 	req.Set("name", name)
 	req.Set("emojis", emojis)
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	stickerFile := inputFile{name: filename}
 	if len(req.Get("tgs_sticker")) > 0 {
@@ -1606,14 +1742,14 @@ func (c *Client) AddStickerToSetFile(userID int, name, filename, emojis string,
 AddStickerToSet add a new sticker to a set created by the bot. Available options:
 	- OptMaskPosition(pos *MaskPosition)
 */
-func (c *Client) AddStickerToSet(userID int, name, fileID, emojis string, opts ...sendOption) error {
+func (c *Client) AddStickerToSet(userID int64, name, fileID, emojis string, opts ...AddStickerToSetOption) error {
 	req := url.Values{}
 	req.Set("user_id", fmt.Sprint(userID))
 	req.Set("name", name)
 	req.Set("png_sticker", fileID)
 	req.Set("emojis", emojis)
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	var added bool
 	return c.doRequestWithFiles("addStickerToSet", req, &added)
@@ -1643,7 +1779,7 @@ func (c *Client) DeleteStickerFromSet(fileID string) error {
 /*
 SetStickerSetThumb sets the thumbnail of a sticker set with a previously uploaded file.
 */
-func (c *Client) SetStickerSetThumb(userID int, name, thumb string) error {
+func (c *Client) SetStickerSetThumb(userID int64, name, thumb string) error {
 	req := url.Values{}
 	req.Set("user_id", fmt.Sprint(userID))
 	req.Set("name", name)
@@ -1655,7 +1791,7 @@ func (c *Client) SetStickerSetThumb(userID int, name, thumb string) error {
 /*
 SetStickerSetThumbFile sets the thumbnail of a sticker set with thumbnail file.
 */
-func (c *Client) SetStickerSetThumbFile(userID int, name, thumbnailFilename string) error {
+func (c *Client) SetStickerSetThumbFile(userID int64, name, thumbnailFilename string) error {
 	req := url.Values{}
 	req.Set("user_id", fmt.Sprint(userID))
 	req.Set("name", name)
@@ -2062,26 +2198,31 @@ func (InlineQueryResultCachedAudio) inlineQueryResult() {}
 
 // AnswerInlineQuery options
 var (
-	OptIsPersonal = func(v url.Values) {
+	OptIsPersonal inlineAnswerOption = func(v url.Values) {
 		v.Set("is_personal", "true")
 	}
-	OptNextOffset = func(offset string) sendOption {
+	OptNextOffset = func(offset string) inlineAnswerOption {
 		return func(v url.Values) {
 			v.Set("next_offset", offset)
 		}
 	}
-	OptSwitchPmText = func(text string) sendOption {
+	OptSwitchPmText = func(text string) inlineAnswerOption {
 		return func(v url.Values) {
 			v.Set("switch_pm_text", text)
 		}
 	}
-	OptSwitchPmParameter = func(param string) sendOption {
+	OptSwitchPmParameter = func(param string) inlineAnswerOption {
 		return func(v url.Values) {
 			v.Set("switch_pm_parameter", param)
 		}
 	}
 )
 
+type answerInlineQueryRequest struct {
+	InlineQueryID string              `json:"inline_query_id"`
+	Results       []InlineQueryResult `json:"results"`
+}
+
 /*
 AnswerInlineQuery send answer to an inline query. No more than 50 results per query are allowed. Available Options:
 	- OptCacheTime(d *time.Duration)
@@ -2090,16 +2231,14 @@ AnswerInlineQuery send answer to an inline query. No more than 50 results per qu
 	- OptSwitchPmText(text string)
 	- OptSwitchPmParameter(param string)
 */
-func (c *Client) AnswerInlineQuery(inlineQueryID string, results []InlineQueryResult, opts ...sendOption) error {
-	req := url.Values{}
-	req.Set("inline_query_id", inlineQueryID)
-	res, _ := json.Marshal(results)
-	req.Set("results", string(res))
+func (c *Client) AnswerInlineQuery(inlineQueryID string, results []InlineQueryResult, opts ...AnswerInlineQueryOption) error {
+	req := &answerInlineQueryRequest{InlineQueryID: inlineQueryID, Results: results}
+	values := url.Values{}
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(values)
 	}
 	var answered bool
-	return c.doRequest("answerInlineQuery", req, &answered)
+	return c.doJSONRequest("answerInlineQuery", req, values, &answered)
 }
 
 // LabeledPrice represents a portion of the price for goods or services
@@ -2110,40 +2249,51 @@ type LabeledPrice struct {
 
 // SendInvoice options
 var (
-	OptProviderData = func(data string) sendOption {
+	OptProviderData = func(data string) invoiceOption {
 		return func(v url.Values) {
 			v.Set("provider_data", data)
 		}
 	}
-	OptPhotoURL = func(u string) sendOption {
+	OptPhotoURL = func(u string) invoiceOption {
 		return func(v url.Values) {
 			v.Set("photo_url", u)
 		}
 	}
-	OptPhotoSize = func(size int) sendOption {
+	OptPhotoSize = func(size int) invoiceOption {
 		return func(v url.Values) {
 			v.Set("photo_size", fmt.Sprint(size))
 		}
 	}
-	OptPhotoWidth = func(width int) sendOption {
+	OptPhotoWidth = func(width int) invoiceOption {
 		return func(v url.Values) {
 			v.Set("photo_width", fmt.Sprint(width))
 		}
 	}
-	OptPhotoHeight = func(height int) sendOption {
+	OptPhotoHeight = func(height int) invoiceOption {
 		return func(v url.Values) {
 			v.Set("photo_height", fmt.Sprint(height))
 		}
 	}
-	OptNeedName                  = func(v url.Values) { v.Set("need_name", "true") }
-	OptNeedPhoneNumber           = func(v url.Values) { v.Set("need_phone_number", "true") }
-	OptNeedEmail                 = func(v url.Values) { v.Set("need_email", "true") }
-	OptNeedShippingAddress       = func(v url.Values) { v.Set("need_shipping_address", "true") }
-	OptSendPhoneNumberToProvider = func(v url.Values) { v.Set("send_phone_number_to_provider", "true") }
-	OptSendEmailToProvider       = func(v url.Values) { v.Set("send_email_to_provider", "true") }
-	OptIsFlexible                = func(v url.Values) { v.Set("is_flexible", "true") }
+	OptNeedName                  invoiceOption = func(v url.Values) { v.Set("need_name", "true") }
+	OptNeedPhoneNumber           invoiceOption = func(v url.Values) { v.Set("need_phone_number", "true") }
+	OptNeedEmail                 invoiceOption = func(v url.Values) { v.Set("need_email", "true") }
+	OptNeedShippingAddress       invoiceOption = func(v url.Values) { v.Set("need_shipping_address", "true") }
+	OptSendPhoneNumberToProvider invoiceOption = func(v url.Values) { v.Set("send_phone_number_to_provider", "true") }
+	OptSendEmailToProvider       invoiceOption = func(v url.Values) { v.Set("send_email_to_provider", "true") }
+	OptIsFlexible                invoiceOption = func(v url.Values) { v.Set("is_flexible", "true") }
 )
 
+type sendInvoiceRequest struct {
+	ChatID         ChatID         `json:"chat_id"`
+	Title          string         `json:"title"`
+	Description    string         `json:"description"`
+	Payload        string         `json:"payload"`
+	ProviderToken  string         `json:"provider_token"`
+	StartParameter string         `json:"start_parameter"`
+	Currency       string         `json:"currency"`
+	Prices         []LabeledPrice `json:"prices"`
+}
+
 /*
 SendInvoice send invoices. Available Options:
 	- OptProviderData(data string)
@@ -2162,22 +2312,23 @@ SendInvoice send invoices. Available Options:
 	- OptReplyToMessageID(id int)
 	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
 */
-func (c *Client) SendInvoice(chatID, payload, providerToken string, invoice *Invoice, prices []LabeledPrice, opts ...sendOption) (*Message, error) {
-	req := url.Values{}
-	req.Set("chat_id", chatID)
-	req.Set("title", invoice.Title)
-	req.Set("description", invoice.Description)
-	req.Set("payload", payload)
-	req.Set("provider_token", providerToken)
-	req.Set("start_parameter", invoice.StartParameter)
-	req.Set("currency", invoice.Currency)
-	pr, _ := json.Marshal(prices)
-	req.Set("prices", string(pr))
+func (c *Client) SendInvoice(chatID ChatID, payload, providerToken string, invoice *Invoice, prices []LabeledPrice, opts ...SendInvoiceOption) (*Message, error) {
+	req := &sendInvoiceRequest{
+		ChatID:         chatID,
+		Title:          invoice.Title,
+		Description:    invoice.Description,
+		Payload:        payload,
+		ProviderToken:  providerToken,
+		StartParameter: invoice.StartParameter,
+		Currency:       invoice.Currency,
+		Prices:         prices,
+	}
+	values := url.Values{}
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(values)
 	}
 	msg := &Message{}
-	err := c.doRequest("sendInvoice", req, msg)
+	err := c.doJSONRequest("sendInvoice", req, values, msg)
 	return msg, err
 }
 
@@ -2190,13 +2341,12 @@ type ShippingOption struct {
 
 // AnswerShippingQuery options
 var (
-	OptShippingOptions = func(options []ShippingOption) sendOption {
+	OptShippingOptions = func(options []ShippingOption) shippingAnswerOption {
 		return func(v url.Values) {
-			op, _ := json.Marshal(options)
-			v.Set("shipping_options", string(op))
+			setJSON(v, "shipping_options", options)
 		}
 	}
-	OptErrorMessage = func(msg string) sendOption {
+	OptErrorMessage = func(msg string) errorMessageOption {
 		return func(v url.Values) {
 			v.Set("error_message", msg)
 		}
@@ -2208,12 +2358,12 @@ AnswerShippingQuery reply to shipping queries. Available options:
 	- OptShippingOptions(options []ShippingOption)
 	- OptErrorMessage(msg string)
 */
-func (c *Client) AnswerShippingQuery(shippingQueryID string, ok bool, opts ...sendOption) error {
+func (c *Client) AnswerShippingQuery(shippingQueryID string, ok bool, opts ...AnswerShippingQueryOption) error {
 	req := url.Values{}
 	req.Set("shipping_query_id", shippingQueryID)
 	req.Set("ok", fmt.Sprint(ok))
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	var answered bool
 	return c.doRequest("answerShippingQuery", req, &answered)
@@ -2223,12 +2373,12 @@ func (c *Client) AnswerShippingQuery(shippingQueryID string, ok bool, opts ...se
 AnswerPreCheckoutQuery respond to pre-checkout queries. Available options:
 	- OptErrorMessage(msg string)
 */
-func (c *Client) AnswerPreCheckoutQuery(preCheckoutQueryID string, ok bool, opts ...sendOption) error {
+func (c *Client) AnswerPreCheckoutQuery(preCheckoutQueryID string, ok bool, opts ...AnswerPreCheckoutQueryOption) error {
 	req := url.Values{}
 	req.Set("pre_checkout_query_id", preCheckoutQueryID)
 	req.Set("ok", fmt.Sprint(ok))
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	var answered bool
 	return c.doRequest("answerPreCheckoutQuery", req, &answered)
@@ -2309,16 +2459,18 @@ type PassportElementErrorFiles struct {
 
 func (PassportElementErrorFiles) passportElementError() {}
 
+type setPassportDataErrorsRequest struct {
+	UserID int64                  `json:"user_id"`
+	Errors []PassportElementError `json:"errors"`
+}
+
 /*
 SetPassportDataErrors informs a user that some of the Telegram Passport elements they provided contains errors
 */
-func (c *Client) SetPassportDataErrors(userID int, errors []PassportElementError) error {
-	req := url.Values{}
-	req.Set("user_id", fmt.Sprint(userID))
-	errs, _ := json.Marshal(errors)
-	req.Set("errors", string(errs))
+func (c *Client) SetPassportDataErrors(userID int64, errors []PassportElementError) error {
+	req := &setPassportDataErrorsRequest{UserID: userID, Errors: errors}
 	var set bool
-	return c.doRequest("setPassportDataErrors", req, &set)
+	return c.doJSONRequest("setPassportDataErrors", req, nil, &set)
 }
 
 /*
@@ -2327,12 +2479,12 @@ SendGame send a game. Available options:
 	- OptReplyToMessageID(id int)
 	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
 */
-func (c *Client) SendGame(chatID, gameShortName string, opts ...sendOption) (*Message, error) {
+func (c *Client) SendGame(chatID ChatID, gameShortName string, opts ...SendGameOption) (*Message, error) {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	req.Set("game_short_name", gameShortName)
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	msg := &Message{}
 	err := c.doRequest("sendGame", req, msg)
@@ -2341,10 +2493,10 @@ func (c *Client) SendGame(chatID, gameShortName string, opts ...sendOption) (*Me
 
 // SetGameScore options
 var (
-	OptForce = func(v url.Values) {
+	OptForce gameScoreOption = func(v url.Values) {
 		v.Set("force", "true")
 	}
-	OptDisableEditMessage = func(v url.Values) {
+	OptDisableEditMessage gameScoreOption = func(v url.Values) {
 		v.Set("disable_edit_message", "true")
 	}
 )
@@ -2354,14 +2506,14 @@ SetGameScore set the score of the specified user in a game. Available options:
 	- OptForce
 	- OptDisableEditMessage
 */
-func (c *Client) SetGameScore(chatID string, messageID, userID, score int, opts ...sendOption) (*Message, error) {
+func (c *Client) SetGameScore(chatID ChatID, messageID int, userID int64, score int, opts ...SetGameScoreOption) (*Message, error) {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	req.Set("message_id", fmt.Sprint(messageID))
 	req.Set("user_id", fmt.Sprint(userID))
 	req.Set("score", fmt.Sprint(score))
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	msg := &Message{}
 	err := c.doRequest("setGameScore", req, msg)
@@ -2373,13 +2525,13 @@ SetInlineGameScore set the score of the specified user in a game (for inline mes
 	- OptForce
 	- OptDisableEditMessage
 */
-func (c *Client) SetInlineGameScore(inlineMessageID string, userID, score int, opts ...sendOption) error {
+func (c *Client) SetInlineGameScore(inlineMessageID string, userID int64, score int, opts ...SetGameScoreOption) error {
 	req := url.Values{}
 	req.Set("inline_message_id", inlineMessageID)
 	req.Set("user_id", fmt.Sprint(userID))
 	req.Set("score", fmt.Sprint(score))
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	var set bool
 	return c.doRequest("setGameScore", req, &set)
@@ -2395,9 +2547,9 @@ type GameHighScore struct {
 /*
 GetGameHighScores get data for high score tables
 */
-func (c *Client) GetGameHighScores(chatID string, messageID, userID int) ([]*GameHighScore, error) {
+func (c *Client) GetGameHighScores(chatID ChatID, messageID int, userID int64) ([]*GameHighScore, error) {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	req.Set("message_id", fmt.Sprint(messageID))
 	req.Set("user_id", fmt.Sprint(userID))
 	var scores []*GameHighScore
@@ -2408,7 +2560,7 @@ func (c *Client) GetGameHighScores(chatID string, messageID, userID int) ([]*Gam
 /*
 GetInlineGameHighScores get data for high score tables
 */
-func (c *Client) GetInlineGameHighScores(inlineMessageID string, userID int) ([]*GameHighScore, error) {
+func (c *Client) GetInlineGameHighScores(inlineMessageID string, userID int64) ([]*GameHighScore, error) {
 	req := url.Values{}
 	req.Set("inline_message_id", inlineMessageID)
 	req.Set("user_id", fmt.Sprint(userID))
@@ -2428,27 +2580,33 @@ const (
 
 // SendPoll options
 var (
-	OptNotAnonymous = func(u url.Values) {
+	OptNotAnonymous newPollOption = func(u url.Values) {
 		u.Set("is_anonymous", "false")
 	}
-	OptPollType = func(pollType PollType) sendOption {
+	OptPollType = func(pollType PollType) newPollOption {
 		return func(u url.Values) {
 			u.Set("type", string(pollType))
 		}
 	}
-	OptAllowMultipleAnswers = func(u url.Values) {
+	OptAllowMultipleAnswers newPollOption = func(u url.Values) {
 		u.Set("allows_multiple_answers", "true")
 	}
-	OptCorrectOptionID = func(id int) sendOption {
+	OptCorrectOptionID = func(id int) newPollOption {
 		return func(u url.Values) {
 			u.Set("correct_option_id", fmt.Sprint(id))
 		}
 	}
-	OptClosedPoll = func(u url.Values) {
+	OptClosedPoll newPollOption = func(u url.Values) {
 		u.Set("is_closed", "true")
 	}
 )
 
+type sendPollRequest struct {
+	ChatID   ChatID   `json:"chat_id"`
+	Question string   `json:"question"`
+	Options  []string `json:"options"`
+}
+
 /*
 SendPoll sends native telegram poll. Available Options:
 	- OptNotAnonymous
@@ -2465,17 +2623,14 @@ SendPoll sends native telegram poll. Available Options:
 	- OptForceReply
 	- OptForceReplySelective
 */
-func (c *Client) SendPoll(chatID string, question string, options []string, opts ...sendOption) (*Message, error) {
-	req := url.Values{}
-	req.Set("chat_id", chatID)
-	req.Set("question", question)
-	marshalledOptions, _ := json.Marshal(options)
-	req.Set("options", string(marshalledOptions))
+func (c *Client) SendPoll(chatID ChatID, question string, options []string, opts ...SendPollOption) (*Message, error) {
+	req := &sendPollRequest{ChatID: chatID, Question: question, Options: options}
+	values := url.Values{}
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(values)
 	}
 	msg := &Message{}
-	err := c.doRequest("sendPoll", req, msg)
+	err := c.doJSONRequest("sendPoll", req, values, msg)
 	return msg, err
 }
 
@@ -2490,12 +2645,12 @@ SendDice sends native telegram dice. Available Options:
 	- OptForceReply
 	- OptForceReplySelective
 */
-func (c *Client) SendDice(chatID string, emoji string, opts ...sendOption) (*Dice, error) {
+func (c *Client) SendDice(chatID ChatID, emoji string, opts ...SendDiceOption) (*Dice, error) {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
+	req.Set("chat_id", string(chatID))
 	req.Set("emoji", emoji)
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	dice := &Dice{}
 	err := c.doRequest("sendDice", req, dice)
@@ -2506,12 +2661,12 @@ func (c *Client) SendDice(chatID string, emoji string, opts ...sendOption) (*Dic
 StopPoll stops poll. Available Options:
 	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
 */
-func (c *Client) StopPoll(chatID string, messageID string, opts ...sendOption) (*Poll, error) {
+func (c *Client) StopPoll(chatID ChatID, messageID int, opts ...StopPollOption) (*Poll, error) {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
-	req.Set("message_id", messageID)
+	req.Set("chat_id", string(chatID))
+	req.Set("message_id", fmt.Sprint(messageID))
 	for _, opt := range opts {
-		opt(req)
+		opt.apply(req)
 	}
 	poll := &Poll{}
 	err := c.doRequest("stopPoll", req, poll)
@@ -2521,25 +2676,18 @@ func (c *Client) StopPoll(chatID string, messageID string, opts ...sendOption) (
 /*
 SetChatAdministratorCustomTitle set a custom title for an administrator in a supergroup promoted by the bot.
 */
-func (c *Client) SetChatAdministratorCustomTitle(chatID string, userID string, customTitle string) error {
+func (c *Client) SetChatAdministratorCustomTitle(chatID ChatID, userID int64, customTitle string) error {
 	req := url.Values{}
-	req.Set("chat_id", chatID)
-	req.Set("user_id", userID)
+	req.Set("chat_id", string(chatID))
+	req.Set("user_id", fmt.Sprint(userID))
 	req.Set("custom_title", customTitle)
 	var set bool
 	return c.doRequest("setChatAdministratorCustomTitle", req, &set)
 }
 
-// ChatPermissions describes actions that a non-administrator user is allowed to take in a chat.
-type ChatPermissions struct {
-	CanSendMessages       bool `json:"can_send_messages,omitempty"`         // True, if the user is allowed to send text messages, contacts, locations and venues
-	CanSendMediaMessages  bool `json:"can_send_media_messages,omitempty"`   // True, if the user is allowed to send audios, documents, photos, videos, video notes and voice notes, implies can_send_messages
-	CanSendPolls          bool `json:"can_send_polls,omitempty"`            // True, if the user is allowed to send polls, implies can_send_messages
-	CanSendOtherMessages  bool `json:"can_send_other_messages,omitempty"`   // True, if the user is allowed to send animations, games, stickers and use inline bots, implies can_send_media_messages
-	CanAddWebPagePreviews bool `json:"can_add_web_page_previews,omitempty"` // True, if the user is allowed to add web page previews to their messages, implies can_send_media_messages
-	CanChangeInfo         bool `json:"can_change_info,omitempty"`           // True, if the user is allowed to change the chat title, photo and other settings. Ignored in public supergroups
-	CanInviteUsers        bool `json:"can_invite_users,omitempty"`          // True, if the user is allowed to invite new users to the chat
-	CanPinMessages        bool `json:"can_pin_messages,omitempty"`          // True, if the user is allowed to pin messages. Ignored in public supergroups
+type setChatPermissionsRequest struct {
+	ChatID      ChatID           `json:"chat_id"`
+	Permissions *ChatPermissions `json:"permissions"`
 }
 
 /*
@@ -2547,11 +2695,8 @@ SetChatPermissions set default chat permissions for all members.
 The bot must be an administrator in the group or a supergroup
 for this to work and must have the can_restrict_members admin rights.
 */
-func (c *Client) SetChatPermissions(chatID string, permissions *ChatPermissions) error {
-	req := url.Values{}
-	req.Set("chat_id", chatID)
-	marshalledPermissions, _ := json.Marshal(permissions)
-	req.Set("permissions", string(marshalledPermissions))
+func (c *Client) SetChatPermissions(chatID ChatID, permissions *ChatPermissions) error {
+	req := &setChatPermissionsRequest{ChatID: chatID, Permissions: permissions}
 	var set bool
-	return c.doRequest("setChatPermissions", req, &set)
+	return c.doJSONRequest("setChatPermissions", req, nil, &set)
 }
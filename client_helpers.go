@@ -1,8 +1,113 @@
 package tbot
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+)
 
-// FileURL returns file URL ready for download
+// FileURL returns file URL ready for download.
+// The URL contains the bot token, don't show it to users or write it to logs,
+// use DownloadFile or FileHandler instead. In local mode it's file:// URI of the file on the server disk.
 func (c *Client) FileURL(file *File) string {
+	if p, ok := c.localPath(file); ok {
+		return FileURI(p)
+	}
 	return fmt.Sprintf("%s/file/bot%s/%s", c.baseURL, c.token, file.FilePath)
 }
+
+// DownloadFile returns content of the file with given fileID, the caller must close it
+func (c *Client) DownloadFile(fileID string) (io.ReadCloser, error) {
+	file, err := c.GetFile(fileID)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.downloadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (c *Client) downloadFile(file *File) (*http.Response, error) {
+	if p, ok := c.localPath(file); ok {
+		return openLocalFile(p)
+	}
+	if file.FileSize > MaxDownloadSize {
+		return nil, fmt.Errorf("unable to download file: file is larger than %d MB, use local mode", MaxDownloadSize>>20)
+	}
+	req, err := http.NewRequest(http.MethodGet, c.FileURL(file), nil)
+	if err != nil {
+		return nil, redactToken(err, c.token)
+	}
+	resp, err := c.httpClient.Do(req.WithContext(c.context()))
+	if err != nil {
+		return nil, redactToken(fmt.Errorf("unable to download file: %v", err), c.token)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unable to download file: unexpected status code: %s", resp.Status)
+	}
+	return resp, nil
+}
+
+/*
+FileHandler returns http.Handler proxying file downloads, so file URLs with the bot token are never exposed.
+The last element of the request path is the file ID:
+
+	http.Handle("/files/", bot.Client().FileHandler(func(r *http.Request, fileID string) bool {
+		// check the user sending the request may see the file
+		return files.Owner(fileID) == session.User(r)
+	}))
+	// GET /files/<file_id>
+
+The handler downloads any file the bot can access, so every request must be authorized:
+authorize is called with the request and the file ID before the download,
+the request is rejected with 403 Forbidden if it returns false.
+FileHandler panics if authorize is nil.
+*/
+func (c *Client) FileHandler(authorize func(r *http.Request, fileID string) bool) http.Handler {
+	if authorize == nil {
+		panic("tbot: FileHandler requires authorize function")
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		fileID := path.Base(r.URL.Path)
+		if !authorize(r, fileID) {
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+		client := c.WithContext(r.Context())
+		file, err := client.GetFile(fileID)
+		if err != nil {
+			client.logger.warn("unable to get file", "method", "getFile", "error", err)
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		resp, err := client.downloadFile(file)
+		if err != nil {
+			client.logger.warn("unable to download file", "error", err)
+			http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+			return
+		}
+		defer resp.Body.Close()
+		if ct := resp.Header.Get("Content-Type"); ct != "" {
+			w.Header().Set("Content-Type", ct)
+		}
+		if resp.ContentLength >= 0 {
+			w.Header().Set("Content-Length", strconv.FormatInt(resp.ContentLength, 10))
+		}
+		if r.Method == http.MethodHead {
+			return
+		}
+		_, err = io.Copy(w, resp.Body)
+		if err != nil {
+			client.logger.warn("unable to proxy file", "error", err)
+		}
+	})
+}
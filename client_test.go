@@ -2,11 +2,15 @@ package tbot_test
 
 import (
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/yanzay/tbot/v2"
+	"github.com/yanzay/tbot/v2/tbottest"
 )
 
 const token = "TOKEN"
@@ -94,6 +98,77 @@ func TestForwardMessage(t *testing.T) {
 	}
 }
 
+func TestCopyMessage(t *testing.T) {
+	api := tbottest.NewServer(token)
+	defer api.Close()
+	c := api.Client()
+
+	orig, err := c.SendPhoto("1", "AgAD1", tbot.OptCaption("original"))
+	if err != nil {
+		t.Fatalf("unable to send photo: %v", err)
+	}
+	kb := &tbot.InlineKeyboardMarkup{InlineKeyboard: [][]tbot.InlineKeyboardButton{{{Text: "ok", CallbackData: "ok"}}}}
+	id, err := c.CopyMessage("2", "1", orig.MessageID, tbot.OptCaption("<b>copy</b>"), tbot.OptParseModeHTML, tbot.OptInlineKeyboardMarkup(kb))
+	if err != nil {
+		t.Fatalf("unable to copy message: %v", err)
+	}
+	if id.MessageID == 0 {
+		t.Errorf("empty message id")
+	}
+	params := api.CallsTo("copyMessage")[0].Params
+	if params.Get("from_chat_id") != "1" || params.Get("caption") != "<b>copy</b>" || params.Get("parse_mode") != "HTML" ||
+		params.Get("reply_markup") != `{"inline_keyboard":[[{"text":"ok","callback_data":"ok"}]]}` {
+		t.Errorf("unexpected params %v", params)
+	}
+}
+
+func TestBulkMessages(t *testing.T) {
+	api := tbottest.NewServer(token)
+	defer api.Close()
+	c := api.Client()
+
+	var ids []int
+	for i := 0; i < 150; i++ {
+		msg, err := c.SendMessage("1", fmt.Sprint(i))
+		if err != nil {
+			t.Fatalf("unable to send message: %v", err)
+		}
+		ids = append([]int{msg.MessageID}, ids...)
+	}
+	ids = append(ids, ids[0])
+
+	forwarded, err := c.ForwardMessagesBulk("2", "1", ids, tbot.OptDisableNotification)
+	if err != nil {
+		t.Fatalf("unable to forward messages: %v", err)
+	}
+	calls := api.CallsTo("forwardMessages")
+	if len(forwarded) != 2 || len(forwarded[0].IDs) != 100 || len(forwarded[0].Sent)+len(forwarded[1].Sent) != 150 ||
+		len(calls) != 2 || calls[1].Params.Get("disable_notification") != "true" {
+		t.Errorf("unexpected forwarded messages %v, calls %v", forwarded, calls)
+	}
+	copied, err := c.CopyMessagesBulk("3", "1", ids[:3], tbot.OptRemoveCaption)
+	if err != nil || len(copied) != 1 || len(copied[0].Sent) != 3 {
+		t.Errorf("unexpected copied messages %v, error %v", copied, err)
+	}
+	if params := api.CallsTo("copyMessages")[0].Params; params.Get("message_ids") != "[148,149,150]" || params.Get("remove_caption") != "true" {
+		t.Errorf("unexpected params %v", params)
+	}
+
+	api.FailNext("deleteMessages", &tbottest.APIError{Code: 400, Description: "Bad Request: message can't be deleted"})
+	deleted, err := c.DeleteMessagesBulk("1", ids)
+	if err == nil || len(deleted) != 1 || deleted[0].Err == nil || deleted[0].IDs[0] != 1 {
+		t.Fatalf("expected the first request to fail, got %v, error %v", deleted, err)
+	}
+	deleted, err = c.DeleteMessagesBulk("1", ids)
+	if err != nil || len(deleted) != 2 || deleted[1].Err != nil || len(deleted[1].IDs) != 50 {
+		t.Fatalf("unable to delete messages: %v, error %v", deleted, err)
+	}
+	forwarded, err = c.ForwardMessagesBulk("2", "1", ids)
+	if err != nil || len(forwarded[0].Sent)+len(forwarded[1].Sent) != 0 {
+		t.Errorf("deleted messages must be skipped, got %v, error %v", forwarded, err)
+	}
+}
+
 func TestSendAudio(t *testing.T) {
 	c := testClient(t, `
 		{
@@ -186,3 +261,102 @@ func testClient(t *testing.T, resp string) *tbot.Client {
 	httpClient := httpServer.Client()
 	return tbot.NewClient(token, httpClient, httpServer.URL)
 }
+
+func TestErrorRedactsToken(t *testing.T) {
+	c := tbot.NewClient("123456:SECRET", http.DefaultClient, "http://127.0.0.1:1")
+	_, err := c.GetMe()
+	if err == nil {
+		t.Fatalf("expected connection error")
+	}
+	if strings.Contains(err.Error(), "SECRET") {
+		t.Errorf("error contains token: %v", err)
+	}
+}
+
+func TestFileHandler(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/bot"+token+"/getFile", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("file_id") != "abc" {
+			fmt.Fprint(w, `{"ok": false, "error_code": 400, "description": "Bad Request: invalid file_id"}`)
+			return
+		}
+		fmt.Fprint(w, `{"ok": true, "result": {"file_id": "abc", "file_path": "photos/1.jpg"}}`)
+	})
+	mux.HandleFunc("/file/bot"+token+"/photos/1.jpg", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/jpeg")
+		fmt.Fprint(w, "jpeg data")
+	})
+	api := httptest.NewServer(mux)
+	defer api.Close()
+	c := tbot.NewClient(token, api.Client(), api.URL)
+
+	proxy := httptest.NewServer(http.StripPrefix("/files/", c.FileHandler(func(r *http.Request, fileID string) bool {
+		return fileID != "private"
+	})))
+	defer proxy.Close()
+	resp, err := http.Get(proxy.URL + "/files/abc")
+	if err != nil {
+		t.Fatalf("unable to get file: %v", err)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || string(body) != "jpeg data" || resp.Header.Get("Content-Type") != "image/jpeg" {
+		t.Errorf("unexpected response: %s %q %s", resp.Status, body, resp.Header.Get("Content-Type"))
+	}
+
+	resp, err = http.Get(proxy.URL + "/files/unknown")
+	if err != nil {
+		t.Fatalf("unable to get file: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected 404, got %s", resp.Status)
+	}
+
+	resp, err = http.Get(proxy.URL + "/files/private")
+	if err != nil {
+		t.Fatalf("unable to get file: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("expected 403, got %s", resp.Status)
+	}
+}
+
+func TestCreateForumTopic(t *testing.T) {
+	api := tbottest.NewServer(token)
+	defer api.Close()
+	api.Handle("createForumTopic", func(call tbottest.Call) (interface{}, *tbottest.APIError) {
+		return map[string]interface{}{"message_thread_id": 7, "name": call.Params.Get("name"), "icon_color": 7322096}, nil
+	})
+	c := api.Client()
+
+	topic, err := c.CreateForumTopic(tbot.NewChatID(-100123), "Support", tbot.OptIconColor(7322096))
+	if err != nil {
+		t.Fatalf("unable to create forum topic: %v", err)
+	}
+	if topic.MessageThreadID != 7 || topic.Name != "Support" || topic.IconColor != 7322096 {
+		t.Errorf("unexpected topic %+v", topic)
+	}
+	params := api.CallsTo("createForumTopic")[0].Params
+	if params.Get("chat_id") != "-100123" || params.Get("icon_color") != "7322096" {
+		t.Errorf("unexpected params %v", params)
+	}
+}
+
+func TestBanChatMember(t *testing.T) {
+	api := tbottest.NewServer(token)
+	defer api.Close()
+	c := api.Client()
+
+	until := time.Unix(1700000000, 0)
+	err := c.BanChatMember("@channel", 5123456789, tbot.OptUntilDate(until), tbot.OptRevokeMessages)
+	if err != nil {
+		t.Fatalf("unable to ban chat member: %v", err)
+	}
+	params := api.CallsTo("banChatMember")[0].Params
+	if params.Get("chat_id") != "@channel" || params.Get("user_id") != "5123456789" ||
+		params.Get("until_date") != "1700000000" || params.Get("revoke_messages") != "true" {
+		t.Errorf("unexpected params %v", params)
+	}
+}
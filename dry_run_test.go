@@ -0,0 +1,78 @@
+package tbot_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/yanzay/tbot/v2"
+	"github.com/yanzay/tbot/v2/tbottest"
+)
+
+func TestDryRun(t *testing.T) {
+	api := tbottest.NewServer(token)
+	defer api.Close()
+	logger := &testLogger{}
+	bot := tbot.New(token, tbot.WithBaseURL(api.URL()), tbot.WithHTTPClient(api.HTTPClient()),
+		tbot.WithStructuredLogger(logger), tbot.WithDryRun(),
+		tbot.WithDryRunFixture("getChat", &tbot.Chat{ID: "1", Type: "private"}))
+	c := bot.Client()
+
+	msg, err := c.SendMessage("1", "hello", tbot.OptDisableNotification)
+	if err != nil {
+		t.Fatalf("unable to send message: %v", err)
+	}
+	if msg.MessageID != 1 || msg.Chat.ID != "1" || msg.Text != "hello" || msg.Date == 0 {
+		t.Errorf("unexpected message %+v", msg)
+	}
+	edited, err := c.EditMessageText("1", msg.MessageID, "bye")
+	if err != nil || edited.MessageID != 1 || edited.Text != "bye" {
+		t.Errorf("unexpected edited message %+v, error %v", edited, err)
+	}
+	msgs, err := c.SendMediaGroup("-100", []tbot.InputMedia{
+		&tbot.InputMediaPhoto{Type: "photo", Media: "AgAD1"},
+		&tbot.InputMediaPhoto{Type: "photo", Media: "AgAD2"},
+	})
+	if err != nil || len(msgs) != 2 || msgs[1].MessageID != 3 || msgs[1].Chat.ID != "-100" {
+		t.Errorf("unexpected media group %v, error %v", msgs, err)
+	}
+	copied, err := c.CopyMessage("2", "1", msg.MessageID)
+	if err != nil || copied.MessageID != 4 {
+		t.Errorf("copy must get a new message id, got %+v, error %v", copied, err)
+	}
+	err = c.DeleteMessage("1", msg.MessageID)
+	if err != nil {
+		t.Errorf("unable to delete message: %v", err)
+	}
+	link, err := c.ExportChatInviteLink("-100")
+	if err != nil || link != "https://t.me/+dryrun" {
+		t.Errorf("unexpected invite link %q, error %v", link, err)
+	}
+	var raw json.RawMessage
+	err = c.Call(context.Background(), "setMyDescription", map[string]string{"description": "bot"}, &raw)
+	if err != nil || string(raw) != "true" {
+		t.Errorf("unexpected Call result %s, error %v", raw, err)
+	}
+	if err = c.Call(context.Background(), "setMyShortDescription", nil, nil); err != nil {
+		t.Errorf("unable to call setMyShortDescription: %v", err)
+	}
+
+	me, err := c.GetMe()
+	if err != nil || me.ID == 0 {
+		t.Errorf("getMe must be sent, got %+v, error %v", me, err)
+	}
+	chat, err := c.GetChat("1")
+	if err != nil || chat.Type != "private" {
+		t.Errorf("unexpected chat %+v, error %v", chat, err)
+	}
+
+	calls := api.Calls()
+	if len(calls) != 1 || calls[0].Method != "getMe" {
+		t.Errorf("only getMe must be sent, got %v", calls)
+	}
+	entry, ok := logger.find("dry run")
+	if !ok || entry.level != tbot.LevelInfo || entry.fields["method"] != "sendMessage" ||
+		entry.fields["request"] != "chat_id=1&disable_notification=true&text=hello" {
+		t.Errorf("unexpected dry run log %+v", entry)
+	}
+}
@@ -0,0 +1,108 @@
+package tbot
+
+import "unicode/utf16"
+
+// Message entity types
+const (
+	EntityMention       = "mention"
+	EntityHashtag       = "hashtag"
+	EntityCashtag       = "cashtag"
+	EntityBotCommand    = "bot_command"
+	EntityURL           = "url"
+	EntityEmail         = "email"
+	EntityPhoneNumber   = "phone_number"
+	EntityBold          = "bold"
+	EntityItalic        = "italic"
+	EntityUnderline     = "underline"
+	EntityStrikethrough = "strikethrough"
+	EntitySpoiler       = "spoiler"
+	EntityCode          = "code"
+	EntityPre           = "pre"
+	EntityTextLink      = "text_link"
+	EntityTextMention   = "text_mention"
+)
+
+// EntityText returns the part of the message text covered by the entity.
+// Entity offsets are measured in UTF-16 code units, EntityText takes care of the conversion.
+func (m *Message) EntityText(e *MessageEntity) string {
+	return entityText(m.Text, e)
+}
+
+// CaptionEntityText returns the part of the message caption covered by the entity.
+func (m *Message) CaptionEntityText(e *MessageEntity) string {
+	return entityText(m.Caption, e)
+}
+
+// EntitiesOfType returns message text entities of given type, e.g. EntityURL
+func (m *Message) EntitiesOfType(entityType string) []*MessageEntity {
+	var entities []*MessageEntity
+	for _, e := range m.Entities {
+		if e.Type == entityType {
+			entities = append(entities, e)
+		}
+	}
+	return entities
+}
+
+// Mentions returns all @username mentions from the message text
+func (m *Message) Mentions() []string {
+	return m.entityTexts(EntityMention)
+}
+
+// Hashtags returns all #hashtags from the message text
+func (m *Message) Hashtags() []string {
+	return m.entityTexts(EntityHashtag)
+}
+
+// Commands returns all /commands from the message text
+func (m *Message) Commands() []string {
+	return m.entityTexts(EntityBotCommand)
+}
+
+// URLs returns all URLs from the message text, both plain and hidden behind text links
+func (m *Message) URLs() []string {
+	var urls []string
+	for _, e := range m.Entities {
+		switch e.Type {
+		case EntityURL:
+			urls = append(urls, m.EntityText(e))
+		case EntityTextLink:
+			urls = append(urls, e.URL)
+		}
+	}
+	return urls
+}
+
+// TextMentions returns users mentioned in the message text without a username
+func (m *Message) TextMentions() []*User {
+	var users []*User
+	for _, e := range m.EntitiesOfType(EntityTextMention) {
+		if e.User != nil {
+			users = append(users, e.User)
+		}
+	}
+	return users
+}
+
+func (m *Message) entityTexts(entityType string) []string {
+	var texts []string
+	for _, e := range m.EntitiesOfType(entityType) {
+		texts = append(texts, m.EntityText(e))
+	}
+	return texts
+}
+
+func entityText(text string, e *MessageEntity) string {
+	if e == nil || e.Offset < 0 || e.Length <= 0 {
+		return ""
+	}
+	encoded := utf16.Encode([]rune(text))
+	if e.Offset >= len(encoded) {
+		return ""
+	}
+	end := e.Offset + e.Length
+	if end > len(encoded) {
+		end = len(encoded)
+	}
+	return string(utf16.Decode(encoded[e.Offset:end]))
+}
@@ -0,0 +1,29 @@
+package tbot_test
+
+import (
+	"testing"
+
+	"github.com/yanzay/tbot/v2"
+)
+
+func TestEntityText(t *testing.T) {
+	m := &tbot.Message{
+		Text: "😀 @user see https://example.com and docs",
+		Entities: []*tbot.MessageEntity{
+			{Type: tbot.EntityMention, Offset: 3, Length: 5},
+			{Type: tbot.EntityURL, Offset: 13, Length: 19},
+			{Type: tbot.EntityTextLink, Offset: 37, Length: 4, URL: "https://docs.example.com"},
+		},
+	}
+	if got := m.EntityText(m.Entities[0]); got != "@user" {
+		t.Fatalf("wrong mention: %q", got)
+	}
+	mentions := m.Mentions()
+	if len(mentions) != 1 || mentions[0] != "@user" {
+		t.Fatalf("wrong mentions: %v", mentions)
+	}
+	urls := m.URLs()
+	if len(urls) != 2 || urls[0] != "https://example.com" || urls[1] != "https://docs.example.com" {
+		t.Fatalf("wrong urls: %v", urls)
+	}
+}
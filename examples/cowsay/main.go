@@ -7,6 +7,7 @@ import (
 	"unicode/utf8"
 
 	"github.com/yanzay/tbot/v2"
+	"github.com/yanzay/tbot/v2/format"
 )
 
 func main() {
@@ -15,7 +16,7 @@ func main() {
 	c := bot.Client()
 	bot.HandleMessage("cowsay .+", func(m *tbot.Message) {
 		text := strings.TrimPrefix(m.Text, "cowsay ")
-		cow := fmt.Sprintf("```\n%s\n```", cowsay(text))
+		cow := format.NewMarkdownV2().Pre(cowsay(text), "").String()
 		c.SendMessage(m.Chat.ID, cow, tbot.OptParseModeMarkdown)
 	})
 	bot.Start()
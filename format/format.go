@@ -0,0 +1,222 @@
+/*
+Package format builds formatted message text with correct escaping.
+
+A Builder produces either HTML, MarkdownV2 or plain text with an entities array:
+
+	b := format.NewHTML().
+		Text("Hello, ").
+		Mention(m.From.FirstName, m.From.ID).
+		Text("! Read the ").
+		Link("docs", "https://example.com?a=1&b=2")
+	c.SendMessage(m.Chat.ID, b.String(), tbot.OptParseModeHTML)
+
+	b := format.NewPlain().Bold("plain ").Code("text")
+	c.SendMessage(m.Chat.ID, b.String(), tbot.OptEntities(b.Entities()))
+
+User input passed to any Builder method is escaped, so it can't break the markup.
+*/
+package format
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/yanzay/tbot/v2"
+)
+
+// Mode is a text formatting mode
+type Mode int
+
+// Available formatting modes
+const (
+	ModePlain Mode = iota
+	ModeHTML
+	ModeMarkdownV2
+)
+
+// Builder builds formatted text, see package documentation for examples
+type Builder struct {
+	mode     Mode
+	buf      strings.Builder
+	length   int
+	entities []*tbot.MessageEntity
+}
+
+// NewHTML returns Builder producing text for HTML parse mode
+func NewHTML() *Builder {
+	return &Builder{mode: ModeHTML}
+}
+
+// NewMarkdownV2 returns Builder producing text for MarkdownV2 parse mode
+func NewMarkdownV2() *Builder {
+	return &Builder{mode: ModeMarkdownV2}
+}
+
+// NewPlain returns Builder producing plain text and an entities array,
+// use it with tbot.OptEntities
+func NewPlain() *Builder {
+	return &Builder{mode: ModePlain}
+}
+
+// Mode returns formatting mode of the builder
+func (b *Builder) Mode() Mode {
+	return b.mode
+}
+
+// String returns the formatted text
+func (b *Builder) String() string {
+	return b.buf.String()
+}
+
+// Entities returns entities for the text built in ModePlain, nil for other modes
+func (b *Builder) Entities() []*tbot.MessageEntity {
+	return b.entities
+}
+
+// Text appends text without formatting
+func (b *Builder) Text(s string) *Builder {
+	switch b.mode {
+	case ModeHTML:
+		b.buf.WriteString(EscapeHTML(s))
+	case ModeMarkdownV2:
+		b.buf.WriteString(EscapeMarkdownV2(s))
+	default:
+		b.writePlain(s)
+	}
+	return b
+}
+
+// Bold appends bold text
+func (b *Builder) Bold(s string) *Builder {
+	return b.wrap(s, tbot.EntityBold, "b", "*")
+}
+
+// Italic appends italic text
+func (b *Builder) Italic(s string) *Builder {
+	return b.wrap(s, tbot.EntityItalic, "i", "_")
+}
+
+// Underline appends underlined text
+func (b *Builder) Underline(s string) *Builder {
+	return b.wrap(s, tbot.EntityUnderline, "u", "__")
+}
+
+// Strikethrough appends strikethrough text
+func (b *Builder) Strikethrough(s string) *Builder {
+	return b.wrap(s, tbot.EntityStrikethrough, "s", "~")
+}
+
+// Code appends inline monospace text
+func (b *Builder) Code(s string) *Builder {
+	switch b.mode {
+	case ModeHTML:
+		b.buf.WriteString("<code>" + EscapeHTML(s) + "</code>")
+	case ModeMarkdownV2:
+		b.buf.WriteString("`" + escapeMarkdownV2Code(s) + "`")
+	default:
+		b.writeEntity(s, &tbot.MessageEntity{Type: tbot.EntityCode})
+	}
+	return b
+}
+
+// Pre appends pre-formatted code block, language is optional
+func (b *Builder) Pre(code, language string) *Builder {
+	switch b.mode {
+	case ModeHTML:
+		if language == "" {
+			b.buf.WriteString("<pre>" + EscapeHTML(code) + "</pre>")
+		} else {
+			b.buf.WriteString(fmt.Sprintf(`<pre><code class="language-%s">%s</code></pre>`,
+				EscapeHTML(language), EscapeHTML(code)))
+		}
+	case ModeMarkdownV2:
+		b.buf.WriteString("```" + escapeMarkdownV2Code(language) + "\n" + escapeMarkdownV2Code(code) + "\n```")
+	default:
+		b.writeEntity(code, &tbot.MessageEntity{Type: tbot.EntityPre, Language: language})
+	}
+	return b
+}
+
+// Link appends text linked to the URL
+func (b *Builder) Link(text, url string) *Builder {
+	switch b.mode {
+	case ModeHTML:
+		b.buf.WriteString(`<a href="` + EscapeHTML(url) + `">` + EscapeHTML(text) + "</a>")
+	case ModeMarkdownV2:
+		b.buf.WriteString("[" + EscapeMarkdownV2(text) + "](" + escapeMarkdownV2URL(url) + ")")
+	default:
+		b.writeEntity(text, &tbot.MessageEntity{Type: tbot.EntityTextLink, URL: url})
+	}
+	return b
+}
+
+// Mention appends text mentioning the user by ID, works for users without username
+func (b *Builder) Mention(text string, userID int64) *Builder {
+	if b.mode == ModePlain {
+		return b.writeEntity(text, &tbot.MessageEntity{Type: tbot.EntityTextMention, User: &tbot.User{ID: userID}})
+	}
+	return b.Link(text, fmt.Sprintf("tg://user?id=%d", userID))
+}
+
+func (b *Builder) wrap(s, entityType, tag, marker string) *Builder {
+	switch b.mode {
+	case ModeHTML:
+		b.buf.WriteString("<" + tag + ">" + EscapeHTML(s) + "</" + tag + ">")
+	case ModeMarkdownV2:
+		b.buf.WriteString(marker + EscapeMarkdownV2(s) + marker)
+	default:
+		b.writeEntity(s, &tbot.MessageEntity{Type: entityType})
+	}
+	return b
+}
+
+func (b *Builder) writeEntity(s string, e *tbot.MessageEntity) *Builder {
+	e.Offset = b.length
+	e.Length = utf16Len(s)
+	if e.Length > 0 {
+		b.entities = append(b.entities, e)
+	}
+	b.writePlain(s)
+	return b
+}
+
+func (b *Builder) writePlain(s string) {
+	b.buf.WriteString(s)
+	b.length += utf16Len(s)
+}
+
+func utf16Len(s string) int {
+	return len(utf16.Encode([]rune(s)))
+}
+
+var htmlReplacer = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+
+// EscapeHTML escapes text for HTML parse mode
+func EscapeHTML(s string) string {
+	return htmlReplacer.Replace(s)
+}
+
+// EscapeMarkdownV2 escapes text for MarkdownV2 parse mode
+func EscapeMarkdownV2(s string) string {
+	return escape(s, "\\_*[]()~`>#+-=|{}.!")
+}
+
+func escapeMarkdownV2Code(s string) string {
+	return escape(s, "\\`")
+}
+
+func escapeMarkdownV2URL(s string) string {
+	return escape(s, "\\)")
+}
+
+func escape(s, chars string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(chars, r) {
+			sb.WriteByte('\\')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
@@ -0,0 +1,51 @@
+package format_test
+
+import (
+	"testing"
+
+	"github.com/yanzay/tbot/v2/format"
+)
+
+func TestHTML(t *testing.T) {
+	got := format.NewHTML().
+		Text("a<b & ").
+		Bold("bold").
+		Link("link", "https://example.com?a=1&b=2").
+		Mention("user", 42).
+		String()
+	want := `a&lt;b &amp; <b>bold</b><a href="https://example.com?a=1&amp;b=2">link</a><a href="tg://user?id=42">user</a>`
+	if got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestMarkdownV2(t *testing.T) {
+	got := format.NewMarkdownV2().
+		Text("1+1=2. ").
+		Italic("it_alic").
+		Code("a`b").
+		Link("x", "https://example.com/(a)").
+		Pre("fmt.Println()", "go").
+		String()
+	want := "1\\+1\\=2\\. _it\\_alic_`a\\`b`[x](https://example.com/(a\\))```go\nfmt.Println()\n```"
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestPlainEntities(t *testing.T) {
+	b := format.NewPlain().Text("👋 ").Bold("hi").Text(" ").Code("x")
+	if b.String() != "👋 hi x" {
+		t.Fatalf("wrong text: %s", b.String())
+	}
+	entities := b.Entities()
+	if len(entities) != 2 {
+		t.Fatalf("expected 2 entities, got %d", len(entities))
+	}
+	if entities[0].Offset != 3 || entities[0].Length != 2 {
+		t.Fatalf("wrong bold entity: %+v", entities[0])
+	}
+	if entities[1].Offset != 6 || entities[1].Length != 1 {
+		t.Fatalf("wrong code entity: %+v", entities[1])
+	}
+}
@@ -0,0 +1,31 @@
+package tbot
+
+/*
+Bot API types and methods are generated from the checked-in Bot API description,
+spec/tbot.json selects what is generated.
+
+spec/botapi.json is a subset of the Bot API 6.5 description, not the full one, copied from
+the upstream description without changes. It covers:
+  - types received in updates (Message, Chat, User, ChatMember and its subtypes, media, payments,
+    passport, forum topics...), generated into types_gen.go
+  - methods added after Bot API 4.7 that take plain parameters (chat member and join request
+    management, forum topics, copyMessage...), generated into methods_gen.go
+
+Methods needed ahead of the spec upgrade are described in spec/tbot.json with "spec", currently
+forwardMessages, copyMessages and deleteMessages of Bot API 7.0. Generation fails once the
+upgraded spec/botapi.json describes them too, remove the descriptions from spec/tbot.json then.
+
+The rest is still written by hand: methods in client.go and their options in options.go,
+types sent to Telegram (reply markups, InputMedia, InlineQueryResult, LabeledPrice...)
+and methods uploading files. go generate doesn't touch them, they are migrated in this order:
+ 1. extend the generator with InputFile parameters sent as multipart forms,
+    union types (InputMedia, InlineQueryResult, ReplyMarkup) and JSON-encoded parameters
+ 2. add the sent types to the spec and replace hand-written declarations one file at a time,
+    keeping their Go names with go_name overrides so the API doesn't change
+ 3. add the remaining methods group by group (sending, editing, inline, payments, stickers),
+    keeping signatures and option names, and move shared options to the generated code
+ 4. replace the subset with the full Bot API description once nothing is left by hand
+Generated declarations must match the hand-written ones they replace,
+TestGeneratedFilesUpToDate checks the generated files are in sync with the spec.
+*/
+//go:generate go run ./internal/gen -spec spec/botapi.json -config spec/tbot.json -types types_gen.go -methods methods_gen.go
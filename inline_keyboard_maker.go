@@ -1,11 +1,17 @@
 package tbot
 
-type row struct {
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// InlineRow is a row of an inline keyboard
+type InlineRow struct {
 	buttons []InlineKeyboardButton
 }
 
 // Short version for adding a new button to a row
-func (r *row) AddButton(text, data, url string) {
+func (r *InlineRow) AddButton(text, data, url string) {
 	r.buttons = append(r.buttons, InlineKeyboardButton{
 		Text:         text,
 		CallbackData: data,
@@ -13,31 +19,65 @@ func (r *row) AddButton(text, data, url string) {
 	})
 }
 
-func (r *row) AddButtonFull(button InlineKeyboardButton) {
+// AddButtonFull adds a button of any kind to a row, see InlineCallbackButton and other constructors
+func (r *InlineRow) AddButtonFull(button InlineKeyboardButton) {
 	r.buttons = append(r.buttons, button)
 }
 
-type keyboardMarker struct {
-	rows []*row
+// InlineKeyboardMaker is a builder for inline keyboards
+type InlineKeyboardMaker struct {
+	rows     []*InlineRow
+	columns  int
+	maxWidth int
 }
 
-// NewKeyboardMaker makes a keyboardMarker (builder design pattern)
+// NewKeyboardMaker makes a InlineKeyboardMaker (builder design pattern)
 // which then can be used for making inline keyboards,
 //
 // It has some methods for adding new rows and you can call Build method at the end and use returned Keyboard.
-func NewKeyboardMaker() *keyboardMarker {
-	return &keyboardMarker{}
+func NewKeyboardMaker() *InlineKeyboardMaker {
+	return &InlineKeyboardMaker{}
 }
 
 // Add a new row to an inline keyboard and returns a pointer to it,
 // then you can use this pointer to add new buttons using `.AddButton` or `.AddButtonFull`
-func (km *keyboardMarker) AddRow() *row {
-	newRow := new(row)
+func (km *InlineKeyboardMaker) AddRow() *InlineRow {
+	newRow := new(InlineRow)
 	km.rows = append(km.rows, newRow)
 	return newRow
 }
 
-func (km *keyboardMarker) Build() *InlineKeyboardMarkup {
+// Columns sets maximum number of buttons in a row for the buttons added with Add
+func (km *InlineKeyboardMaker) Columns(n int) *InlineKeyboardMaker {
+	km.columns = n
+	return km
+}
+
+// MaxRowWidth sets maximum total length of button texts in a row for the buttons added with Add.
+// Buttons wrap to the next row when the texts don't fit.
+func (km *InlineKeyboardMaker) MaxRowWidth(chars int) *InlineKeyboardMaker {
+	km.maxWidth = chars
+	return km
+}
+
+// Add adds buttons to the keyboard, placing them in rows according to Columns and MaxRowWidth.
+// Without layout settings every button gets its own row.
+func (km *InlineKeyboardMaker) Add(buttons ...InlineKeyboardButton) *InlineKeyboardMaker {
+	for _, button := range buttons {
+		var last *InlineRow
+		if len(km.rows) > 0 {
+			last = km.rows[len(km.rows)-1]
+		}
+		if last == nil || !fitsRow(len(last.buttons), inlineRowWidth(last.buttons), button.Text, km.columns, km.maxWidth) {
+			last = km.AddRow()
+		}
+		last.AddButtonFull(button)
+	}
+	return km
+}
+
+// Build returns the inline keyboard without checking it, see BuildChecked
+func (km *InlineKeyboardMaker) Build() *InlineKeyboardMarkup {
 	ikm := &InlineKeyboardMarkup{
 		InlineKeyboard: make([][]InlineKeyboardButton, len(km.rows)),
 	}
@@ -46,3 +86,112 @@ func (km *keyboardMarker) Build() *InlineKeyboardMarkup {
 	}
 	return ikm
 }
+
+// BuildChecked returns the inline keyboard, or an error if any of its buttons is invalid, see Validate
+func (km *InlineKeyboardMaker) BuildChecked() (*InlineKeyboardMarkup, error) {
+	ikm := km.Build()
+	if err := ikm.Validate(); err != nil {
+		return nil, err
+	}
+	return ikm, nil
+}
+
+// InlineCallbackButton creates a button sending callback query with data, up to 64 bytes
+func InlineCallbackButton(text, data string) InlineKeyboardButton {
+	return InlineKeyboardButton{Text: text, CallbackData: data}
+}
+
+// InlineURLButton creates a button opening the URL
+func InlineURLButton(text, url string) InlineKeyboardButton {
+	return InlineKeyboardButton{Text: text, URL: url}
+}
+
+// InlineLoginButton creates a button authorizing the user on a website with Seamless Login
+func InlineLoginButton(text string, login *LoginURL) InlineKeyboardButton {
+	return InlineKeyboardButton{Text: text, LoginURL: login}
+}
+
+// InlineSwitchQueryButton creates a button prompting the user to choose a chat and inserting the bot's username and the query
+func InlineSwitchQueryButton(text, query string) InlineKeyboardButton {
+	return InlineKeyboardButton{Text: text, SwitchInlineQuery: &query}
+}
+
+// InlineSwitchQueryCurrentChatButton creates a button inserting the bot's username and the query in the current chat
+func InlineSwitchQueryCurrentChatButton(text, query string) InlineKeyboardButton {
+	return InlineKeyboardButton{Text: text, SwitchInlineQueryCurrentChat: &query}
+}
+
+// InlineGameButton creates a button launching the game, it must be the first button in the first row
+func InlineGameButton(text string) InlineKeyboardButton {
+	return InlineKeyboardButton{Text: text, CallbackGame: &CallbackGame{}}
+}
+
+// InlinePayButton creates a pay button, it must be the first button in the first row
+func InlinePayButton(text string) InlineKeyboardButton {
+	return InlineKeyboardButton{Text: text, Pay: true}
+}
+
+// InlineWebAppButton creates a button launching the Web App
+func InlineWebAppButton(text, url string) InlineKeyboardButton {
+	return InlineKeyboardButton{Text: text, WebApp: &WebAppInfo{URL: url}}
+}
+
+// Validate checks that the button has exactly one action and its callback data fits in 64 bytes
+func (b InlineKeyboardButton) Validate() error {
+	actions := 0
+	for _, set := range []bool{
+		b.URL != "",
+		b.LoginURL != nil,
+		b.CallbackData != "",
+		b.SwitchInlineQuery != nil,
+		b.SwitchInlineQueryCurrentChat != nil,
+		b.CallbackGame != nil,
+		b.Pay,
+		b.WebApp != nil,
+	} {
+		if set {
+			actions++
+		}
+	}
+	if actions != 1 {
+		return fmt.Errorf("button %q must have exactly one action, got %d", b.Text, actions)
+	}
+	if len(b.CallbackData) > maxCallbackData {
+		return fmt.Errorf("button %q callback data is longer than %d bytes", b.Text, maxCallbackData)
+	}
+	return nil
+}
+
+// Validate checks all buttons of the keyboard
+func (m *InlineKeyboardMarkup) Validate() error {
+	for _, row := range m.InlineKeyboard {
+		for _, button := range row {
+			err := button.Validate()
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func inlineRowWidth(buttons []InlineKeyboardButton) int {
+	width := 0
+	for _, b := range buttons {
+		width += utf8.RuneCountInString(b.Text)
+	}
+	return width
+}
+
+func fitsRow(count, width int, text string, columns, maxWidth int) bool {
+	if columns <= 0 && maxWidth <= 0 {
+		return false
+	}
+	if columns > 0 && count >= columns {
+		return false
+	}
+	if maxWidth > 0 && width+utf8.RuneCountInString(text) > maxWidth {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,1107 @@
+/*
+Command gen generates Bot API types and Client methods of package tbot
+from the machine-readable Bot API description in spec/botapi.json.
+
+spec/tbot.json selects the types and methods to generate and keeps
+the Go names and types of existing fields compatible:
+
+	go generate github.com/yanzay/tbot/v2
+
+Generated declarations must not be declared by hand in the package, gen fails on such clashes.
+Existing Opt* variables are reused for method parameters they set.
+*/
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Spec is the Bot API description
+type Spec struct {
+	Version     string                 `json:"version"`
+	ReleaseDate string                 `json:"release_date"`
+	Changelog   string                 `json:"changelog"`
+	Methods     map[string]*SpecMethod `json:"methods"`
+	Types       map[string]*SpecType   `json:"types"`
+}
+
+// SpecType is an object of the Bot API
+type SpecType struct {
+	Name        string       `json:"name"`
+	Href        string       `json:"href"`
+	Description []string     `json:"description"`
+	Fields      []*SpecField `json:"fields"`
+	Subtypes    []string     `json:"subtypes"`
+	SubtypeOf   []string     `json:"subtype_of"`
+}
+
+// SpecMethod is a method of the Bot API
+type SpecMethod struct {
+	Name        string       `json:"name"`
+	Href        string       `json:"href"`
+	Description []string     `json:"description"`
+	Returns     []string     `json:"returns"`
+	Fields      []*SpecField `json:"fields"`
+}
+
+// SpecField is a field of an object or a parameter of a method
+type SpecField struct {
+	Name        string   `json:"name"`
+	Types       []string `json:"types"`
+	Required    bool     `json:"required"`
+	Description string   `json:"description"`
+}
+
+// Config selects generated types and methods
+type Config struct {
+	Types   []*TypeConfig   `json:"types"`
+	Methods []*MethodConfig `json:"methods"`
+}
+
+// TypeConfig configures generated type
+type TypeConfig struct {
+	Name string `json:"name"`
+	// Context embeds updateContext, for types delivered in updates
+	Context bool `json:"context"`
+	// Flatten merges fields of all subtypes into one struct
+	Flatten bool `json:"flatten"`
+	// Variants generates subtypes of the flattened type and an interface implemented by them
+	Variants *VariantsConfig         `json:"variants"`
+	Fields   map[string]*FieldConfig `json:"fields"`
+	// Deprecated are fields removed from the Bot API, kept for compatibility
+	Deprecated []*SpecField `json:"deprecated"`
+}
+
+// VariantsConfig configures subtypes generated along with a flattened type
+type VariantsConfig struct {
+	// Field tells the subtype of an object, e.g. status of ChatMember
+	Field string `json:"field"`
+	// Types maps values of the field to subtypes
+	Types map[string]string `json:"types"`
+}
+
+// FieldConfig overrides generated field
+type FieldConfig struct {
+	GoName      string `json:"go_name"`
+	GoType      string `json:"go_type"`
+	Description string `json:"description"`
+}
+
+// MethodConfig configures generated method
+type MethodConfig struct {
+	Name   string `json:"name"`
+	GoName string `json:"go_name"`
+	// Options maps optional parameters to names of Opt* variables
+	Options map[string]OptionNames `json:"options"`
+	// Spec describes a method released after the spec version, generated ahead of the spec upgrade
+	Spec *SpecMethod `json:"spec"`
+}
+
+// OptionNames are names of Opt* variables setting a parameter, a string or a list in the config
+type OptionNames []string
+
+// UnmarshalJSON implements json.Unmarshaler
+func (o *OptionNames) UnmarshalJSON(data []byte) error {
+	var name string
+	if json.Unmarshal(data, &name) == nil {
+		*o = OptionNames{name}
+		return nil
+	}
+	return json.Unmarshal(data, (*[]string)(o))
+}
+
+func main() {
+	specFile := flag.String("spec", "spec/botapi.json", "Bot API description")
+	configFile := flag.String("config", "spec/tbot.json", "generation config")
+	dir := flag.String("dir", ".", "package directory")
+	typesFile := flag.String("types", "types_gen.go", "output file for types")
+	methodsFile := flag.String("methods", "methods_gen.go", "output file for methods")
+	flag.Parse()
+	log.SetFlags(0)
+	log.SetPrefix("gen: ")
+
+	out, err := run(*specFile, *configFile, *dir, *typesFile, *methodsFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for name, src := range out {
+		err = ioutil.WriteFile(filepath.Join(*dir, name), src, 0644)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// run generates sources of typesFile and methodsFile
+func run(specFile, configFile, dir, typesFile, methodsFile string) (map[string][]byte, error) {
+	spec := &Spec{}
+	err := readJSON(specFile, spec)
+	if err != nil {
+		return nil, err
+	}
+	config := &Config{}
+	err = readJSON(configFile, config)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range config.Methods {
+		if c.Spec == nil {
+			continue
+		}
+		if _, ok := spec.Methods[c.Name]; ok {
+			return nil, fmt.Errorf("method %s is described in %s, remove its spec from %s", c.Name, specFile, configFile)
+		}
+		c.Spec.Name = c.Name
+		spec.Methods[c.Name] = c.Spec
+	}
+	pkg, err := parsePackage(dir, typesFile, methodsFile)
+	if err != nil {
+		return nil, err
+	}
+	g := &generator{spec: spec, pkg: pkg, types: map[string]bool{}}
+	types, err := g.generateTypes(config.Types)
+	if err != nil {
+		return nil, err
+	}
+	methods, err := g.generateMethods(config.Methods)
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{typesFile: types, methodsFile: methods}, nil
+}
+
+func readJSON(filename string, v interface{}) error {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(data, v)
+	if err != nil {
+		return fmt.Errorf("unable to decode %s: %v", filename, err)
+	}
+	return nil
+}
+
+// option is an Opt* variable declared in the package
+type option struct {
+	signature string
+	typ       string // option type implementing method option interfaces
+	params    map[string]bool
+}
+
+// pkgDecls are declarations of the package which are not generated
+type pkgDecls struct {
+	names   map[string]string // name -> file
+	methods map[string]string // Client method -> file
+	options map[string]*option
+}
+
+func parsePackage(dir string, generated ...string) (*pkgDecls, error) {
+	skip := map[string]bool{}
+	for _, name := range generated {
+		skip[name] = true
+	}
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !skip[fi.Name()] && !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+	pkg, ok := pkgs["tbot"]
+	if !ok {
+		return nil, fmt.Errorf("package tbot is not found in %s", dir)
+	}
+	decls := &pkgDecls{names: map[string]string{}, methods: map[string]string{}, options: map[string]*option{}}
+	for filename, file := range pkg.Files {
+		filename = filepath.Base(filename)
+		for _, decl := range file.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				if d.Recv == nil {
+					decls.names[d.Name.Name] = filename
+					continue
+				}
+				if recvName(d.Recv.List[0].Type) == "Client" {
+					decls.methods[d.Name.Name] = filename
+				}
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					switch s := spec.(type) {
+					case *ast.TypeSpec:
+						decls.names[s.Name.Name] = filename
+					case *ast.ValueSpec:
+						for i, name := range s.Names {
+							decls.names[name.Name] = filename
+							if i < len(s.Values) && strings.HasPrefix(name.Name, "Opt") {
+								if fn, ok := s.Values[i].(*ast.FuncLit); ok {
+									decls.options[name.Name] = parseOption(fset, name.Name, s.Type, fn)
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	return decls, nil
+}
+
+func recvName(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// parseOption returns signature and type of Opt* variable and names of parameters it sets,
+// typ is the declared type of the variable, nil for options returned by a function
+func parseOption(fset *token.FileSet, name string, typ ast.Expr, fn *ast.FuncLit) *option {
+	opt := &option{signature: name, params: map[string]bool{}}
+	if ident, ok := typ.(*ast.Ident); ok {
+		opt.typ = ident.Name
+	}
+	if fn.Type.Results != nil {
+		if ident, ok := fn.Type.Results.List[0].Type.(*ast.Ident); ok {
+			opt.typ = ident.Name
+		}
+		var params []string
+		for _, field := range fn.Type.Params.List {
+			var names []string
+			for _, n := range field.Names {
+				names = append(names, n.Name)
+			}
+			typ := &bytes.Buffer{}
+			printer.Fprint(typ, fset, field.Type)
+			params = append(params, strings.Join(names, ", ")+" "+typ.String())
+		}
+		opt.signature = fmt.Sprintf("%s(%s)", name, strings.Join(params, ", "))
+	}
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) == 0 {
+			return true
+		}
+		if fn, ok := call.Fun.(*ast.Ident); ok && fn.Name == "setJSON" && len(call.Args) > 1 {
+			if lit, ok := call.Args[1].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+				param, _ := strconv.Unquote(lit.Value)
+				opt.params[param] = true
+			}
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "Set" {
+			return true
+		}
+		if lit, ok := call.Args[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+			param, _ := strconv.Unquote(lit.Value)
+			opt.params[param] = true
+		}
+		return true
+	})
+	return opt
+}
+
+type generator struct {
+	spec *Spec
+	pkg  *pkgDecls
+	// types are generated types
+	types map[string]bool
+}
+
+// field is a field of generated struct
+type field struct {
+	name    string
+	goType  string
+	tag     string
+	comment string
+}
+
+func (g *generator) generateTypes(configs []*TypeConfig) ([]byte, error) {
+	configs, err := g.expandVariants(configs)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range configs {
+		name := typeName(c.Name)
+		if file, ok := g.pkg.names[name]; ok {
+			return nil, fmt.Errorf("type %s is declared in %s", name, file)
+		}
+		if g.spec.Types[c.Name] == nil {
+			return nil, fmt.Errorf("type %s is not found in the spec", c.Name)
+		}
+		g.types[name] = true
+	}
+	buf := &bytes.Buffer{}
+	g.header(buf)
+	buf.WriteString("package tbot\n")
+	for _, c := range configs {
+		if c.Variants != nil {
+			buf.WriteString("\nimport (\n\t\"encoding/json\"\n\t\"fmt\"\n)\n")
+			break
+		}
+	}
+	for _, c := range configs {
+		t := g.spec.Types[c.Name]
+		name := typeName(t.Name)
+		specFields, err := g.typeFields(t, c)
+		if err != nil {
+			return nil, err
+		}
+		var fields []*field
+		for _, f := range specFields {
+			override := c.Fields[f.Name]
+			if override == nil {
+				override = &FieldConfig{}
+			}
+			goType, err := g.goType(f, override.GoType)
+			if err != nil {
+				return nil, fmt.Errorf("%s.%s: %v", t.Name, f.Name, err)
+			}
+			goName := override.GoName
+			if goName == "" {
+				goName = camelCase(f.Name)
+			}
+			description := f.Description
+			if override.Description != "" {
+				description = override.Description
+			}
+			tag := f.Name
+			if !f.Required {
+				tag += ",omitempty"
+			}
+			fields = append(fields, &field{
+				name:    goName,
+				goType:  goType,
+				tag:     tag,
+				comment: firstSentence(strings.TrimPrefix(description, "Optional. ")),
+			})
+		}
+		for _, f := range c.Deprecated {
+			goType, err := g.goType(f, "")
+			if err != nil {
+				return nil, fmt.Errorf("%s.%s: %v", t.Name, f.Name, err)
+			}
+			fields = append(fields, &field{
+				name:    camelCase(f.Name),
+				goType:  goType,
+				tag:     f.Name + ",omitempty",
+				comment: "Deprecated: " + f.Description,
+			})
+		}
+
+		description := t.Description
+		if c.Flatten {
+			words := splitWords(t.Name)
+			kind := strings.ToLower(words[len(words)-1])
+			description = []string{fmt.Sprintf("%s. Fields of all kinds of %ss are merged into one struct",
+				firstSentence(description[0]), kind)}
+			if c.Variants != nil {
+				description = append(description, "", fmt.Sprintf("Deprecated: use Variant to get the %s as one of %s.",
+					kind, strings.Join(t.Subtypes, ", ")))
+			}
+		}
+		buf.WriteString("\n")
+		writeComment(buf, typeDoc(name, description))
+		fmt.Fprintf(buf, "type %s struct {\n", name)
+		for _, f := range fields {
+			fmt.Fprintf(buf, "\t%s %s `json:\"%s\"`", f.name, f.goType, f.tag)
+			if f.comment != "" {
+				fmt.Fprintf(buf, " // %s", f.comment)
+			}
+			buf.WriteString("\n")
+		}
+		if len(fields) > 0 {
+			buf.WriteString("\n")
+		}
+		buf.WriteString("\tExtra ExtraFields `json:\"-\"`\n")
+		if c.Context {
+			buf.WriteString("\tupdateContext\n")
+		}
+		buf.WriteString("}\n")
+
+		recv := receiver(name)
+		fmt.Fprintf(buf, `
+// UnmarshalJSON implements json.Unmarshaler
+func (%[1]s *%[2]s) UnmarshalJSON(data []byte) error {
+	type plain %[2]s
+	return unmarshalExtra(data, (*plain)(%[1]s), &%[1]s.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (%[1]s %[2]s) MarshalJSON() ([]byte, error) {
+	type plain %[2]s
+	return marshalExtra(plain(%[1]s), %[1]s.Extra)
+}
+`, recv, name)
+		if c.Variants != nil {
+			g.writeVariants(buf, t, c.Variants)
+		}
+	}
+	return formatSource(buf.Bytes())
+}
+
+// expandVariants adds configs of subtypes after configs of types with variants,
+// subtypes get field overrides of the flattened type
+func (g *generator) expandVariants(configs []*TypeConfig) ([]*TypeConfig, error) {
+	var expanded []*TypeConfig
+	for _, c := range configs {
+		expanded = append(expanded, c)
+		if c.Variants == nil {
+			continue
+		}
+		t := g.spec.Types[c.Name]
+		if t == nil || !c.Flatten {
+			return nil, fmt.Errorf("type %s with variants must be flattened", c.Name)
+		}
+		if len(c.Variants.Types) != len(t.Subtypes) {
+			return nil, fmt.Errorf("variants of %s don't match its subtypes", c.Name)
+		}
+		for _, name := range t.Subtypes {
+			if variantValue(c.Variants, name) == "" {
+				return nil, fmt.Errorf("subtype %s of %s has no variant", name, c.Name)
+			}
+			fields := map[string]*FieldConfig{}
+			for k, f := range c.Fields {
+				fields[k] = &FieldConfig{GoName: f.GoName, GoType: f.GoType}
+			}
+			expanded = append(expanded, &TypeConfig{Name: name, Fields: fields})
+		}
+	}
+	return expanded, nil
+}
+
+func variantValue(v *VariantsConfig, subtype string) string {
+	for value, name := range v.Types {
+		if name == subtype {
+			return value
+		}
+	}
+	return ""
+}
+
+// writeVariants writes the interface implemented by subtypes of t and functions decoding them
+func (g *generator) writeVariants(buf *bytes.Buffer, t *SpecType, v *VariantsConfig) {
+	iface := t.Name + "Variant"
+	marker := strings.ToLower(iface[:1]) + iface[1:]
+	buf.WriteString("\n")
+	writeComment(buf, fmt.Sprintf("%s is one of %s", iface, strings.Join(t.Subtypes, ", ")))
+	fmt.Fprintf(buf, "type %s interface {\n\t%s()\n}\n\n", iface, marker)
+	for _, name := range t.Subtypes {
+		fmt.Fprintf(buf, "func (*%s) %s() {}\n", name, marker)
+	}
+	fmt.Fprintf(buf, `
+// Unmarshal%[1]s decodes %[2]s as its subtype chosen by %[3]s
+func Unmarshal%[1]s(data []byte) (%[1]s, error) {
+	var kind struct {
+		Value string `+"`json:\"%[3]s\"`"+`
+	}
+	err := json.Unmarshal(data, &kind)
+	if err != nil {
+		return nil, err
+	}
+	var v %[1]s
+	switch kind.Value {
+`, iface, t.Name, v.Field)
+	for _, name := range t.Subtypes {
+		fmt.Fprintf(buf, "\tcase %q:\n\t\tv = &%s{}\n", variantValue(v, name), name)
+	}
+	recv := receiver(t.Name)
+	fmt.Fprintf(buf, `	default:
+		return nil, fmt.Errorf("unknown %[2]s %[3]s %%q", kind.Value)
+	}
+	return v, json.Unmarshal(data, v)
+}
+
+`, iface, t.Name, v.Field)
+	kind := strings.ToLower(splitWords(t.Name)[len(splitWords(t.Name))-1])
+	writeComment(buf, fmt.Sprintf("Variant returns the %s as one of %s chosen by %s", kind, strings.Join(t.Subtypes, ", "), v.Field))
+	fmt.Fprintf(buf, `func (%[3]s *%[2]s) Variant() (%[1]s, error) {
+	data, err := json.Marshal(%[3]s)
+	if err != nil {
+		return nil, err
+	}
+	return Unmarshal%[1]s(data)
+}
+`, iface, t.Name, recv)
+}
+
+// typeFields returns spec fields of type t, merged fields of subtypes if it's flattened
+func (g *generator) typeFields(t *SpecType, c *TypeConfig) ([]*SpecField, error) {
+	if !c.Flatten {
+		if len(t.Subtypes) > 0 {
+			return nil, fmt.Errorf("type %s has subtypes, it must be flattened", t.Name)
+		}
+		return t.Fields, nil
+	}
+	var fields []*SpecField
+	index := map[string]int{}
+	count := map[string]int{}
+	for _, name := range t.Subtypes {
+		sub := g.spec.Types[name]
+		if sub == nil {
+			return nil, fmt.Errorf("subtype %s of %s is not found in the spec", name, t.Name)
+		}
+		for _, f := range sub.Fields {
+			count[f.Name]++
+			if i, ok := index[f.Name]; ok {
+				if strings.Join(fields[i].Types, ",") != strings.Join(f.Types, ",") {
+					return nil, fmt.Errorf("%s.%s has different types in subtypes", t.Name, f.Name)
+				}
+				continue
+			}
+			merged := *f
+			index[f.Name] = len(fields)
+			fields = append(fields, &merged)
+		}
+	}
+	for _, f := range fields {
+		f.Required = count[f.Name] == len(t.Subtypes)
+	}
+	return fields, nil
+}
+
+// goType returns Go type of field f
+func (g *generator) goType(f *SpecField, override string) (string, error) {
+	typ := override
+	if typ == "" {
+		var err error
+		typ, err = g.specType(f)
+		if err != nil {
+			return "", err
+		}
+	}
+	base := strings.TrimLeft(typ, "[]*")
+	switch base {
+	case "ChatID", "string", "bool", "int", "int64", "float32", "float64":
+		return typ, nil
+	}
+	if !g.types[base] && g.pkg.names[base] == "" {
+		return "", fmt.Errorf("type %s is neither generated nor declared", base)
+	}
+	return typ, nil
+}
+
+func (g *generator) specType(f *SpecField) (string, error) {
+	if len(f.Types) == 2 && f.Types[0] == "Integer" && f.Types[1] == "String" {
+		return "ChatID", nil
+	}
+	if len(f.Types) != 1 {
+		return "", fmt.Errorf("unsupported type %s", strings.Join(f.Types, " or "))
+	}
+	typ := f.Types[0]
+	prefix := ""
+	for strings.HasPrefix(typ, "Array of ") {
+		prefix += "[]"
+		typ = strings.TrimPrefix(typ, "Array of ")
+	}
+	switch typ {
+	case "Integer":
+		if is64bit(f) {
+			return prefix + "int64", nil
+		}
+		return prefix + "int", nil
+	case "Float", "Float number":
+		return prefix + "float64", nil
+	case "String":
+		return prefix + "string", nil
+	case "Boolean", "True":
+		return prefix + "bool", nil
+	}
+	return prefix + "*" + typeName(typ), nil
+}
+
+// typeName returns Go name of the Bot API type: MessageId -> MessageID
+func typeName(name string) string {
+	return camelCase(name)
+}
+
+// is64bit reports whether integer field f doesn't fit into 32 bits: identifiers of users and chats and dates
+func is64bit(f *SpecField) bool {
+	name := f.Name
+	return strings.Contains(f.Description, "52 significant bits") ||
+		name == "date" || strings.HasSuffix(name, "_date") ||
+		name == "user_id" || strings.HasSuffix(name, "_user_id") ||
+		name == "chat_id" || strings.HasSuffix(name, "_chat_id")
+}
+
+// methodOption is Opt* variable generated for a method parameter
+type methodOption struct {
+	name   string
+	param  string
+	arg    string
+	typ    string
+	optTyp string
+}
+
+func (g *generator) generateMethods(configs []*MethodConfig) ([]byte, error) {
+	body := &bytes.Buffer{}
+	generated := map[string]*methodOption{}
+	for _, c := range configs {
+		m := g.spec.Methods[c.Name]
+		if m == nil {
+			return nil, fmt.Errorf("method %s is not found in the spec", c.Name)
+		}
+		goName := c.GoName
+		if goName == "" {
+			goName = camelCase(m.Name)
+		}
+		if file, ok := g.pkg.methods[goName]; ok {
+			return nil, fmt.Errorf("method Client.%s is declared in %s", goName, file)
+		}
+
+		var params []string
+		var sets []string
+		var docOptions []string
+		var newOptions []*methodOption
+		var optTypes []string
+		used := map[string]bool{}
+		for _, f := range m.Fields {
+			if f.Required {
+				goType, err := g.goType(f, "")
+				if err != nil {
+					return nil, fmt.Errorf("%s.%s: %v", m.Name, f.Name, err)
+				}
+				arg := lowerCamelCase(f.Name)
+				used[arg] = true
+				params = append(params, arg+" "+goType)
+				sets = append(sets, setParam("req", f.Name, arg, goType))
+				continue
+			}
+			names := c.Options[f.Name]
+			if len(names) == 0 {
+				names = OptionNames{"Opt" + camelCase(f.Name)}
+			}
+			// parameters set by several options, e.g. reply_markup, must be set by declared options
+			if _, ok := g.pkg.options[names[0]]; ok || len(names) > 1 {
+				for _, name := range names {
+					opt, ok := g.pkg.options[name]
+					if !ok {
+						return nil, fmt.Errorf("%s.%s: %s is not declared", m.Name, f.Name, name)
+					}
+					if !opt.params[f.Name] {
+						return nil, fmt.Errorf("%s.%s: %s doesn't set %s", m.Name, f.Name, name, f.Name)
+					}
+					if opt.typ == "" {
+						return nil, fmt.Errorf("%s.%s: type of %s is unknown", m.Name, f.Name, name)
+					}
+					docOptions = append(docOptions, opt.signature)
+					optTypes = appendUnique(optTypes, opt.typ)
+				}
+				continue
+			}
+			name := names[0]
+			goType, err := g.goType(f, "")
+			if err != nil {
+				return nil, fmt.Errorf("%s.%s: %v", m.Name, f.Name, err)
+			}
+			if file, ok := g.pkg.names[name]; ok {
+				return nil, fmt.Errorf("%s.%s: %s is declared in %s", m.Name, f.Name, name, file)
+			}
+			opt, ok := generated[name]
+			if !ok {
+				optTyp := lowerFirst(strings.TrimPrefix(name, "Opt")) + "Option"
+				if file, ok := g.pkg.names[optTyp]; ok {
+					return nil, fmt.Errorf("%s.%s: %s is declared in %s", m.Name, f.Name, optTyp, file)
+				}
+				opt = &methodOption{name: name, param: f.Name, arg: lowerCamelCase(f.Name), typ: goType, optTyp: optTyp}
+				generated[name] = opt
+				newOptions = append(newOptions, opt)
+			} else if opt.param != f.Name || opt.typ != goType {
+				return nil, fmt.Errorf("%s.%s: %s is generated for %s", m.Name, f.Name, name, opt.param)
+			}
+			docOptions = append(docOptions, opt.signature())
+			optTypes = appendUnique(optTypes, opt.optTyp)
+		}
+
+		iface := goName + "Option"
+		if len(docOptions) > 0 {
+			if file, ok := g.pkg.names[iface]; ok {
+				return nil, fmt.Errorf("%s: %s is declared in %s", m.Name, iface, file)
+			}
+			for _, opt := range newOptions {
+				opt.writeType(body)
+			}
+			marker := lowerFirst(iface) + "()"
+			fmt.Fprintf(body, "\n// %s is an option of %s\ntype %s interface {\n\toption\n\t%s\n}\n\n", iface, goName, iface, marker)
+			fmt.Fprintf(body, "func (Option) %s {}\n", marker)
+			for _, typ := range optTypes {
+				fmt.Fprintf(body, "func (%s) %s {}\n", typ, marker)
+			}
+		}
+		if len(newOptions) > 0 {
+			fmt.Fprintf(body, "\n// %s options\nvar (\n", goName)
+			for _, opt := range newOptions {
+				opt.write(body)
+			}
+			body.WriteString(")\n")
+		}
+
+		doc := methodDoc(goName, m.Description)
+		if len(docOptions) > 0 {
+			doc += ". Available options:"
+		}
+		body.WriteString("\n/*\n")
+		for _, line := range wrap(doc, 100) {
+			body.WriteString(line + "\n")
+		}
+		for _, opt := range docOptions {
+			fmt.Fprintf(body, "\t- %s\n", opt)
+		}
+		body.WriteString("*/\n")
+
+		if len(docOptions) > 0 {
+			params = append(params, "opts ..."+iface)
+		}
+		result, err := g.result(m)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(body, "func (c *Client) %s(%s) ", goName, groupParams(params))
+		if result.typ == "" {
+			body.WriteString("error {\n")
+		} else {
+			fmt.Fprintf(body, "(%s, error) {\n", result.typ)
+		}
+		req := "nil"
+		if len(m.Fields) > 0 {
+			req = "req"
+			body.WriteString("\treq := url.Values{}\n")
+			for _, set := range sets {
+				body.WriteString("\t" + set + "\n")
+			}
+			if len(docOptions) > 0 {
+				body.WriteString("\tfor _, opt := range opts {\n\t\topt.apply(req)\n\t}\n")
+			}
+		}
+		name := result.name
+		if used[name] {
+			name = "result"
+		}
+		switch {
+		case result.typ == "":
+			fmt.Fprintf(body, "\tvar %s bool\n\treturn c.doRequest(%q, %s, &%s)\n", name, m.Name, req, name)
+		case result.init == "":
+			fmt.Fprintf(body, "\tvar %s %s\n\terr := c.doRequest(%q, %s, &%s)\n\treturn %s, err\n", name, result.typ, m.Name, req, name, name)
+		case strings.HasPrefix(result.typ, "*"):
+			fmt.Fprintf(body, "\t%s := %s\n\terr := c.doRequest(%q, %s, %s)\n\treturn %s, err\n", name, result.init, m.Name, req, name, name)
+		default:
+			fmt.Fprintf(body, "\t%s := %s\n\terr := c.doRequest(%q, %s, &%s)\n\treturn %s, err\n", name, result.init, m.Name, req, name, name)
+		}
+		body.WriteString("}\n")
+	}
+
+	buf := &bytes.Buffer{}
+	g.header(buf)
+	buf.WriteString("package tbot\n\n")
+	var imports []string
+	if strings.Contains(body.String(), "fmt.") {
+		imports = append(imports, `"fmt"`)
+	}
+	if strings.Contains(body.String(), "url.") {
+		imports = append(imports, `"net/url"`)
+	}
+	if len(imports) > 0 {
+		fmt.Fprintf(buf, "import (\n%s\n)\n", strings.Join(imports, "\n"))
+	}
+	buf.Write(body.Bytes())
+	return formatSource(buf.Bytes())
+}
+
+func (opt *methodOption) signature() string {
+	if opt.typ == "bool" {
+		return opt.name
+	}
+	return fmt.Sprintf("%s(%s %s)", opt.name, opt.arg, opt.typ)
+}
+
+func (opt *methodOption) write(buf *bytes.Buffer) {
+	if opt.typ == "bool" {
+		fmt.Fprintf(buf, "\t%s %s = func(v url.Values) {\n\t\tv.Set(%q, \"true\")\n\t}\n", opt.name, opt.optTyp, opt.param)
+		return
+	}
+	fmt.Fprintf(buf, "\t%s = func(%s %s) %s {\n\t\treturn func(v url.Values) {\n\t\t\t%s\n\t\t}\n\t}\n",
+		opt.name, opt.arg, opt.typ, opt.optTyp, setParam("v", opt.param, opt.arg, opt.typ))
+}
+
+// writeType writes type of the option, it implements option interfaces of methods accepting the option
+func (opt *methodOption) writeType(buf *bytes.Buffer) {
+	fmt.Fprintf(buf, "\n// %s sets %s\ntype %s func(url.Values)\n\n", opt.optTyp, opt.param, opt.optTyp)
+	fmt.Fprintf(buf, "func (o %s) apply(v url.Values) { o(v) }\n", opt.optTyp)
+}
+
+// methodResult describes how a method result is decoded
+type methodResult struct {
+	typ  string
+	name string
+	init string
+}
+
+func (g *generator) result(m *SpecMethod) (*methodResult, error) {
+	if len(m.Returns) != 1 {
+		return nil, fmt.Errorf("%s: unsupported result %s", m.Name, strings.Join(m.Returns, " or "))
+	}
+	returns := m.Returns[0]
+	switch returns {
+	case "Boolean", "True":
+		return &methodResult{name: "ok"}, nil
+	case "Integer":
+		return &methodResult{typ: "int", name: "result"}, nil
+	case "String":
+		return &methodResult{typ: "string", name: "result"}, nil
+	}
+	typ, err := g.goType(&SpecField{Name: "result", Types: m.Returns}, "")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", m.Name, err)
+	}
+	base := strings.TrimLeft(typ, "[]*")
+	if strings.HasPrefix(typ, "[]") {
+		return &methodResult{typ: typ, name: lowerFirst(base) + "s", init: typ + "{}"}, nil
+	}
+	return &methodResult{typ: typ, name: lowerFirst(base), init: "&" + base + "{}"}, nil
+}
+
+// setParam returns statement setting request parameter param of values to Go value v of type typ,
+// nested types are encoded as JSON
+func setParam(values, param, v, typ string) string {
+	switch typ {
+	case "ChatID":
+		return fmt.Sprintf("%s.Set(%q, string(%s))", values, param, v)
+	case "string":
+		return fmt.Sprintf("%s.Set(%q, %s)", values, param, v)
+	case "int", "int64", "float64", "bool":
+		return fmt.Sprintf("%s.Set(%q, fmt.Sprint(%s))", values, param, v)
+	}
+	return fmt.Sprintf("setJSON(%s, %q, %s)", values, param, v)
+}
+
+// groupParams joins consecutive parameters of the same type: chatID, fromChatID ChatID
+func groupParams(params []string) string {
+	var out []string
+	for i, p := range params {
+		name, typ := splitParam(p)
+		if i+1 < len(params) {
+			if _, next := splitParam(params[i+1]); next == typ && !strings.HasPrefix(typ, "...") {
+				out = append(out, name)
+				continue
+			}
+		}
+		out = append(out, p)
+	}
+	return strings.Join(out, ", ")
+}
+
+func splitParam(p string) (string, string) {
+	i := strings.Index(p, " ")
+	return p[:i], p[i+1:]
+}
+
+func (g *generator) header(buf *bytes.Buffer) {
+	fmt.Fprintf(buf, "// Code generated by internal/gen from spec/botapi.json (%s); DO NOT EDIT.\n\n", g.spec.Version)
+}
+
+func formatSource(src []byte) ([]byte, error) {
+	formatted, err := format.Source(src)
+	if err != nil {
+		return nil, fmt.Errorf("unable to format generated code: %v\n%s", err, src)
+	}
+	return formatted, nil
+}
+
+// typeDoc turns "This object represents a chat." into "Chat represents a chat."
+func typeDoc(name string, description []string) string {
+	doc := strings.Join(description, "\n")
+	doc = strings.TrimPrefix(doc, "This object ")
+	doc = strings.ToLower(doc[:1]) + doc[1:]
+	return name + " " + strings.TrimSuffix(doc, ".")
+}
+
+// methodDoc turns "Use this method to ban a user. Returns True on success." into "Name bans a user"
+func methodDoc(name string, description []string) string {
+	var sentences []string
+	for _, s := range splitSentences(strings.Join(description, " ")) {
+		if strings.HasPrefix(s, "Returns ") || strings.HasPrefix(s, "On success") || strings.HasPrefix(s, "Requires no parameters") {
+			continue
+		}
+		sentences = append(sentences, s)
+	}
+	if len(sentences) == 0 {
+		return name
+	}
+	first := strings.TrimPrefix(sentences[0], "Use this method to ")
+	if first != sentences[0] {
+		words := strings.SplitN(first, " ", 2)
+		words[0] = thirdPerson(words[0])
+		first = strings.Join(words, " ")
+	}
+	sentences[0] = name + " " + first
+	return strings.TrimSuffix(strings.Join(sentences, " "), ".")
+}
+
+// abbreviations don't end sentences
+var abbreviations = []string{"e.g", "i.e", "etc"}
+
+func splitSentences(text string) []string {
+	var sentences []string
+	start := 0
+	for i := 0; i+1 < len(text); i++ {
+		if text[i] != '.' || text[i+1] != ' ' || isAbbreviation(text[start:i]) {
+			continue
+		}
+		sentences = append(sentences, text[start:i+1])
+		start = i + 2
+	}
+	if start < len(text) {
+		sentences = append(sentences, text[start:])
+	}
+	return sentences
+}
+
+func isAbbreviation(text string) bool {
+	for _, a := range abbreviations {
+		if strings.HasSuffix(text, " "+a) {
+			return true
+		}
+	}
+	return false
+}
+
+func firstSentence(text string) string {
+	return strings.TrimSuffix(splitSentences(text)[0], ".")
+}
+
+func thirdPerson(verb string) string {
+	switch {
+	case strings.HasSuffix(verb, "s"), strings.HasSuffix(verb, "sh"), strings.HasSuffix(verb, "ch"),
+		strings.HasSuffix(verb, "x"), strings.HasSuffix(verb, "z"):
+		return verb + "es"
+	case strings.HasSuffix(verb, "y") && !strings.ContainsAny(verb[len(verb)-2:len(verb)-1], "aeiou"):
+		return verb[:len(verb)-1] + "ies"
+	}
+	return verb + "s"
+}
+
+func writeComment(buf *bytes.Buffer, text string) {
+	for _, line := range wrap(text, 100) {
+		if line == "" {
+			buf.WriteString("//\n")
+			continue
+		}
+		fmt.Fprintf(buf, "// %s\n", line)
+	}
+}
+
+// wrap splits text into lines not longer than width
+func wrap(text string, width int) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			if line != "" && len(line)+1+len(word) > width {
+				lines = append(lines, line)
+				line = ""
+			}
+			if line != "" {
+				line += " "
+			}
+			line += word
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// initialisms are written in upper case in Go names
+var initialisms = map[string]string{
+	"id":   "ID",
+	"ids":  "IDs",
+	"url":  "URL",
+	"mime": "MIME",
+}
+
+// camelCase turns snake_case and lowerCamelCase names into CamelCase: file_unique_id -> FileUniqueID
+func camelCase(name string) string {
+	var out strings.Builder
+	for _, word := range splitWords(name) {
+		if s, ok := initialisms[strings.ToLower(word)]; ok {
+			out.WriteString(s)
+			continue
+		}
+		out.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return out.String()
+}
+
+// lowerCamelCase turns snake_case names into lowerCamelCase: chat_id -> chatID
+func lowerCamelCase(name string) string {
+	words := splitWords(name)
+	result := strings.ToLower(words[0]) + camelCase(strings.Join(words[1:], "_"))
+	if token.IsKeyword(result) {
+		result += "Value"
+	}
+	return result
+}
+
+// appendUnique appends s to list if it's not there yet
+func appendUnique(list []string, s string) []string {
+	for _, v := range list {
+		if v == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
+
+func lowerFirst(s string) string {
+	for word, initialism := range initialisms {
+		if strings.HasPrefix(s, initialism) && (len(s) == len(initialism) || unicode.IsUpper(rune(s[len(initialism)]))) {
+			return word + s[len(initialism):]
+		}
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+// splitWords splits snake_case, lowerCamelCase and CamelCase names into words
+func splitWords(name string) []string {
+	var words []string
+	for _, part := range strings.Split(name, "_") {
+		start := 0
+		for i := 1; i < len(part); i++ {
+			if unicode.IsUpper(rune(part[i])) && !unicode.IsUpper(rune(part[i-1])) {
+				words = append(words, part[start:i])
+				start = i
+			}
+		}
+		if start < len(part) {
+			words = append(words, part[start:])
+		}
+	}
+	return words
+}
+
+// receiver returns receiver name of type: ChatMemberUpdated -> cmu
+func receiver(name string) string {
+	var r strings.Builder
+	for _, word := range splitWords(name) {
+		r.WriteString(strings.ToLower(word[:1]))
+	}
+	return r.String()
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGeneratedFilesUpToDate(t *testing.T) {
+	root := filepath.Join("..", "..")
+	out, err := run(filepath.Join(root, "spec", "botapi.json"), filepath.Join(root, "spec", "tbot.json"),
+		root, "types_gen.go", "methods_gen.go")
+	if err != nil {
+		t.Fatalf("unable to generate: %v", err)
+	}
+	for name, src := range out {
+		checkedIn, err := ioutil.ReadFile(filepath.Join(root, name))
+		if err != nil {
+			t.Fatalf("unable to read %s: %v", name, err)
+		}
+		if !bytes.Equal(src, checkedIn) {
+			t.Errorf("%s is out of date, run go generate", name)
+		}
+	}
+}
+
+func TestNames(t *testing.T) {
+	for name, want := range map[string]string{
+		"file_unique_id":    "FileUniqueID",
+		"option_ids":        "OptionIDs",
+		"thumb_url":         "ThumbURL",
+		"mime_type":         "MIMEType",
+		"banChatMember":     "BanChatMember",
+		"message_thread_id": "MessageThreadID",
+	} {
+		if got := camelCase(name); got != want {
+			t.Errorf("camelCase(%q) = %q, want %q", name, got, want)
+		}
+	}
+	if got := lowerCamelCase("sender_chat_id"); got != "senderChatID" {
+		t.Errorf("unexpected lowerCamelCase: %s", got)
+	}
+	if got := receiver("ChatMemberUpdated"); got != "cmu" {
+		t.Errorf("unexpected receiver: %s", got)
+	}
+}
+
+func TestDocs(t *testing.T) {
+	doc := methodDoc("GetChatMemberCount", []string{"Use this method to get the number of members in a chat. Returns Int on success."})
+	if doc != "GetChatMemberCount gets the number of members in a chat" {
+		t.Errorf("unexpected method doc: %s", doc)
+	}
+	doc = typeDoc("Chat", []string{"This object represents a chat."})
+	if doc != "Chat represents a chat" {
+		t.Errorf("unexpected type doc: %s", doc)
+	}
+	first := firstSentence("For example, hashtags, usernames, URLs, etc. that appear in the text. More.")
+	if first != "For example, hashtags, usernames, URLs, etc. that appear in the text" {
+		t.Errorf("unexpected first sentence: %s", first)
+	}
+}
+
+func TestMethodSpecInConfig(t *testing.T) {
+	root := filepath.Join("..", "..")
+	dir, err := ioutil.TempDir("", "gen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	config := filepath.Join(dir, "tbot.json")
+	ioutil.WriteFile(config, []byte(`{"methods": [{"name": "banChatSenderChat", "spec": {"returns": ["Boolean"]}}]}`), 0644)
+	_, err = run(filepath.Join(root, "spec", "botapi.json"), config, root, "types_gen.go", "methods_gen.go")
+	if err == nil || !strings.Contains(err.Error(), "banChatSenderChat") {
+		t.Errorf("expected error for method described twice, got %v", err)
+	}
+}
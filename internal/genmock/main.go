@@ -0,0 +1,334 @@
+/*
+Command genmock generates tbotmock.Client, a recording mock of the tbot.API interface
+and capability interfaces it embeds:
+
+	go generate github.com/yanzay/tbot/v2/tbotmock
+
+Every mock method records its arguments and options, calls <Method>Func field of the mock
+if it is set, and returns zero results otherwise, with pointers to empty values instead of nil pointers.
+*/
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const tbotPath = "github.com/yanzay/tbot/v2"
+
+func main() {
+	dir := flag.String("dir", "..", "directory of package tbot")
+	iface := flag.String("interface", "API", "interface to mock")
+	out := flag.String("out", "mock_gen.go", "output file")
+	flag.Parse()
+	log.SetFlags(0)
+	log.SetPrefix("genmock: ")
+
+	src, err := run(*dir, *iface)
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = ioutil.WriteFile(*out, src, 0644)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+type method struct {
+	name string
+	typ  *ast.FuncType
+}
+
+// run generates source of the mock of interface iface declared in package tbot in dir
+func run(dir, iface string) ([]byte, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+	pkg, ok := pkgs["tbot"]
+	if !ok {
+		return nil, fmt.Errorf("package tbot is not found in %s", dir)
+	}
+	g := &generator{
+		fset:       fset,
+		interfaces: map[string]*ast.InterfaceType{},
+		imports:    map[string]string{},
+		used:       map[string]bool{"net/url": true},
+	}
+	for _, file := range pkg.Files {
+		for _, decl := range file.Decls {
+			d, ok := decl.(*ast.GenDecl)
+			if !ok || d.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range d.Specs {
+				s := spec.(*ast.TypeSpec)
+				if it, ok := s.Type.(*ast.InterfaceType); ok {
+					g.interfaces[s.Name.Name] = it
+					g.addImports(file)
+				}
+			}
+		}
+	}
+	methods, err := g.methods(iface)
+	if err != nil {
+		return nil, err
+	}
+	return g.generate(iface, methods)
+}
+
+type generator struct {
+	fset       *token.FileSet
+	interfaces map[string]*ast.InterfaceType
+	imports    map[string]string // package name to import path
+	used       map[string]bool
+}
+
+func (g *generator) addImports(file *ast.File) {
+	for _, imp := range file.Imports {
+		path, _ := strconv.Unquote(imp.Path.Value)
+		name := path[strings.LastIndex(path, "/")+1:]
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		g.imports[name] = path
+	}
+}
+
+// methods returns methods of the interface including methods of embedded interfaces
+func (g *generator) methods(name string) ([]*method, error) {
+	it, ok := g.interfaces[name]
+	if !ok {
+		return nil, fmt.Errorf("interface %s is not found", name)
+	}
+	var methods []*method
+	for _, field := range it.Methods.List {
+		switch t := field.Type.(type) {
+		case *ast.Ident:
+			embedded, err := g.methods(t.Name)
+			if err != nil {
+				return nil, err
+			}
+			methods = append(methods, embedded...)
+		case *ast.FuncType:
+			for _, name := range field.Names {
+				methods = append(methods, &method{name: name.Name, typ: t})
+			}
+		default:
+			return nil, fmt.Errorf("unsupported field of interface %s", name)
+		}
+	}
+	return methods, nil
+}
+
+func (g *generator) generate(iface string, methods []*method) ([]byte, error) {
+	var body bytes.Buffer
+	fmt.Fprintf(&body, "// Client is a recording mock of tbot.%s\n", iface)
+	fmt.Fprintf(&body, "type Client struct {\n\trecorder\n\n")
+	for _, m := range methods {
+		typ, err := g.expr(m.typ)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&body, "\t%sFunc %s\n", m.name, typ)
+	}
+	fmt.Fprintf(&body, "}\n\nvar _ tbot.%s = (*Client)(nil)\n", iface)
+	for _, m := range methods {
+		err := g.method(&body, m)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", m.name, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by internal/genmock from tbot.%s; DO NOT EDIT.\n\npackage tbotmock\n\nimport (\n", iface)
+	var paths []string
+	for path := range g.used {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		fmt.Fprintf(&buf, "\t%q\n", path)
+	}
+	fmt.Fprintf(&buf, "\n\t%q\n)\n\n", tbotPath)
+	buf.Write(body.Bytes())
+	return format.Source(buf.Bytes())
+}
+
+func (g *generator) method(w *bytes.Buffer, m *method) error {
+	var params, args, recorded []string
+	var opts string
+	for _, field := range m.typ.Params.List {
+		typ, err := g.expr(field.Type)
+		if err != nil {
+			return err
+		}
+		if len(field.Names) == 0 {
+			return fmt.Errorf("parameters must be named")
+		}
+		var names []string
+		for _, name := range field.Names {
+			if name.Name == "c" || name.Name == "values" {
+				return fmt.Errorf("parameter name %s clashes with mock variables", name.Name)
+			}
+			names = append(names, name.Name)
+		}
+		params = append(params, strings.Join(names, ", ")+" "+typ)
+		if _, ok := field.Type.(*ast.Ellipsis); ok {
+			opts = names[0]
+			args = append(args, opts+"...")
+			continue
+		}
+		args = append(args, names...)
+		recorded = append(recorded, names...)
+	}
+	var results, zeros []string
+	if m.typ.Results != nil {
+		for _, field := range m.typ.Results.List {
+			typ, err := g.expr(field.Type)
+			if err != nil {
+				return err
+			}
+			n := len(field.Names)
+			if n == 0 {
+				n = 1
+			}
+			for i := 0; i < n; i++ {
+				results = append(results, typ)
+				zeros = append(zeros, zero(field.Type, typ))
+			}
+		}
+	}
+	result := strings.Join(results, ", ")
+	if len(results) > 1 {
+		result = "(" + result + ")"
+	}
+
+	fmt.Fprintf(w, "\n// %s records the call and calls %sFunc if it is set\n", m.name, m.name)
+	fmt.Fprintf(w, "func (c *Client) %s(%s) %s {\n", m.name, strings.Join(params, ", "), result)
+	fmt.Fprintf(w, "\tvalues := url.Values{}\n")
+	if opts != "" {
+		fmt.Fprintf(w, "\tfor _, opt := range %s {\n\t\ttbot.ApplyOption(values, opt)\n\t}\n", opts)
+	}
+	fmt.Fprintf(w, "\tc.record(%q, values", m.name)
+	for _, name := range recorded {
+		fmt.Fprintf(w, ", %s", name)
+	}
+	fmt.Fprintf(w, ")\n\tif c.%sFunc != nil {\n\t\t", m.name)
+	if len(results) > 0 {
+		w.WriteString("return ")
+	}
+	fmt.Fprintf(w, "c.%sFunc(%s)\n", m.name, strings.Join(args, ", "))
+	if len(results) == 0 {
+		w.WriteString("\t\treturn\n\t}\n}\n")
+		return nil
+	}
+	fmt.Fprintf(w, "\t}\n\treturn %s\n}\n", strings.Join(zeros, ", "))
+	return nil
+}
+
+// zero returns the default result of type t printed as typ
+func zero(t ast.Expr, typ string) string {
+	switch t := t.(type) {
+	case *ast.StarExpr:
+		return "&" + typ[1:] + "{}"
+	case *ast.Ident:
+		switch t.Name {
+		case "string":
+			return `""`
+		case "bool":
+			return "false"
+		case "int", "int64", "float64":
+			return "0"
+		}
+	}
+	return "nil"
+}
+
+// expr prints type expression declared in package tbot as it is used from another package
+func (g *generator) expr(e ast.Expr) (string, error) {
+	q, err := g.qualify(e)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	err = printer.Fprint(&buf, g.fset, q)
+	return buf.String(), err
+}
+
+func (g *generator) qualify(e ast.Expr) (ast.Expr, error) {
+	switch t := e.(type) {
+	case *ast.Ident:
+		if !ast.IsExported(t.Name) {
+			return ast.NewIdent(t.Name), nil
+		}
+		return &ast.SelectorExpr{X: ast.NewIdent("tbot"), Sel: ast.NewIdent(t.Name)}, nil
+	case *ast.SelectorExpr:
+		pkg := t.X.(*ast.Ident).Name
+		path, ok := g.imports[pkg]
+		if !ok {
+			return nil, fmt.Errorf("unknown package %s", pkg)
+		}
+		g.used[path] = true
+		return &ast.SelectorExpr{X: ast.NewIdent(pkg), Sel: ast.NewIdent(t.Sel.Name)}, nil
+	case *ast.StarExpr:
+		x, err := g.qualify(t.X)
+		return &ast.StarExpr{X: x}, err
+	case *ast.ArrayType:
+		elt, err := g.qualify(t.Elt)
+		if t.Len != nil {
+			return nil, fmt.Errorf("unsupported array type")
+		}
+		return &ast.ArrayType{Elt: elt}, err
+	case *ast.Ellipsis:
+		elt, err := g.qualify(t.Elt)
+		return &ast.Ellipsis{Elt: elt}, err
+	case *ast.InterfaceType:
+		if len(t.Methods.List) > 0 {
+			return nil, fmt.Errorf("unsupported interface type")
+		}
+		return ast.NewIdent("interface{}"), nil
+	case *ast.FuncType:
+		params, err := g.qualifyFields(t.Params)
+		if err != nil {
+			return nil, err
+		}
+		results, err := g.qualifyFields(t.Results)
+		return &ast.FuncType{Params: params, Results: results}, err
+	}
+	return nil, fmt.Errorf("unsupported type %T", e)
+}
+
+func (g *generator) qualifyFields(fields *ast.FieldList) (*ast.FieldList, error) {
+	if fields == nil {
+		return nil, nil
+	}
+	q := &ast.FieldList{}
+	for _, field := range fields.List {
+		typ, err := g.qualify(field.Type)
+		if err != nil {
+			return nil, err
+		}
+		var names []*ast.Ident
+		for _, name := range field.Names {
+			names = append(names, ast.NewIdent(name.Name))
+		}
+		q.List = append(q.List, &ast.Field{Names: names, Type: typ})
+	}
+	return q, nil
+}
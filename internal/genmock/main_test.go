@@ -0,0 +1,22 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestGeneratedMockUpToDate(t *testing.T) {
+	src, err := run(filepath.Join("..", ".."), "API")
+	if err != nil {
+		t.Fatalf("unable to generate: %v", err)
+	}
+	checkedIn, err := ioutil.ReadFile(filepath.Join("..", "..", "tbotmock", "mock_gen.go"))
+	if err != nil {
+		t.Fatalf("unable to read mock: %v", err)
+	}
+	if !bytes.Equal(src, checkedIn) {
+		t.Errorf("tbotmock/mock_gen.go is out of date, run go generate")
+	}
+}
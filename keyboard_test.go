@@ -0,0 +1,79 @@
+package tbot_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yanzay/tbot/v2"
+)
+
+func TestInlineKeyboardColumns(t *testing.T) {
+	kb := tbot.NewKeyboardMaker().Columns(2).Add(
+		tbot.InlineCallbackButton("1", "1"),
+		tbot.InlineCallbackButton("2", "2"),
+		tbot.InlineCallbackButton("3", "3"),
+	).Build()
+	if len(kb.InlineKeyboard) != 2 || len(kb.InlineKeyboard[0]) != 2 || len(kb.InlineKeyboard[1]) != 1 {
+		t.Fatalf("wrong layout: %+v", kb.InlineKeyboard)
+	}
+	if err := kb.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+}
+
+func TestReplyKeyboardMaxRowWidth(t *testing.T) {
+	kb := tbot.NewReplyKeyboardMaker().MaxRowWidth(10).Add(
+		tbot.ReplyTextButton("Yes"),
+		tbot.ReplyTextButton("No"),
+		tbot.ReplyTextButton("Maybe later"),
+	).Build()
+	if len(kb.Keyboard) != 2 || len(kb.Keyboard[0]) != 2 {
+		t.Fatalf("wrong layout: %+v", kb.Keyboard)
+	}
+}
+
+func TestKeyboardBuildChecked(t *testing.T) {
+	km := tbot.NewKeyboardMaker().Add(tbot.InlineCallbackButton("ok", "ok"))
+	if kb, err := km.BuildChecked(); err != nil || len(kb.InlineKeyboard) != 1 {
+		t.Fatalf("unexpected keyboard %v, error %v", kb, err)
+	}
+	km.Add(tbot.InlineCallbackButton("long", strings.Repeat("a", 65)))
+	if _, err := km.BuildChecked(); err == nil {
+		t.Errorf("expected error for long callback data")
+	}
+	rm := tbot.NewReplyKeyboardMaker()
+	rm.SetPlaceholder(strings.Repeat("a", 65))
+	if _, err := rm.Add(tbot.ReplyTextButton("yes")).BuildChecked(); err == nil {
+		t.Errorf("expected error for long placeholder")
+	}
+}
+
+func TestInlineButtonValidate(t *testing.T) {
+	b := tbot.InlineCallbackButton("text", "data")
+	b.URL = "https://example.com"
+	if err := b.Validate(); err == nil {
+		t.Fatalf("expected error for button with two actions")
+	}
+	long := tbot.InlineCallbackButton("text", strings.Repeat("x", 65))
+	if err := long.Validate(); err == nil {
+		t.Fatalf("expected error for long callback data")
+	}
+	if err := tbot.InlinePayButton("pay").Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestReplyRequestButtons(t *testing.T) {
+	user := tbot.ReplyRequestUserButton("Share bot", 1, true)
+	if user.RequestUser == nil || user.RequestUser.RequestID != 1 || !*user.RequestUser.UserIsBot {
+		t.Fatalf("unexpected button %+v", user)
+	}
+	chat := tbot.ReplyRequestChatButton("Share channel", 2, true)
+	if err := chat.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+	chat.RequestContact = true
+	if err := chat.Validate(); err == nil {
+		t.Fatalf("expected error for button with two actions")
+	}
+}
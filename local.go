@@ -0,0 +1,90 @@
+package tbot
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+)
+
+// File size limits of Bot API, in bytes
+const (
+	MaxUploadSize      = 50 << 20   // uploads to api.telegram.org
+	MaxDownloadSize    = 20 << 20   // downloads from api.telegram.org
+	LocalMaxUploadSize = 2000 << 20 // uploads to a local Bot API server, see WithLocalMode
+)
+
+// FileURI returns file:// URI of the local file, a local Bot API server accepts it
+// instead of file ID, e.g. client.SendDocument(chatID, tbot.FileURI("/data/report.pdf"))
+func FileURI(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = path
+	}
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(abs)}).String()
+}
+
+func (c *Client) maxUploadSize() int64 {
+	if c.localMode {
+		return LocalMaxUploadSize
+	}
+	return MaxUploadSize
+}
+
+func (c *Client) checkUploadSize(files []inputFile) error {
+	for _, file := range files {
+		info, err := os.Stat(file.name)
+		if err != nil {
+			return err
+		}
+		if info.Size() > c.maxUploadSize() {
+			return fmt.Errorf("file %s is larger than %d MB", file.name, c.maxUploadSize()>>20)
+		}
+	}
+	return nil
+}
+
+// localFileValues passes files to a local Bot API server as file:// URIs
+func localFileValues(request url.Values, files []inputFile) url.Values {
+	if request == nil {
+		request = url.Values{}
+	}
+	for _, file := range files {
+		request.Set(file.field, FileURI(file.name))
+	}
+	return request
+}
+
+// localPath returns path of the file on the disk of a local Bot API server
+func (c *Client) localPath(file *File) (string, bool) {
+	if !c.localMode || !filepath.IsAbs(file.FilePath) {
+		return "", false
+	}
+	return file.FilePath, true
+}
+
+// openLocalFile returns file written by a local Bot API server as a download response
+func openLocalFile(path string) (*http.Response, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open file: %v", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("unable to open file: %v", err)
+	}
+	header := http.Header{}
+	if ct := mime.TypeByExtension(filepath.Ext(path)); ct != "" {
+		header.Set("Content-Type", ct)
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Header:        header,
+		Body:          f,
+		ContentLength: info.Size(),
+	}, nil
+}
@@ -0,0 +1,100 @@
+package tbot_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/yanzay/tbot/v2"
+	"github.com/yanzay/tbot/v2/tbottest"
+)
+
+func localBot() (*tbottest.Server, *tbot.Client) {
+	api := tbottest.NewServer("TOKEN")
+	bot := tbot.New("TOKEN", tbot.WithBaseURL(api.URL()), tbot.WithHTTPClient(api.HTTPClient()), tbot.WithLocalMode())
+	return api, bot.Client()
+}
+
+func TestLocalModeUpload(t *testing.T) {
+	api, c := localBot()
+	defer api.Close()
+	dir, err := ioutil.TempDir("", "tbot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "report.txt")
+	ioutil.WriteFile(path, []byte("report"), 0600)
+
+	_, err = c.SendDocumentFile("1", path)
+	if err != nil {
+		t.Fatalf("unable to send document: %v", err)
+	}
+	call := api.CallsTo("sendDocument")[0]
+	if call.Params.Get("document") != tbot.FileURI(path) || len(call.Files) != 0 {
+		t.Errorf("expected document passed as %s, got %v %v", tbot.FileURI(path), call.Params, call.Files)
+	}
+}
+
+func TestLocalModeDownload(t *testing.T) {
+	api, c := localBot()
+	defer api.Close()
+	dir, err := ioutil.TempDir("", "tbot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "photo.jpg")
+	ioutil.WriteFile(path, []byte("jpeg data"), 0600)
+	api.Handle("getFile", func(call tbottest.Call) (interface{}, *tbottest.APIError) {
+		return &tbot.File{FileID: call.Params.Get("file_id"), FilePath: path}, nil
+	})
+
+	r, err := c.DownloadFile("abc")
+	if err != nil {
+		t.Fatalf("unable to download file: %v", err)
+	}
+	data, _ := ioutil.ReadAll(r)
+	r.Close()
+	if string(data) != "jpeg data" {
+		t.Errorf("unexpected content %q", data)
+	}
+	if url := c.FileURL(&tbot.File{FilePath: path}); url != tbot.FileURI(path) {
+		t.Errorf("unexpected file url %s", url)
+	}
+}
+
+func TestLogOutAndClose(t *testing.T) {
+	api, c := localBot()
+	defer api.Close()
+	if err := c.LogOut(); err != nil {
+		t.Errorf("unable to log out: %v", err)
+	}
+	if err := c.Close(); err != nil {
+		t.Errorf("unable to close: %v", err)
+	}
+	if len(api.CallsTo("logOut")) != 1 || len(api.CallsTo("close")) != 1 {
+		t.Errorf("unexpected calls %v", api.Calls())
+	}
+}
+
+func TestUploadSizeLimit(t *testing.T) {
+	api := tbottest.NewServer("TOKEN")
+	defer api.Close()
+	f, err := ioutil.TempFile("", "big")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	f.Truncate(tbot.MaxUploadSize + 1)
+	f.Close()
+
+	_, err = api.Client().SendDocumentFile("1", f.Name())
+	if err == nil {
+		t.Errorf("expected error for file larger than the limit")
+	}
+	if len(api.CallsTo("sendDocument")) != 0 {
+		t.Errorf("file is uploaded")
+	}
+}
@@ -1,6 +1,10 @@
 package tbot
 
-import "log"
+import (
+	"fmt"
+	"log"
+	"strings"
+)
 
 // Logger defines interface for any compatible logger
 type Logger interface {
@@ -17,6 +21,38 @@ type Logger interface {
 	Error(args ...interface{})
 }
 
+// Level is a logging level
+type Level int
+
+// Logging levels
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	}
+	return fmt.Sprintf("LEVEL(%d)", int(l))
+}
+
+// StructuredLogger defines interface for loggers with key/value fields, see WithStructuredLogger.
+// Keyvals are alternating keys and values, e.g. "method", "sendMessage", "latency", time.Second.
+// Library logs use keys update_id, update_type, chat_id, method, code, latency and error.
+type StructuredLogger interface {
+	Log(level Level, msg string, keyvals ...interface{})
+}
+
 type nopLogger struct{}
 
 func (nopLogger) Debugf(format string, args ...interface{}) {}
@@ -29,16 +65,140 @@ func (nopLogger) Info(args ...interface{})                  {}
 func (nopLogger) Print(args ...interface{})                 {}
 func (nopLogger) Warn(args ...interface{})                  {}
 func (nopLogger) Error(args ...interface{})                 {}
+func (nopLogger) Log(Level, string, ...interface{})         {}
 
+// BasicLogger writes messages prefixed with level using standard log package.
+// It implements both Logger and StructuredLogger.
 type BasicLogger struct{}
 
-func (BasicLogger) Debugf(format string, args ...interface{}) { log.Printf(format, args...) }
-func (BasicLogger) Infof(format string, args ...interface{})  { log.Printf(format, args...) }
+func (BasicLogger) Debugf(format string, args ...interface{}) { log.Printf("DEBUG "+format, args...) }
+func (BasicLogger) Infof(format string, args ...interface{})  { log.Printf("INFO "+format, args...) }
 func (BasicLogger) Printf(format string, args ...interface{}) { log.Printf(format, args...) }
-func (BasicLogger) Warnf(format string, args ...interface{})  { log.Printf(format, args...) }
-func (BasicLogger) Errorf(format string, args ...interface{}) { log.Printf(format, args...) }
-func (BasicLogger) Debug(args ...interface{})                 { log.Print(args...) }
-func (BasicLogger) Info(args ...interface{})                  { log.Print(args...) }
+func (BasicLogger) Warnf(format string, args ...interface{})  { log.Printf("WARN "+format, args...) }
+func (BasicLogger) Errorf(format string, args ...interface{}) { log.Printf("ERROR "+format, args...) }
+func (BasicLogger) Debug(args ...interface{})                 { log.Print(append([]interface{}{"DEBUG "}, args...)...) }
+func (BasicLogger) Info(args ...interface{})                  { log.Print(append([]interface{}{"INFO "}, args...)...) }
 func (BasicLogger) Print(args ...interface{})                 { log.Print(args...) }
-func (BasicLogger) Warn(args ...interface{})                  { log.Print(args...) }
-func (BasicLogger) Error(args ...interface{})                 { log.Print(args...) }
+func (BasicLogger) Warn(args ...interface{})                  { log.Print(append([]interface{}{"WARN "}, args...)...) }
+func (BasicLogger) Error(args ...interface{})                 { log.Print(append([]interface{}{"ERROR "}, args...)...) }
+
+// Log implements StructuredLogger
+func (BasicLogger) Log(level Level, msg string, keyvals ...interface{}) {
+	log.Print(level.String() + " " + formatFields(msg, keyvals))
+}
+
+// printfLogger adapts Logger to StructuredLogger, fields are appended to the message as key=value
+type printfLogger struct {
+	l Logger
+}
+
+func (p printfLogger) Log(level Level, msg string, keyvals ...interface{}) {
+	line := formatFields(msg, keyvals)
+	switch level {
+	case LevelDebug:
+		p.l.Debug(line)
+	case LevelInfo:
+		p.l.Info(line)
+	case LevelWarn:
+		p.l.Warn(line)
+	default:
+		p.l.Error(line)
+	}
+}
+
+func formatFields(msg string, keyvals []interface{}) string {
+	var b strings.Builder
+	b.WriteString(msg)
+	for i := 0; i < len(keyvals); i += 2 {
+		var v interface{} = "(MISSING)"
+		if i+1 < len(keyvals) {
+			v = keyvals[i+1]
+		}
+		s := fmt.Sprint(v)
+		if strings.ContainsAny(s, " \t\n\"=") {
+			s = fmt.Sprintf("%q", s)
+		}
+		fmt.Fprintf(&b, " %v=%s", keyvals[i], s)
+	}
+	return b.String()
+}
+
+// ZapSugaredLogger is implemented by *zap.SugaredLogger
+type ZapSugaredLogger interface {
+	Debugw(msg string, keysAndValues ...interface{})
+	Infow(msg string, keysAndValues ...interface{})
+	Warnw(msg string, keysAndValues ...interface{})
+	Errorw(msg string, keysAndValues ...interface{})
+}
+
+// NewZapLogger adapts zap logger to StructuredLogger, e.g. NewZapLogger(zapLogger.Sugar())
+func NewZapLogger(l ZapSugaredLogger) StructuredLogger {
+	return zapLogger{l: l}
+}
+
+type zapLogger struct {
+	l ZapSugaredLogger
+}
+
+func (z zapLogger) Log(level Level, msg string, keyvals ...interface{}) {
+	switch level {
+	case LevelDebug:
+		z.l.Debugw(msg, keyvals...)
+	case LevelInfo:
+		z.l.Infow(msg, keyvals...)
+	case LevelWarn:
+		z.l.Warnw(msg, keyvals...)
+	default:
+		z.l.Errorw(msg, keyvals...)
+	}
+}
+
+const redacted = "[REDACTED]"
+
+// libLogger is used for library logs, it drops messages below the minimum level
+// and hides the bot token
+type libLogger struct {
+	out   StructuredLogger
+	level Level
+	token string
+}
+
+func newLibLogger(out StructuredLogger, level Level, token string) *libLogger {
+	return &libLogger{out: out, level: level, token: token}
+}
+
+func (l *libLogger) log(level Level, msg string, keyvals []interface{}) {
+	if l == nil || level < l.level {
+		return
+	}
+	if l.token != "" {
+		msg = strings.Replace(msg, l.token, redacted, -1)
+		for i, v := range keyvals {
+			keyvals[i] = l.redact(v)
+		}
+	}
+	l.out.Log(level, msg, keyvals...)
+}
+
+func (l *libLogger) redact(v interface{}) interface{} {
+	var s string
+	switch v := v.(type) {
+	case string:
+		s = v
+	case error:
+		return redactToken(v, l.token)
+	case fmt.Stringer:
+		s = v.String()
+	default:
+		return v
+	}
+	if !strings.Contains(s, l.token) {
+		return v
+	}
+	return strings.Replace(s, l.token, redacted, -1)
+}
+
+func (l *libLogger) debug(msg string, keyvals ...interface{}) { l.log(LevelDebug, msg, keyvals) }
+func (l *libLogger) info(msg string, keyvals ...interface{})  { l.log(LevelInfo, msg, keyvals) }
+func (l *libLogger) warn(msg string, keyvals ...interface{})  { l.log(LevelWarn, msg, keyvals) }
+func (l *libLogger) error(msg string, keyvals ...interface{}) { l.log(LevelError, msg, keyvals) }
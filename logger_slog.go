@@ -0,0 +1,34 @@
+//go:build go1.21
+// +build go1.21
+
+package tbot
+
+import (
+	"context"
+	"log/slog"
+)
+
+// NewSlogLogger adapts slog.Logger to StructuredLogger
+func NewSlogLogger(l *slog.Logger) StructuredLogger {
+	return slogLogger{l: l}
+}
+
+type slogLogger struct {
+	l *slog.Logger
+}
+
+func (s slogLogger) Log(level Level, msg string, keyvals ...interface{}) {
+	s.l.Log(context.Background(), slogLevel(level), msg, keyvals...)
+}
+
+func slogLevel(level Level) slog.Level {
+	switch level {
+	case LevelDebug:
+		return slog.LevelDebug
+	case LevelInfo:
+		return slog.LevelInfo
+	case LevelWarn:
+		return slog.LevelWarn
+	}
+	return slog.LevelError
+}
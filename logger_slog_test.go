@@ -0,0 +1,33 @@
+//go:build go1.21
+// +build go1.21
+
+package tbot_test
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/yanzay/tbot/v2"
+)
+
+func TestSlogLogger(t *testing.T) {
+	buf := &bytes.Buffer{}
+	handler := slog.NewTextHandler(buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+	tbot.NewSlogLogger(slog.New(handler)).Log(tbot.LevelWarn, "bot api call failed", "method", "sendMessage", "code", 403)
+
+	got := strings.TrimSpace(buf.String())
+	want := `level=WARN msg="bot api call failed" method=sendMessage code=403`
+	if got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
@@ -0,0 +1,134 @@
+package tbot_test
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/yanzay/tbot/v2"
+	"github.com/yanzay/tbot/v2/tbottest"
+)
+
+type logEntry struct {
+	level  tbot.Level
+	msg    string
+	fields map[string]interface{}
+}
+
+type testLogger struct {
+	mu      sync.Mutex
+	entries []logEntry
+}
+
+func (l *testLogger) Log(level tbot.Level, msg string, keyvals ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	fields := make(map[string]interface{})
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		fields[fmt.Sprint(keyvals[i])] = keyvals[i+1]
+	}
+	l.entries = append(l.entries, logEntry{level: level, msg: msg, fields: fields})
+}
+
+func (l *testLogger) find(msg string) (logEntry, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, e := range l.entries {
+		if e.msg == msg {
+			return e, true
+		}
+	}
+	return logEntry{}, false
+}
+
+func TestStructuredLogger(t *testing.T) {
+	logger := &testLogger{}
+	d := tbottest.NewDriver(t, tbot.WithStructuredLogger(logger), tbot.WithLogLevel(tbot.LevelDebug))
+	defer d.Close()
+	d.Bot.HandleMessage("", func(m *tbot.Message) {
+		d.Bot.Client().SendMessage(m.Chat.ID, "hi")
+	})
+	user := tbottest.User(42, "alice")
+	d.SendText(user, tbottest.PrivateChat(user), "hello")
+
+	processed, ok := logger.find("update processed")
+	if !ok {
+		t.Fatalf("update is not logged")
+	}
+	if processed.level != tbot.LevelDebug || processed.fields["chat_id"] != "42" ||
+		processed.fields["update_type"] != "message" || processed.fields["update_id"] == nil || processed.fields["latency"] == nil {
+		t.Errorf("unexpected update log: %+v", processed)
+	}
+	call, ok := logger.find("bot api call")
+	if !ok {
+		t.Fatalf("api call is not logged")
+	}
+	if call.fields["method"] != "sendMessage" || call.fields["code"] != 200 {
+		t.Errorf("unexpected api call log: %+v", call)
+	}
+}
+
+func TestLogLevel(t *testing.T) {
+	logger := &testLogger{}
+	d := tbottest.NewDriver(t, tbot.WithStructuredLogger(logger))
+	defer d.Close()
+	user := tbottest.User(42, "alice")
+	d.SendText(user, tbottest.PrivateChat(user), "hello")
+	if len(logger.entries) != 0 {
+		t.Errorf("expected debug logs to be filtered, got %+v", logger.entries)
+	}
+}
+
+func TestLoggerRedactsToken(t *testing.T) {
+	logger := &testLogger{}
+	token := "123456:SECRET"
+	bot := tbot.New(token, tbot.WithBaseURL("http://127.0.0.1:1"), tbot.WithStructuredLogger(logger))
+	_, err := bot.Client().SendMessage("1", "hi")
+	if err == nil {
+		t.Fatalf("expected connection error")
+	}
+	failed, ok := logger.find("bot api call failed")
+	if !ok {
+		t.Fatalf("failed call is not logged")
+	}
+	msg := fmt.Sprint(failed.fields["error"])
+	if strings.Contains(msg, token) || !strings.Contains(msg, "[REDACTED]") {
+		t.Errorf("token is not redacted: %s", msg)
+	}
+}
+
+type printfLogger struct {
+	lines []string
+}
+
+func (l *printfLogger) Debugf(format string, args ...interface{}) {}
+func (l *printfLogger) Infof(format string, args ...interface{})  {}
+func (l *printfLogger) Printf(format string, args ...interface{}) {}
+func (l *printfLogger) Warnf(format string, args ...interface{})  {}
+func (l *printfLogger) Errorf(format string, args ...interface{}) {}
+func (l *printfLogger) Debug(args ...interface{})                 { l.lines = append(l.lines, fmt.Sprint(args...)) }
+func (l *printfLogger) Info(args ...interface{})                  {}
+func (l *printfLogger) Print(args ...interface{})                 {}
+func (l *printfLogger) Warn(args ...interface{})                  {}
+func (l *printfLogger) Error(args ...interface{})                 {}
+
+func TestPrintfLoggerFields(t *testing.T) {
+	logger := &printfLogger{}
+	d := tbottest.NewDriver(t, tbot.WithLogger(logger), tbot.WithLogLevel(tbot.LevelDebug))
+	defer d.Close()
+	d.Bot.HandleMessage("", func(m *tbot.Message) {
+		d.Bot.Client().SendMessage(m.Chat.ID, "hi")
+	})
+	user := tbottest.User(42, "alice")
+	d.SendText(user, tbottest.PrivateChat(user), "hello")
+	if len(logger.lines) != 2 {
+		t.Fatalf("expected 2 lines, got %q", logger.lines)
+	}
+	if !strings.HasPrefix(logger.lines[0], "bot api call method=sendMessage code=200 latency=") {
+		t.Errorf("unexpected line %q", logger.lines[0])
+	}
+	if !strings.HasPrefix(logger.lines[1], "update processed update_id=1 update_type=message chat_id=42 latency=") {
+		t.Errorf("unexpected line %q", logger.lines[1])
+	}
+}
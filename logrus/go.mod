@@ -0,0 +1,12 @@
+module github.com/yanzay/tbot/v2/logrus
+
+go 1.23
+
+require (
+	github.com/sirupsen/logrus v1.10.2
+	github.com/yanzay/tbot/v2 v2.0.0
+)
+
+require golang.org/x/sys v0.13.0 // indirect
+
+replace github.com/yanzay/tbot/v2 => ../
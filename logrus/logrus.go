@@ -0,0 +1,51 @@
+/*
+Package logrus adapts logrus loggers to tbot.StructuredLogger.
+
+It's a separate module, so tbot itself stays free of dependencies.
+Import it as tbotlogrus to avoid a clash with github.com/sirupsen/logrus:
+
+	bot := tbot.New(token, tbot.WithStructuredLogger(tbotlogrus.New(logrus.StandardLogger())))
+*/
+package logrus
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/yanzay/tbot/v2"
+)
+
+// Logger implements tbot.StructuredLogger, fields are passed to logrus as logrus.Fields
+type Logger struct {
+	l logrus.FieldLogger
+}
+
+var _ tbot.StructuredLogger = (*Logger)(nil)
+
+// New creates Logger writing to l, which is *logrus.Logger or *logrus.Entry
+func New(l logrus.FieldLogger) *Logger {
+	return &Logger{l: l}
+}
+
+// Log implements tbot.StructuredLogger
+func (l *Logger) Log(level tbot.Level, msg string, keyvals ...interface{}) {
+	fields := make(logrus.Fields, len(keyvals)/2)
+	for i := 0; i < len(keyvals); i += 2 {
+		var v interface{} = "(MISSING)"
+		if i+1 < len(keyvals) {
+			v = keyvals[i+1]
+		}
+		fields[fmt.Sprint(keyvals[i])] = v
+	}
+	entry := l.l.WithFields(fields)
+	switch level {
+	case tbot.LevelDebug:
+		entry.Debug(msg)
+	case tbot.LevelInfo:
+		entry.Info(msg)
+	case tbot.LevelWarn:
+		entry.Warn(msg)
+	default:
+		entry.Error(msg)
+	}
+}
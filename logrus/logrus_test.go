@@ -0,0 +1,27 @@
+package logrus_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/yanzay/tbot/v2"
+	tbotlogrus "github.com/yanzay/tbot/v2/logrus"
+)
+
+func TestLogger(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := logrus.New()
+	l.SetOutput(buf)
+	l.SetLevel(logrus.DebugLevel)
+	l.SetFormatter(&logrus.TextFormatter{DisableTimestamp: true})
+
+	tbotlogrus.New(l).Log(tbot.LevelWarn, "bot api call failed", "method", "sendMessage", "code", 403)
+
+	got := strings.TrimSpace(buf.String())
+	want := `level=warning msg="bot api call failed" code=403 method=sendMessage`
+	if got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
@@ -0,0 +1,429 @@
+package tbot
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Text length limits, in UTF-16 code units
+const (
+	MaxMessageLength = 4096
+	MaxCaptionLength = 1024
+)
+
+/*
+SendLongMessage sends text of any length to telegram chat, splitting it into several messages if needed.
+Text is split on paragraph, line or word boundaries. Formatting open at a split point is closed
+at the end of one part and reopened at the beginning of the next one, so HTML and MarkdownV2
+markup stays valid. Parts after the first one are sent as replies to the first message,
+reply markup is attached to the last part only. Available options are the same as for SendMessage.
+Messages sent before an error occurred are returned along with the error.
+*/
+func (c *Client) SendLongMessage(chatID ChatID, text string, opts ...SendMessageOption) ([]*Message, error) {
+	req := url.Values{}
+	for _, opt := range opts {
+		opt.apply(req)
+	}
+	var entities []*MessageEntity
+	if e := req.Get("entities"); e != "" {
+		err := json.Unmarshal([]byte(e), &entities)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode entities: %v", err)
+		}
+	}
+	replyMarkup := req.Get("reply_markup")
+	req.Del("reply_markup")
+	parts := splitMessage(text, entities, MaxMessageLength, req.Get("parse_mode"))
+	msgs := make([]*Message, 0, len(parts))
+	for i, part := range parts {
+		partReq := url.Values{}
+		for k, v := range req {
+			partReq[k] = v
+		}
+		partReq.Set("chat_id", string(chatID))
+		partReq.Set("text", part.text)
+		if entities != nil {
+			setJSON(partReq, "entities", part.entities)
+		}
+		if i > 0 {
+			partReq.Set("reply_to_message_id", fmt.Sprint(msgs[0].MessageID))
+		}
+		if i == len(parts)-1 && replyMarkup != "" {
+			partReq.Set("reply_markup", replyMarkup)
+		}
+		msg := &Message{}
+		err := c.doRequest("sendMessage", partReq, msg)
+		if err != nil {
+			return msgs, err
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
+
+/*
+SplitText splits text into parts no longer than limit UTF-16 code units, e.g. MaxMessageLength
+or MaxCaptionLength. Pass parse mode of the text ("HTML", "MarkdownV2" or empty string for plain text)
+to keep the markup of every part valid.
+*/
+func SplitText(text string, limit int, parseMode string) []string {
+	parts := splitMessage(text, nil, limit, parseMode)
+	texts := make([]string, len(parts))
+	for i, part := range parts {
+		texts[i] = part.text
+	}
+	return texts
+}
+
+type messagePart struct {
+	text     string
+	entities []*MessageEntity
+}
+
+type tokenKind int
+
+const (
+	tokenText tokenKind = iota
+	tokenOpen
+	tokenClose
+)
+
+// textToken is an unsplittable piece of text: a rune, an escape sequence, a tag or a markdown marker
+type textToken struct {
+	text  string
+	size  int
+	kind  tokenKind
+	close string // markup closing the entity opened by tokenOpen
+	rank  int    // split preference of whitespace tokens: paragraph > line > word
+}
+
+func splitMessage(text string, entities []*MessageEntity, limit int, parseMode string) []messagePart {
+	if utf16Len(text) <= limit {
+		return []messagePart{{text: text, entities: entities}}
+	}
+	tokens := tokenize(text, parseMode)
+	var parts []messagePart
+	var stack []*textToken
+	offset, start := 0, 0
+	for start < len(tokens) {
+		open := stack
+		size := markupLen(open, false)
+		cur := stack
+		best, bestRank := -1, 0
+		var bestStack []*textToken
+		i := start
+		for ; i < len(tokens); i++ {
+			t := tokens[i]
+			if t.rank > 0 && t.rank >= bestRank && i > start {
+				best, bestRank, bestStack = i, t.rank, cur
+			}
+			next := applyToken(cur, t)
+			if size+t.size+markupLen(next, true) > limit && i > start {
+				break
+			}
+			size += t.size
+			cur = next
+		}
+		end, nextStart := i, i
+		if i < len(tokens) && best >= 0 {
+			end, nextStart, cur = best, best+1, bestStack
+		}
+		part := renderPart(open, tokens[start:end], cur)
+		partLen := tokensLen(tokens[start:end])
+		if entities != nil {
+			part.entities = clipEntities(entities, offset, offset+partLen)
+		}
+		parts = append(parts, part)
+		offset += partLen + tokensLen(tokens[end:nextStart])
+		start, stack = nextStart, cur
+	}
+	return parts
+}
+
+func applyToken(stack []*textToken, t *textToken) []*textToken {
+	switch t.kind {
+	case tokenOpen:
+		next := make([]*textToken, len(stack), len(stack)+1)
+		copy(next, stack)
+		return append(next, t)
+	case tokenClose:
+		if len(stack) > 0 {
+			return stack[:len(stack)-1]
+		}
+	}
+	return stack
+}
+
+func markupLen(stack []*textToken, closing bool) int {
+	n := 0
+	for _, t := range stack {
+		if closing {
+			n += utf16Len(t.close)
+		} else {
+			n += t.size
+		}
+	}
+	return n
+}
+
+func tokensLen(tokens []*textToken) int {
+	n := 0
+	for _, t := range tokens {
+		n += t.size
+	}
+	return n
+}
+
+func renderPart(open, tokens, close []*textToken) messagePart {
+	var sb strings.Builder
+	for _, t := range open {
+		sb.WriteString(t.text)
+	}
+	for _, t := range tokens {
+		sb.WriteString(t.text)
+	}
+	for i := len(close) - 1; i >= 0; i-- {
+		sb.WriteString(close[i].close)
+	}
+	return messagePart{text: sb.String()}
+}
+
+func clipEntities(entities []*MessageEntity, from, to int) []*MessageEntity {
+	clipped := []*MessageEntity{}
+	for _, e := range entities {
+		start, end := e.Offset, e.Offset+e.Length
+		if start < from {
+			start = from
+		}
+		if end > to {
+			end = to
+		}
+		if end <= start {
+			continue
+		}
+		ce := *e
+		ce.Offset = start - from
+		ce.Length = end - start
+		clipped = append(clipped, &ce)
+	}
+	return clipped
+}
+
+func tokenize(text, parseMode string) []*textToken {
+	switch parseMode {
+	case "HTML":
+		return tokenizeHTML(text)
+	case "MarkdownV2":
+		return tokenizeMarkdownV2(text)
+	}
+	return tokenizePlain(text)
+}
+
+// textLen returns length of text without markup, the way Telegram limits it
+func textLen(text, parseMode string) int {
+	n := 0
+	for _, t := range tokenize(text, parseMode) {
+		switch {
+		case t.kind != tokenText:
+		case len(t.text) > 1 && (t.text[0] == '&' || t.text[0] == '\\'):
+			n++ // escape sequence
+		case len(t.text) > 1 && t.text[0] == '[' && parseMode == "MarkdownV2":
+			n += textLen(t.text[1:indexUnescaped(t.text, 1, ']')], parseMode) // only text of [text](url) link is shown
+		default:
+			n += t.size
+		}
+	}
+	return n
+}
+
+func tokenizePlain(text string) []*textToken {
+	var tokens []*textToken
+	for i := 0; i < len(text); {
+		t, n := whitespaceToken(text[i:])
+		if t == nil {
+			_, n = utf8.DecodeRuneInString(text[i:])
+			t = newTextToken(text[i : i+n])
+		}
+		tokens = append(tokens, t)
+		i += n
+	}
+	return tokens
+}
+
+func tokenizeHTML(text string) []*textToken {
+	var tokens []*textToken
+	var open []string
+	for i := 0; i < len(text); {
+		var t *textToken
+		n := 0
+		switch text[i] {
+		case '<':
+			n = strings.IndexByte(text[i:], '>') + 1
+			if n <= 0 {
+				n = len(text) - i
+			}
+			tag := text[i : i+n]
+			name := htmlTagName(tag)
+			t = &textToken{text: tag}
+			if strings.HasPrefix(tag, "</") {
+				if len(open) > 0 && open[len(open)-1] == name {
+					open = open[:len(open)-1]
+					t.kind = tokenClose
+				}
+			} else {
+				open = append(open, name)
+				t.kind = tokenOpen
+				t.close = "</" + name + ">"
+			}
+		case '&':
+			n = strings.IndexByte(text[i:], ';') + 1
+			if n <= 0 || n > 10 {
+				n = 1
+			}
+			t = newTextToken(text[i : i+n])
+		default:
+			t, n = whitespaceToken(text[i:])
+			if t == nil {
+				_, n = utf8.DecodeRuneInString(text[i:])
+				t = newTextToken(text[i : i+n])
+			}
+		}
+		t.size = utf16Len(t.text)
+		tokens = append(tokens, t)
+		i += n
+	}
+	return tokens
+}
+
+func htmlTagName(tag string) string {
+	name := strings.TrimLeft(tag, "</")
+	if i := strings.IndexAny(name, " \t\n>"); i >= 0 {
+		name = name[:i]
+	}
+	return strings.ToLower(name)
+}
+
+var markdownV2Markers = []string{"||", "__", "*", "_", "~"}
+
+func tokenizeMarkdownV2(text string) []*textToken {
+	var tokens []*textToken
+	var open []string
+	top := func() string {
+		if len(open) == 0 {
+			return ""
+		}
+		return open[len(open)-1]
+	}
+	for i := 0; i < len(text); {
+		rest := text[i:]
+		var t *textToken
+		n := 0
+		inCode := top() == "`" || top() == "```"
+		switch {
+		case rest[0] == '\\' && len(rest) > 1:
+			_, n = utf8.DecodeRuneInString(rest[1:])
+			n++
+			t = newTextToken(rest[:n])
+		case strings.HasPrefix(rest, "```") && top() == "```":
+			n = 3
+			t = &textToken{text: "```", kind: tokenClose}
+			open = open[:len(open)-1]
+		case strings.HasPrefix(rest, "```") && !inCode:
+			n = strings.IndexByte(rest, '\n') + 1
+			if n <= 0 {
+				n = 3
+			}
+			t = &textToken{text: rest[:n], kind: tokenOpen, close: "```"}
+			open = append(open, "```")
+		case rest[0] == '`' && top() == "`":
+			n = 1
+			t = &textToken{text: "`", kind: tokenClose}
+			open = open[:len(open)-1]
+		case rest[0] == '`' && !inCode:
+			n = 1
+			t = &textToken{text: "`", kind: tokenOpen, close: "`"}
+			open = append(open, "`")
+		case rest[0] == '[' && !inCode:
+			n = markdownV2LinkLen(rest)
+			if n > 0 {
+				t = newTextToken(rest[:n])
+			}
+		}
+		if t == nil && !inCode {
+			for _, marker := range markdownV2Markers {
+				if !strings.HasPrefix(rest, marker) {
+					continue
+				}
+				n = len(marker)
+				if top() == marker {
+					t = &textToken{text: marker, kind: tokenClose}
+					open = open[:len(open)-1]
+				} else {
+					t = &textToken{text: marker, kind: tokenOpen, close: marker}
+					open = append(open, marker)
+				}
+				break
+			}
+		}
+		if t == nil {
+			t, n = whitespaceToken(rest)
+		}
+		if t == nil {
+			_, n = utf8.DecodeRuneInString(rest)
+			t = newTextToken(rest[:n])
+		}
+		t.size = utf16Len(t.text)
+		tokens = append(tokens, t)
+		i += n
+	}
+	return tokens
+}
+
+// markdownV2LinkLen returns length of the [text](url) link at the beginning of s, or 0
+func markdownV2LinkLen(s string) int {
+	i := indexUnescaped(s, 1, ']')
+	if i < 0 || i+1 >= len(s) || s[i+1] != '(' {
+		return 0
+	}
+	j := indexUnescaped(s, i+2, ')')
+	if j < 0 {
+		return 0
+	}
+	return j + 1
+}
+
+func indexUnescaped(s string, from int, c byte) int {
+	for i := from; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case c:
+			return i
+		}
+	}
+	return -1
+}
+
+func whitespaceToken(s string) (*textToken, int) {
+	switch {
+	case strings.HasPrefix(s, "\n\n"):
+		return &textToken{text: "\n\n", size: 2, rank: 3}, 2
+	case s[0] == '\n':
+		return &textToken{text: "\n", size: 1, rank: 2}, 1
+	case s[0] == ' ':
+		return &textToken{text: " ", size: 1, rank: 1}, 1
+	}
+	return nil, 0
+}
+
+func newTextToken(s string) *textToken {
+	return &textToken{text: s, size: utf16Len(s)}
+}
+
+func utf16Len(s string) int {
+	return len(utf16.Encode([]rune(s)))
+}
@@ -0,0 +1,66 @@
+package tbot_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yanzay/tbot/v2"
+)
+
+func TestSplitTextPlain(t *testing.T) {
+	text := "first paragraph\n\nsecond line\nthird words here"
+	parts := tbot.SplitText(text, 20, "")
+	want := []string{"first paragraph", "second line", "third words here"}
+	if strings.Join(parts, "|") != strings.Join(want, "|") {
+		t.Fatalf("got %q, want %q", parts, want)
+	}
+}
+
+func TestSplitTextHTML(t *testing.T) {
+	text := "<b>bold text that is long</b> tail"
+	parts := tbot.SplitText(text, 20, "HTML")
+	for _, part := range parts {
+		if len(part) > 20 {
+			t.Fatalf("part is too long: %q", part)
+		}
+		if strings.Count(part, "<b>") != strings.Count(part, "</b>") {
+			t.Fatalf("unbalanced tags: %q", part)
+		}
+	}
+	if parts[0] != "<b>bold text</b>" {
+		t.Fatalf("wrong first part: %q", parts[0])
+	}
+}
+
+func TestSplitTextMarkdownV2(t *testing.T) {
+	text := "*bold\\* text that is long* and `code \\` block`"
+	parts := tbot.SplitText(text, 16, "MarkdownV2")
+	for _, part := range parts {
+		if len(part) > 16 {
+			t.Fatalf("part is too long: %q", part)
+		}
+	}
+	if parts[0] != "*bold\\* text*" {
+		t.Fatalf("wrong first part: %q", parts[0])
+	}
+	if parts[1] != "*that is long*" {
+		t.Fatalf("wrong second part: %q", parts[1])
+	}
+}
+
+func TestSendLongMessage(t *testing.T) {
+	c := testClient(t, `
+		{
+			"result": {"message_id": 1, "chat": {"id": 1}},
+			"ok": true
+		}
+	`)
+	text := strings.Repeat("word ", 2000)
+	msgs, err := c.SendLongMessage("123", text)
+	if err != nil {
+		t.Fatalf("error on sendLongMessage: %v", err)
+	}
+	if len(msgs) != 3 {
+		t.Fatalf("expected 3 messages, got %d", len(msgs))
+	}
+}
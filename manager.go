@@ -0,0 +1,455 @@
+package tbot
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const secretTokenHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+// BotFactory installs handlers on a bot added to Manager. Returned error cancels adding of the bot.
+type BotFactory func(token string, bot *Server) error
+
+// ManagerOption type for additional Manager options
+type ManagerOption func(*Manager)
+
+// BotHealth describes state of a bot run by Manager
+type BotHealth struct {
+	Username    string    // bot username from getMe
+	Running     bool      // bot receives updates
+	Updates     int64     // number of processed updates
+	LastUpdate  time.Time // time of the last processed update
+	Errors      int64     // number of failed Bot API calls and polling errors
+	LastError   string    // the last error
+	LastErrorAt time.Time // time of the last error
+}
+
+/*
+Manager runs many bots in one process. Bots share the worker pool processing updates,
+the Bot API rate limiter and, in webhook mode, one HTTP listener:
+
+	m := tbot.NewManager(func(token string, bot *tbot.Server) error {
+		bot.HandleMessage("/start", func(m *tbot.Message) {
+			bot.Client().WithContext(m.Context()).SendMessage(m.Chat.ID, "hello")
+		})
+		return nil
+	}, tbot.ManagerWebhook("https://bots.example.com/telegram", "0.0.0.0:8080"))
+	m.Add(token1)
+	m.Add(token2)
+	go m.Start()
+	...
+	m.Remove(token1)
+
+Webhook requests are routed by the secret token Telegram sends with every update,
+requests without a known secret are rejected.
+*/
+type Manager struct {
+	factory    BotFactory
+	options    []ServerOption
+	webhookURL string
+	listenAddr string
+	workers    int
+	limiter    *rateLimiter
+	logger     *libLogger
+
+	mu         sync.RWMutex
+	bots       map[string]*managedBot // by token
+	byID       map[string]*managedBot
+	bySecret   map[string]*managedBot
+	jobs       chan managerJob
+	done       chan struct{}
+	running    bool
+	httpServer *http.Server
+}
+
+type managerJob struct {
+	bot    *managedBot
+	update *Update
+}
+
+type managedBot struct {
+	id     string
+	token  string
+	secret string
+	server *Server
+
+	mu     sync.Mutex
+	health BotHealth
+}
+
+/*
+NewManager creates new Manager, factory is called for every added bot. Available options:
+
+	ManagerWebhook(url, addr string)
+	ManagerWorkers(n int)
+	ManagerRateLimit(rate float64, burst int)
+	ManagerServerOptions(options ...ServerOption)
+*/
+func NewManager(factory BotFactory, opts ...ManagerOption) *Manager {
+	m := &Manager{
+		factory:  factory,
+		workers:  64,
+		bots:     make(map[string]*managedBot),
+		byID:     make(map[string]*managedBot),
+		bySecret: make(map[string]*managedBot),
+		done:     make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	m.jobs = make(chan managerJob, m.workers)
+	// bots share logger settings, apply them to a blank server to get the manager logger
+	probe := &Server{logOutput: nopLogger{}, logLevel: LevelInfo}
+	for _, opt := range m.options {
+		opt(probe)
+	}
+	m.logger = newLibLogger(probe.logOutput, probe.logLevel, "")
+	return m
+}
+
+// ManagerWebhook makes bots receive updates with webhook. Every bot gets URL with its ID appended,
+// e.g. https://bots.example.com/telegram/123456. If addr is empty, Manager doesn't listen,
+// mount it as http.Handler instead.
+func ManagerWebhook(url, addr string) ManagerOption {
+	return func(m *Manager) {
+		m.webhookURL = strings.TrimRight(url, "/")
+		m.listenAddr = addr
+	}
+}
+
+// ManagerWorkers sets number of goroutines processing updates of all bots, 64 by default
+func ManagerWorkers(n int) ManagerOption {
+	return func(m *Manager) {
+		if n > 0 {
+			m.workers = n
+		}
+	}
+}
+
+// ManagerRateLimit limits Bot API calls of all bots to rate calls per second with given burst
+func ManagerRateLimit(rate float64, burst int) ManagerOption {
+	return func(m *Manager) {
+		m.limiter = newRateLimiter(rate, burst)
+	}
+}
+
+// ManagerServerOptions sets options applied to every bot, e.g. WithStructuredLogger or WithMetrics.
+// WithWebhook is ignored, use ManagerWebhook.
+func ManagerServerOptions(options ...ServerOption) ManagerOption {
+	return func(m *Manager) {
+		m.options = append(m.options, options...)
+	}
+}
+
+// Add creates bot with the token, options are applied after the options of the Manager.
+// If the Manager is started, the bot starts receiving updates immediately.
+func (m *Manager) Add(token string, options ...ServerOption) error {
+	id := strings.SplitN(token, ":", 2)[0]
+	// reserve the ID before running the factory, nil marks a bot being added
+	m.mu.Lock()
+	if _, ok := m.byID[id]; ok {
+		m.mu.Unlock()
+		return fmt.Errorf("bot %s is already added", id)
+	}
+	m.byID[id] = nil
+	m.mu.Unlock()
+
+	bot := New(token, append(append([]ServerOption(nil), m.options...), options...)...)
+	bot.webhookURL, bot.listenAddr = "", ""
+	mb := &managedBot{id: id, token: token, server: bot, secret: randomSecret()}
+	hm := healthMetrics{Metrics: bot.metrics, bot: mb}
+	bot.metrics = hm
+	bot.client.metrics = hm
+	bot.client.limiter = m.limiter
+	bot.pollErrorHandler = mb.failed
+	if m.factory != nil {
+		err := m.factory(token, bot)
+		if err != nil {
+			m.forget(mb)
+			return err
+		}
+	}
+
+	m.mu.Lock()
+	m.bots[token] = mb
+	m.byID[id] = mb
+	m.bySecret[mb.secret] = mb
+	running := m.running
+	m.mu.Unlock()
+
+	if running {
+		err := m.start(mb)
+		if err != nil {
+			m.forget(mb)
+			return err
+		}
+	}
+	return nil
+}
+
+// Remove stops the bot and removes it from the Manager. In webhook mode the webhook is deleted.
+func (m *Manager) Remove(token string) error {
+	m.mu.RLock()
+	mb, ok := m.bots[token]
+	m.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("bot %s is not found", strings.SplitN(token, ":", 2)[0])
+	}
+	m.forget(mb)
+	mb.stop()
+	if m.webhookURL != "" {
+		return mb.server.client.deleteWebhook()
+	}
+	return nil
+}
+
+func (m *Manager) forget(mb *managedBot) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.bots, mb.token)
+	delete(m.byID, mb.id)
+	delete(m.bySecret, mb.secret)
+}
+
+// Bot returns the bot with the token, nil if it's not added
+func (m *Manager) Bot(token string) *Server {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if mb, ok := m.bots[token]; ok {
+		return mb.server
+	}
+	return nil
+}
+
+// Health returns state of the bot with the token
+func (m *Manager) Health(token string) (BotHealth, bool) {
+	m.mu.RLock()
+	mb, ok := m.bots[token]
+	m.mu.RUnlock()
+	if !ok {
+		return BotHealth{}, false
+	}
+	return mb.state(), true
+}
+
+// HealthAll returns states of all bots by token
+func (m *Manager) HealthAll() map[string]BotHealth {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	health := make(map[string]BotHealth, len(m.bots))
+	for token, mb := range m.bots {
+		health[token] = mb.state()
+	}
+	return health
+}
+
+// Start starts workers and all added bots and blocks until Stop is called.
+// Bots failing to start are logged and reported by Health.
+func (m *Manager) Start() error {
+	m.mu.Lock()
+	if m.running {
+		m.mu.Unlock()
+		return fmt.Errorf("manager is already started")
+	}
+	m.running = true
+	bots := make([]*managedBot, 0, len(m.bots))
+	for _, mb := range m.bots {
+		bots = append(bots, mb)
+	}
+	m.mu.Unlock()
+
+	for i := 0; i < m.workers; i++ {
+		go m.work()
+	}
+	for _, mb := range bots {
+		err := m.start(mb)
+		if err != nil {
+			m.logger.error("unable to start bot", "bot", mb.id, "error", err)
+		}
+	}
+	if m.webhookURL == "" || m.listenAddr == "" {
+		<-m.done
+		return nil
+	}
+	l, err := net.Listen("tcp", m.listenAddr)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	select {
+	case <-m.done:
+		m.mu.Unlock()
+		return l.Close()
+	default:
+	}
+	m.httpServer = &http.Server{Handler: m}
+	m.mu.Unlock()
+	m.logger.info("starting webhook", "url", m.webhookURL, "addr", m.listenAddr)
+	err = m.httpServer.Serve(l)
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
+}
+
+// Stop stops all bots and workers. Webhooks are kept, so updates are delivered after restart.
+func (m *Manager) Stop() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	select {
+	case <-m.done:
+		return
+	default:
+	}
+	close(m.done)
+	for _, mb := range m.bots {
+		mb.stop()
+	}
+	if m.httpServer != nil {
+		m.httpServer.Close()
+	}
+}
+
+// ServeHTTP receives webhook updates of all bots
+func (m *Manager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	secret := r.Header.Get(secretTokenHeader)
+	if secret == "" {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+	m.mu.RLock()
+	mb := m.bySecret[secret]
+	m.mu.RUnlock()
+	if mb == nil {
+		http.NotFound(w, r)
+		return
+	}
+	up := &Update{}
+	err := json.NewDecoder(r.Body).Decode(up)
+	if err != nil {
+		m.logger.error("unable to decode update", "bot", mb.id, "error", err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	mb.server.received(up)
+	select {
+	case m.jobs <- managerJob{bot: mb, update: up}:
+	case <-m.done:
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+	}
+}
+
+// start connects the bot to Telegram
+func (m *Manager) start(mb *managedBot) error {
+	me, err := mb.server.client.GetMe()
+	if err != nil {
+		mb.failed(err)
+		return err
+	}
+	mb.mu.Lock()
+	mb.health.Username = me.Username
+	mb.mu.Unlock()
+	if m.webhookURL != "" {
+		err = mb.server.client.setWebhook(m.webhookURL+"/"+mb.id, mb.server.allowedUpdates, mb.secret)
+		if err != nil {
+			mb.failed(err)
+			return fmt.Errorf("unable to set webhook: %v", err)
+		}
+		mb.setRunning(true)
+		return nil
+	}
+	mb.server.client.deleteWebhook()
+	updates, err := mb.server.longPoolUpdates()
+	if err != nil {
+		mb.failed(err)
+		return redactToken(err, mb.token)
+	}
+	mb.setRunning(true)
+	go func() {
+		for up := range updates {
+			select {
+			case m.jobs <- managerJob{bot: mb, update: up}:
+			case <-m.done:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+func (m *Manager) work() {
+	for {
+		select {
+		case job := <-m.jobs:
+			job.bot.processed()
+			job.bot.server.ProcessUpdate(job.update)
+		case <-m.done:
+			return
+		}
+	}
+}
+
+func (mb *managedBot) stop() {
+	mb.server.cancel()
+	mb.setRunning(false)
+}
+
+func (mb *managedBot) setRunning(running bool) {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	mb.health.Running = running
+}
+
+func (mb *managedBot) processed() {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	mb.health.Updates++
+	mb.health.LastUpdate = time.Now()
+}
+
+func (mb *managedBot) failed(err error) {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	mb.health.Errors++
+	mb.health.LastError = redactToken(err, mb.token).Error()
+	mb.health.LastErrorAt = time.Now()
+}
+
+func (mb *managedBot) state() BotHealth {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	return mb.health
+}
+
+// healthMetrics counts failed Bot API calls of a managed bot and passes all events further
+type healthMetrics struct {
+	Metrics
+	bot *managedBot
+}
+
+func (hm healthMetrics) ObserveRequest(method string, code int, duration time.Duration) {
+	if code != http.StatusOK {
+		hm.bot.failed(fmt.Errorf("%s failed with code %d", method, code))
+	}
+	hm.Metrics.ObserveRequest(method, code, duration)
+}
+
+func randomSecret() string {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		panic(fmt.Sprintf("unable to generate secret token: %v", err))
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,173 @@
+package tbot_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yanzay/tbot/v2"
+	"github.com/yanzay/tbot/v2/tbottest"
+)
+
+func echoFactory(token string, bot *tbot.Server) error {
+	bot.HandleMessage("", func(m *tbot.Message) {
+		bot.Client().SendMessage(m.Chat.ID, "echo: "+m.Text)
+	})
+	return nil
+}
+
+func fakeAPI(token, username string) (*tbottest.Server, []tbot.ServerOption) {
+	api := tbottest.NewServer(token)
+	api.SetMe(tbot.User{ID: 1, IsBot: true, Username: username})
+	return api, []tbot.ServerOption{tbot.WithBaseURL(api.URL()), tbot.WithHTTPClient(api.HTTPClient())}
+}
+
+func TestManagerPolling(t *testing.T) {
+	m := tbot.NewManager(echoFactory, tbot.ManagerWorkers(2))
+	apiA, optsA := fakeAPI("1:A", "bot_a")
+	defer apiA.Close()
+	apiB, optsB := fakeAPI("2:B", "bot_b")
+	defer apiB.Close()
+	if err := m.Add("1:A", optsA...); err != nil {
+		t.Fatalf("unable to add bot: %v", err)
+	}
+	if err := m.Add("1:A", optsA...); err == nil {
+		t.Errorf("expected error adding the same bot twice")
+	}
+	go m.Start()
+	defer m.Stop()
+	// bots can be added to the running manager
+	if err := m.Add("2:B", optsB...); err != nil {
+		t.Fatalf("unable to add bot: %v", err)
+	}
+
+	apiA.PushUpdate(&tbot.Update{Message: &tbot.Message{Text: "a", Chat: tbot.Chat{ID: "10"}}})
+	apiB.PushUpdate(&tbot.Update{Message: &tbot.Message{Text: "b", Chat: tbot.Chat{ID: "20"}}})
+	call, err := apiA.WaitForCall("sendMessage", time.Second)
+	if err != nil || call.Params.Get("text") != "echo: a" {
+		t.Errorf("unexpected bot A reply: %v %v", call.Params, err)
+	}
+	call, err = apiB.WaitForCall("sendMessage", time.Second)
+	if err != nil || call.Params.Get("text") != "echo: b" {
+		t.Errorf("unexpected bot B reply: %v %v", call.Params, err)
+	}
+
+	health, ok := m.Health("2:B")
+	if !ok || !health.Running || health.Username != "bot_b" || health.Updates != 1 {
+		t.Errorf("unexpected health: %+v", health)
+	}
+	if err := m.Remove("1:A"); err != nil {
+		t.Fatalf("unable to remove bot: %v", err)
+	}
+	if _, ok := m.Health("1:A"); ok {
+		t.Errorf("removed bot is still reported")
+	}
+	if len(m.HealthAll()) != 1 {
+		t.Errorf("expected one bot, got %v", m.HealthAll())
+	}
+}
+
+func TestManagerWebhook(t *testing.T) {
+	m := tbot.NewManager(echoFactory, tbot.ManagerWebhook("https://bots.example.com/telegram/", ""))
+	api, opts := fakeAPI("42:SECRET", "hook_bot")
+	defer api.Close()
+	if err := m.Add("42:SECRET", opts...); err != nil {
+		t.Fatalf("unable to add bot: %v", err)
+	}
+	go m.Start()
+	defer m.Stop()
+	call, err := api.WaitForCall("setWebhook", time.Second)
+	if err != nil {
+		t.Fatalf("webhook is not set: %v", err)
+	}
+	if call.Params.Get("url") != "https://bots.example.com/telegram/42" {
+		t.Errorf("unexpected webhook url %s", call.Params.Get("url"))
+	}
+	secret := call.Params.Get("secret_token")
+	if secret == "" {
+		t.Fatalf("secret token is not set")
+	}
+
+	listener := httptest.NewServer(m)
+	defer listener.Close()
+	post := func(path, secret string) int {
+		req, _ := http.NewRequest(http.MethodPost, listener.URL+path,
+			strings.NewReader(`{"update_id": 1, "message": {"text": "hi", "chat": {"id": 5}}}`))
+		if secret != "" {
+			req.Header.Set("X-Telegram-Bot-Api-Secret-Token", secret)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("unable to post update: %v", err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	if code := post("/telegram/42", "wrong"); code != http.StatusNotFound {
+		t.Errorf("expected 404 for wrong secret, got %d", code)
+	}
+	if code := post("/telegram/42", ""); code != http.StatusUnauthorized {
+		t.Errorf("expected 401 without secret, got %d", code)
+	}
+	if code := post("/telegram/42", secret); code != http.StatusOK {
+		t.Errorf("expected 200, got %d", code)
+	}
+	call, err = api.WaitForCall("sendMessage", time.Second)
+	if err != nil || call.Params.Get("text") != "echo: hi" {
+		t.Errorf("unexpected reply: %v %v", call.Params, err)
+	}
+
+	if err := m.Remove("42:SECRET"); err != nil {
+		t.Fatalf("unable to remove bot: %v", err)
+	}
+	if _, err := api.WaitForCall("deleteWebhook", time.Second); err != nil {
+		t.Errorf("webhook is not deleted: %v", err)
+	}
+}
+
+func TestManagerRateLimit(t *testing.T) {
+	m := tbot.NewManager(nil, tbot.ManagerRateLimit(20, 1))
+	apiA, optsA := fakeAPI("1:A", "bot_a")
+	defer apiA.Close()
+	apiB, optsB := fakeAPI("2:B", "bot_b")
+	defer apiB.Close()
+	m.Add("1:A", optsA...)
+	m.Add("2:B", optsB...)
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		m.Bot("1:A").Client().GetMe()
+		m.Bot("2:B").Client().GetMe()
+	}
+	// the first call uses the burst, the other three are shared 50ms slots
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("calls are not limited, took %v", elapsed)
+	}
+}
+
+func TestManagerAddDuplicate(t *testing.T) {
+	var calls int
+	m := tbot.NewManager(func(token string, bot *tbot.Server) error {
+		calls++
+		if calls == 1 {
+			return fmt.Errorf("factory failed")
+		}
+		return nil
+	})
+	api, opts := fakeAPI("1:A", "bot_a")
+	defer api.Close()
+	if err := m.Add("1:A", opts...); err == nil {
+		t.Fatalf("expected factory error")
+	}
+	if err := m.Add("1:A", opts...); err != nil {
+		t.Fatalf("unable to add bot after failed factory: %v", err)
+	}
+	if err := m.Add("1:A", opts...); err == nil {
+		t.Errorf("expected error adding the same bot twice")
+	}
+	if calls != 2 {
+		t.Errorf("factory is called for duplicate bot, %d calls", calls)
+	}
+}
@@ -0,0 +1,347 @@
+package tbot
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	menuCallbackPrefix = "menu|"
+	maxCallbackData    = 64
+)
+
+// MenuItem is one entry of a Menu.
+// Key is passed to the select handler, keep it short: it's sent in callback data limited to 64 bytes.
+type MenuItem struct {
+	Key  string
+	Text string
+}
+
+// MenuSource returns the list of menu entries. It's called on every render, so the list may change over time.
+type MenuSource func() ([]interface{}, error)
+
+// MenuRender renders one entry of the list into a menu button
+type MenuRender func(item interface{}) MenuItem
+
+// MenuOption type for additional Menu options
+type MenuOption func(*Menu)
+
+/*
+Menu is an inline keyboard widget showing a paged list of items.
+It registers its own callback routes, edits the message in place when user pages
+and calls select handler when an item is pressed. Menus can be nested, sub-menus get a back button
+returning to the page of the parent menu they were opened from.
+
+	settings := tbot.NewMenu("settings", "Settings", source, render, tbot.MenuPageSize(5))
+	settings.HandleSelect(func(cq *tbot.CallbackQuery, key string) { ... })
+	settings.AddSubmenu("Language", languages)
+	settings.Register(bot)
+	bot.HandleMessage("/settings", func(m *tbot.Message) {
+		settings.Send(m.Chat.ID)
+	})
+*/
+type Menu struct {
+	id         string
+	title      string
+	source     MenuSource
+	render     MenuRender
+	pageSize   int
+	columns    int
+	backText   string
+	prevText   string
+	nextText   string
+	onSelect   func(cq *CallbackQuery, key string)
+	parent     *Menu
+	submenus   []*Menu
+	subTexts   []string
+	client     *Client
+	logger     *libLogger
+	registered map[string]*Menu
+}
+
+/*
+NewMenu creates new Menu. The id must be unique among the menus registered on a Server.
+It's sent in callback data of the menu buttons, so NewMenu panics if the id is empty, contains "|"
+or is too long to leave room for menu actions in 64 bytes of callback data.
+Available options:
+
+	MenuPageSize(size int)
+	MenuColumns(columns int)
+	MenuNavigation(prev, next, back string)
+*/
+func NewMenu(id, title string, source MenuSource, render MenuRender, opts ...MenuOption) *Menu {
+	if err := checkMenuID(id); err != nil {
+		panic(err)
+	}
+	m := &Menu{
+		id:       id,
+		title:    title,
+		source:   source,
+		render:   render,
+		pageSize: 10,
+		columns:  1,
+		prevText: "«",
+		nextText: "»",
+		backText: "« Back",
+		onSelect: func(*CallbackQuery, string) {},
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// maxMenuArgLength is the length of the menu action argument, e.g. page and page of the parent menu "12.3"
+const maxMenuArgLength = 7
+
+// maxMenuIDLength is the length of the menu id which leaves room for the one byte menu action and its argument
+// in the callback data of a root menu: menu|<id>|<id>|<action>|<arg>
+const maxMenuIDLength = (maxCallbackData - len(menuCallbackPrefix) - 3 - 1 - maxMenuArgLength) / 2
+
+// checkMenuID reports whether the id can be used in callback data
+func checkMenuID(id string) error {
+	switch {
+	case id == "":
+		return fmt.Errorf("menu id is empty")
+	case strings.Contains(id, "|"):
+		return fmt.Errorf("menu id %q contains |", id)
+	case len(id) > maxMenuIDLength:
+		return fmt.Errorf("menu id %q is longer than %d bytes", id, maxMenuIDLength)
+	}
+	return nil
+}
+
+// MenuPageSize sets number of items on one page, 10 by default
+func MenuPageSize(size int) MenuOption {
+	return func(m *Menu) {
+		if size > 0 {
+			m.pageSize = size
+		}
+	}
+}
+
+// MenuColumns sets number of item buttons in one row, 1 by default
+func MenuColumns(columns int) MenuOption {
+	return func(m *Menu) {
+		if columns > 0 {
+			m.columns = columns
+		}
+	}
+}
+
+// MenuNavigation sets texts of the navigation buttons
+func MenuNavigation(prev, next, back string) MenuOption {
+	return func(m *Menu) {
+		m.prevText = prev
+		m.nextText = next
+		m.backText = back
+	}
+}
+
+// HandleSelect sets handler called when user presses an item button.
+// Handler is responsible for answering the callback query.
+func (m *Menu) HandleSelect(handler func(cq *CallbackQuery, key string)) {
+	m.onSelect = handler
+}
+
+// AddSubmenu adds a button opening sub-menu in place of the current one
+func (m *Menu) AddSubmenu(text string, sub *Menu) {
+	sub.parent = m
+	m.submenus = append(m.submenus, sub)
+	m.subTexts = append(m.subTexts, text)
+}
+
+// Register installs callback routes of the menu and all its sub-menus on the server
+func (m *Menu) Register(s *Server) {
+	m.client = s.Client()
+	m.logger = s.logger
+	m.registered = make(map[string]*Menu)
+	m.collect(m.registered)
+	s.RegisterCallbackPrefixHandler(menuCallbackPrefix+m.id+"|", m.handleCallback)
+}
+
+func (m *Menu) collect(menus map[string]*Menu) {
+	menus[m.id] = m
+	for _, sub := range m.submenus {
+		sub.client = m.client
+		sub.logger = m.logger
+		sub.collect(menus)
+	}
+}
+
+// Send sends first page of the menu to the chat
+func (m *Menu) Send(chatID ChatID) (*Message, error) {
+	if m.client == nil {
+		return nil, fmt.Errorf("menu %s is not registered", m.id)
+	}
+	markup, err := m.Keyboard(0)
+	if err != nil {
+		return nil, err
+	}
+	return m.client.SendMessage(chatID, m.title, OptInlineKeyboardMarkup(markup))
+}
+
+// Keyboard builds the inline keyboard of given page
+func (m *Menu) Keyboard(page int) (*InlineKeyboardMarkup, error) {
+	return m.keyboard(page, 0)
+}
+
+// keyboard builds the inline keyboard of given page, back is the page of the parent menu Back returns to
+func (m *Menu) keyboard(page, back int) (*InlineKeyboardMarkup, error) {
+	items, err := m.source()
+	if err != nil {
+		return nil, err
+	}
+	pages := (len(items) + m.pageSize - 1) / m.pageSize
+	if page >= pages {
+		page = pages - 1
+	}
+	if page < 0 {
+		page = 0
+	}
+	km := NewKeyboardMaker()
+	var r *InlineRow
+	from, to := page*m.pageSize, (page+1)*m.pageSize
+	if to > len(items) {
+		to = len(items)
+	}
+	for i, item := range items[from:to] {
+		mi := m.render(item)
+		data, err := m.callbackData("s", mi.Key)
+		if err != nil {
+			return nil, err
+		}
+		if i%m.columns == 0 {
+			r = km.AddRow()
+		}
+		r.AddButton(mi.Text, data, "")
+	}
+	for i, text := range m.subTexts {
+		data, err := m.callbackData("o", withBackPage(i, page))
+		if err != nil {
+			return nil, err
+		}
+		km.AddRow().AddButton(text, data, "")
+	}
+	if pages > 1 {
+		nav := km.AddRow()
+		if page > 0 {
+			data, _ := m.callbackData("p", withBackPage(page-1, back))
+			nav.AddButton(m.prevText, data, "")
+		}
+		data, _ := m.callbackData("n", "")
+		nav.AddButton(fmt.Sprintf("%d/%d", page+1, pages), data, "")
+		if page < pages-1 {
+			data, _ := m.callbackData("p", withBackPage(page+1, back))
+			nav.AddButton(m.nextText, data, "")
+		}
+	}
+	if m.parent != nil {
+		arg := ""
+		if back > 0 {
+			arg = strconv.Itoa(back)
+		}
+		data, err := m.callbackData("b", arg)
+		if err != nil {
+			return nil, err
+		}
+		km.AddRow().AddButton(m.backText, data, "")
+	}
+	return km.BuildChecked()
+}
+
+func (m *Menu) root() *Menu {
+	root := m
+	for root.parent != nil {
+		root = root.parent
+	}
+	return root
+}
+
+// callbackData encodes menu action as menu|<root>|<menu>|<action>|<arg>
+func (m *Menu) callbackData(action, arg string) (string, error) {
+	data := menuCallbackPrefix + strings.Join([]string{m.root().id, m.id, action, arg}, "|")
+	if len(data) > maxCallbackData {
+		return "", fmt.Errorf("callback data %q is longer than %d bytes", data, maxCallbackData)
+	}
+	return data, nil
+}
+
+// withBackPage encodes action argument n with the page of the parent menu as "<n>.<back>",
+// back is dropped if it's the first page or doesn't fit into the argument
+func withBackPage(n, back int) string {
+	arg := strconv.Itoa(n)
+	if back == 0 {
+		return arg
+	}
+	if withBack := arg + "." + strconv.Itoa(back); len(withBack) <= maxMenuArgLength {
+		return withBack
+	}
+	return arg
+}
+
+// parseBackPage decodes action argument encoded by withBackPage
+func parseBackPage(arg string) (n, back int, err error) {
+	parts := strings.SplitN(arg, ".", 2)
+	n, err = strconv.Atoi(parts[0])
+	if err != nil || len(parts) == 1 {
+		return n, 0, err
+	}
+	back, err = strconv.Atoi(parts[1])
+	return n, back, err
+}
+
+func (m *Menu) handleCallback(cq *CallbackQuery) {
+	parts := strings.SplitN(cq.Data, "|", 5)
+	if len(parts) != 5 {
+		return
+	}
+	menu, ok := m.registered[parts[2]]
+	if !ok {
+		return
+	}
+	action, arg := parts[3], parts[4]
+	switch action {
+	case "s":
+		menu.onSelect(cq, arg)
+		return
+	case "p":
+		page, back, _ := parseBackPage(arg)
+		menu.show(cq, page, back)
+	case "o":
+		i, page, err := parseBackPage(arg)
+		if err == nil && i >= 0 && i < len(menu.submenus) {
+			menu.submenus[i].show(cq, 0, page)
+		}
+	case "b":
+		if menu.parent != nil {
+			// the page of the parent's own parent isn't kept, Back from the parent returns to its first page
+			page, _ := strconv.Atoi(arg)
+			menu.parent.show(cq, page, 0)
+		}
+	}
+	err := m.client.WithContext(cq.Context()).AnswerCallbackQuery(cq.ID)
+	if err != nil {
+		m.logger.error("unable to answer menu callback", "menu", m.id, "error", err)
+	}
+}
+
+func (m *Menu) show(cq *CallbackQuery, page, back int) {
+	markup, err := m.keyboard(page, back)
+	if err != nil {
+		m.logger.error("unable to render menu", "menu", m.id, "error", err)
+		return
+	}
+	opt := OptInlineKeyboardMarkup(markup)
+	client := m.client.WithContext(cq.Context())
+	switch {
+	case cq.InlineMessageID != "":
+		err = client.EditInlineMessageText(cq.InlineMessageID, m.title, opt)
+	case cq.Message != nil:
+		_, err = client.EditMessageText(cq.Message.Chat.ID, cq.Message.MessageID, m.title, opt)
+	}
+	if err != nil {
+		m.logger.error("unable to edit menu", "menu", m.id, "error", err)
+	}
+}
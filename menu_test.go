@@ -0,0 +1,108 @@
+package tbot_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/yanzay/tbot/v2"
+	"github.com/yanzay/tbot/v2/tbottest"
+)
+
+func TestMenuKeyboard(t *testing.T) {
+	source := func() ([]interface{}, error) {
+		items := make([]interface{}, 25)
+		for i := range items {
+			items[i] = i
+		}
+		return items, nil
+	}
+	render := func(item interface{}) tbot.MenuItem {
+		return tbot.MenuItem{Key: fmt.Sprint(item), Text: fmt.Sprintf("Item %d", item)}
+	}
+	menu := tbot.NewMenu("list", "List", source, render, tbot.MenuPageSize(10), tbot.MenuColumns(2))
+	sub := tbot.NewMenu("sub", "Sub", source, render)
+	menu.AddSubmenu("More", sub)
+
+	kb, err := menu.Keyboard(1)
+	if err != nil {
+		t.Fatalf("unable to build keyboard: %v", err)
+	}
+	// 5 rows of items, submenu row and navigation row
+	if len(kb.InlineKeyboard) != 7 {
+		t.Fatalf("expected 7 rows, got %d", len(kb.InlineKeyboard))
+	}
+	if data := kb.InlineKeyboard[0][0].CallbackData; data != "menu|list|list|s|10" {
+		t.Fatalf("wrong item callback data: %s", data)
+	}
+	nav := kb.InlineKeyboard[6]
+	if len(nav) != 3 || nav[1].Text != "2/3" {
+		t.Fatalf("wrong navigation row: %+v", nav)
+	}
+
+	subKb, err := sub.Keyboard(0)
+	if err != nil {
+		t.Fatalf("unable to build sub-menu keyboard: %v", err)
+	}
+	back := subKb.InlineKeyboard[len(subKb.InlineKeyboard)-1][0]
+	if back.CallbackData != "menu|list|sub|b|" {
+		t.Fatalf("wrong back button: %+v", back)
+	}
+}
+
+func TestMenuBackPage(t *testing.T) {
+	source := func() ([]interface{}, error) {
+		items := make([]interface{}, 25)
+		for i := range items {
+			items[i] = i
+		}
+		return items, nil
+	}
+	render := func(item interface{}) tbot.MenuItem {
+		return tbot.MenuItem{Key: fmt.Sprint(item), Text: fmt.Sprintf("Item %d", item)}
+	}
+	menu := tbot.NewMenu("list", "List", source, render, tbot.MenuPageSize(10))
+	menu.AddSubmenu("More", tbot.NewMenu("sub", "Sub", source, render, tbot.MenuPageSize(10)))
+	d := tbottest.NewDriver(t)
+	defer d.Close()
+	menu.Register(d.Bot)
+	user := tbottest.User(7, "alice")
+	msg, err := menu.Send(tbottest.PrivateChat(user).ID)
+	if err != nil {
+		t.Fatalf("unable to send menu: %v", err)
+	}
+
+	kb, _ := menu.Keyboard(1)
+	open := kb.InlineKeyboard[10][0].CallbackData
+	if open != "menu|list|list|o|0.1" {
+		t.Fatalf("wrong submenu callback data: %s", open)
+	}
+	markup := d.PressButton(user, msg, open).ExpectCall("editMessageText").Params.Get("reply_markup")
+	if !strings.Contains(markup, `"menu|list|sub|p|1.1"`) || !strings.Contains(markup, `"menu|list|sub|b|1"`) {
+		t.Fatalf("parent page is not kept in the submenu: %s", markup)
+	}
+	markup = d.PressButton(user, msg, "menu|list|sub|p|1.1").ExpectCall("editMessageText").Params.Get("reply_markup")
+	if !strings.Contains(markup, `"menu|list|sub|b|1"`) {
+		t.Fatalf("parent page is lost on the submenu page: %s", markup)
+	}
+	markup = d.PressButton(user, msg, "menu|list|sub|b|1").ExpectCall("editMessageText").Params.Get("reply_markup")
+	if !strings.Contains(markup, `"2/3"`) {
+		t.Errorf("Back doesn't return to the parent page: %s", markup)
+	}
+}
+
+func TestMenuInvalidID(t *testing.T) {
+	source := func() ([]interface{}, error) { return nil, nil }
+	render := func(item interface{}) tbot.MenuItem { return tbot.MenuItem{} }
+	for _, id := range []string{"", "a|b", strings.Repeat("m", 25)} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic for menu id %q", id)
+				}
+			}()
+			tbot.NewMenu(id, "Menu", source, render)
+		}()
+	}
+	tbot.NewMenu(strings.Repeat("m", 24), "Menu", source, render)
+}
@@ -0,0 +1,443 @@
+// Code generated by internal/gen from spec/botapi.json (Bot API 6.5); DO NOT EDIT.
+
+package tbot
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// revokeMessagesOption sets revoke_messages
+type revokeMessagesOption func(url.Values)
+
+func (o revokeMessagesOption) apply(v url.Values) { o(v) }
+
+// BanChatMemberOption is an option of BanChatMember
+type BanChatMemberOption interface {
+	option
+	banChatMemberOption()
+}
+
+func (Option) banChatMemberOption()               {}
+func (untilDateOption) banChatMemberOption()      {}
+func (revokeMessagesOption) banChatMemberOption() {}
+
+// BanChatMember options
+var (
+	OptRevokeMessages revokeMessagesOption = func(v url.Values) {
+		v.Set("revoke_messages", "true")
+	}
+)
+
+/*
+BanChatMember bans a user in a group, a supergroup or a channel. In the case of supergroups and
+channels, the user will not be able to return to the chat on their own using invite links, etc.,
+unless unbanned first. The bot must be an administrator in the chat for this to work and must have
+the appropriate administrator rights. Available options:
+  - OptUntilDate(date time.Time)
+  - OptRevokeMessages
+*/
+func (c *Client) BanChatMember(chatID ChatID, userID int64, opts ...BanChatMemberOption) error {
+	req := url.Values{}
+	req.Set("chat_id", string(chatID))
+	req.Set("user_id", fmt.Sprint(userID))
+	for _, opt := range opts {
+		opt.apply(req)
+	}
+	var ok bool
+	return c.doRequest("banChatMember", req, &ok)
+}
+
+/*
+BanChatSenderChat bans a channel chat in a supergroup or a channel. Until the chat is unbanned, the
+owner of the banned chat won't be able to send messages on behalf of any of their channels. The bot
+must be an administrator in the supergroup or channel for this to work and must have the appropriate
+administrator rights
+*/
+func (c *Client) BanChatSenderChat(chatID ChatID, senderChatID int64) error {
+	req := url.Values{}
+	req.Set("chat_id", string(chatID))
+	req.Set("sender_chat_id", fmt.Sprint(senderChatID))
+	var ok bool
+	return c.doRequest("banChatSenderChat", req, &ok)
+}
+
+/*
+UnbanChatSenderChat unbans a previously banned channel chat in a supergroup or channel. The bot must
+be an administrator for this to work and must have the appropriate administrator rights
+*/
+func (c *Client) UnbanChatSenderChat(chatID ChatID, senderChatID int64) error {
+	req := url.Values{}
+	req.Set("chat_id", string(chatID))
+	req.Set("sender_chat_id", fmt.Sprint(senderChatID))
+	var ok bool
+	return c.doRequest("unbanChatSenderChat", req, &ok)
+}
+
+/*
+ApproveChatJoinRequest approves a chat join request. The bot must be an administrator in the chat
+for this to work and must have the can_invite_users administrator right
+*/
+func (c *Client) ApproveChatJoinRequest(chatID ChatID, userID int64) error {
+	req := url.Values{}
+	req.Set("chat_id", string(chatID))
+	req.Set("user_id", fmt.Sprint(userID))
+	var ok bool
+	return c.doRequest("approveChatJoinRequest", req, &ok)
+}
+
+/*
+DeclineChatJoinRequest declines a chat join request. The bot must be an administrator in the chat
+for this to work and must have the can_invite_users administrator right
+*/
+func (c *Client) DeclineChatJoinRequest(chatID ChatID, userID int64) error {
+	req := url.Values{}
+	req.Set("chat_id", string(chatID))
+	req.Set("user_id", fmt.Sprint(userID))
+	var ok bool
+	return c.doRequest("declineChatJoinRequest", req, &ok)
+}
+
+/*
+UnpinAllChatMessages clears the list of pinned messages in a chat. If the chat is not a private
+chat, the bot must be an administrator in the chat for this to work and must have the
+'can_pin_messages' administrator right in a supergroup or 'can_edit_messages' administrator right in
+a channel
+*/
+func (c *Client) UnpinAllChatMessages(chatID ChatID) error {
+	req := url.Values{}
+	req.Set("chat_id", string(chatID))
+	var ok bool
+	return c.doRequest("unpinAllChatMessages", req, &ok)
+}
+
+/*
+GetChatMemberCount gets the number of members in a chat
+*/
+func (c *Client) GetChatMemberCount(chatID ChatID) (int, error) {
+	req := url.Values{}
+	req.Set("chat_id", string(chatID))
+	var result int
+	err := c.doRequest("getChatMemberCount", req, &result)
+	return result, err
+}
+
+/*
+GetForumTopicIconStickers gets custom emoji stickers, which can be used as a forum topic icon by any
+user
+*/
+func (c *Client) GetForumTopicIconStickers() ([]*Sticker, error) {
+	stickers := []*Sticker{}
+	err := c.doRequest("getForumTopicIconStickers", nil, &stickers)
+	return stickers, err
+}
+
+// iconColorOption sets icon_color
+type iconColorOption func(url.Values)
+
+func (o iconColorOption) apply(v url.Values) { o(v) }
+
+// iconCustomEmojiIDOption sets icon_custom_emoji_id
+type iconCustomEmojiIDOption func(url.Values)
+
+func (o iconCustomEmojiIDOption) apply(v url.Values) { o(v) }
+
+// CreateForumTopicOption is an option of CreateForumTopic
+type CreateForumTopicOption interface {
+	option
+	createForumTopicOption()
+}
+
+func (Option) createForumTopicOption()                  {}
+func (iconColorOption) createForumTopicOption()         {}
+func (iconCustomEmojiIDOption) createForumTopicOption() {}
+
+// CreateForumTopic options
+var (
+	OptIconColor = func(iconColor int) iconColorOption {
+		return func(v url.Values) {
+			v.Set("icon_color", fmt.Sprint(iconColor))
+		}
+	}
+	OptIconCustomEmojiID = func(iconCustomEmojiID string) iconCustomEmojiIDOption {
+		return func(v url.Values) {
+			v.Set("icon_custom_emoji_id", iconCustomEmojiID)
+		}
+	}
+)
+
+/*
+CreateForumTopic creates a topic in a forum supergroup chat. The bot must be an administrator in the
+chat for this to work and must have the can_manage_topics administrator rights. Available options:
+  - OptIconColor(iconColor int)
+  - OptIconCustomEmojiID(iconCustomEmojiID string)
+*/
+func (c *Client) CreateForumTopic(chatID ChatID, name string, opts ...CreateForumTopicOption) (*ForumTopic, error) {
+	req := url.Values{}
+	req.Set("chat_id", string(chatID))
+	req.Set("name", name)
+	for _, opt := range opts {
+		opt.apply(req)
+	}
+	forumTopic := &ForumTopic{}
+	err := c.doRequest("createForumTopic", req, forumTopic)
+	return forumTopic, err
+}
+
+// topicNameOption sets name
+type topicNameOption func(url.Values)
+
+func (o topicNameOption) apply(v url.Values) { o(v) }
+
+// EditForumTopicOption is an option of EditForumTopic
+type EditForumTopicOption interface {
+	option
+	editForumTopicOption()
+}
+
+func (Option) editForumTopicOption()                  {}
+func (topicNameOption) editForumTopicOption()         {}
+func (iconCustomEmojiIDOption) editForumTopicOption() {}
+
+// EditForumTopic options
+var (
+	OptTopicName = func(name string) topicNameOption {
+		return func(v url.Values) {
+			v.Set("name", name)
+		}
+	}
+)
+
+/*
+EditForumTopic edits name and icon of a topic in a forum supergroup chat. The bot must be an
+administrator in the chat for this to work and must have can_manage_topics administrator rights,
+unless it is the creator of the topic. Available options:
+  - OptTopicName(name string)
+  - OptIconCustomEmojiID(iconCustomEmojiID string)
+*/
+func (c *Client) EditForumTopic(chatID ChatID, messageThreadID int, opts ...EditForumTopicOption) error {
+	req := url.Values{}
+	req.Set("chat_id", string(chatID))
+	req.Set("message_thread_id", fmt.Sprint(messageThreadID))
+	for _, opt := range opts {
+		opt.apply(req)
+	}
+	var ok bool
+	return c.doRequest("editForumTopic", req, &ok)
+}
+
+/*
+CloseForumTopic closes an open topic in a forum supergroup chat. The bot must be an administrator in
+the chat for this to work and must have the can_manage_topics administrator rights, unless it is the
+creator of the topic
+*/
+func (c *Client) CloseForumTopic(chatID ChatID, messageThreadID int) error {
+	req := url.Values{}
+	req.Set("chat_id", string(chatID))
+	req.Set("message_thread_id", fmt.Sprint(messageThreadID))
+	var ok bool
+	return c.doRequest("closeForumTopic", req, &ok)
+}
+
+/*
+ReopenForumTopic reopens a closed topic in a forum supergroup chat. The bot must be an administrator
+in the chat for this to work and must have the can_manage_topics administrator rights, unless it is
+the creator of the topic
+*/
+func (c *Client) ReopenForumTopic(chatID ChatID, messageThreadID int) error {
+	req := url.Values{}
+	req.Set("chat_id", string(chatID))
+	req.Set("message_thread_id", fmt.Sprint(messageThreadID))
+	var ok bool
+	return c.doRequest("reopenForumTopic", req, &ok)
+}
+
+/*
+DeleteForumTopic deletes a forum topic along with all its messages in a forum supergroup chat. The
+bot must be an administrator in the chat for this to work and must have the can_delete_messages
+administrator rights
+*/
+func (c *Client) DeleteForumTopic(chatID ChatID, messageThreadID int) error {
+	req := url.Values{}
+	req.Set("chat_id", string(chatID))
+	req.Set("message_thread_id", fmt.Sprint(messageThreadID))
+	var ok bool
+	return c.doRequest("deleteForumTopic", req, &ok)
+}
+
+/*
+UnpinAllForumTopicMessages clears the list of pinned messages in a forum topic. The bot must be an
+administrator in the chat for this to work and must have the can_pin_messages administrator right in
+the supergroup
+*/
+func (c *Client) UnpinAllForumTopicMessages(chatID ChatID, messageThreadID int) error {
+	req := url.Values{}
+	req.Set("chat_id", string(chatID))
+	req.Set("message_thread_id", fmt.Sprint(messageThreadID))
+	var ok bool
+	return c.doRequest("unpinAllForumTopicMessages", req, &ok)
+}
+
+// messageThreadIDOption sets message_thread_id
+type messageThreadIDOption func(url.Values)
+
+func (o messageThreadIDOption) apply(v url.Values) { o(v) }
+
+// protectContentOption sets protect_content
+type protectContentOption func(url.Values)
+
+func (o protectContentOption) apply(v url.Values) { o(v) }
+
+// allowSendingWithoutReplyOption sets allow_sending_without_reply
+type allowSendingWithoutReplyOption func(url.Values)
+
+func (o allowSendingWithoutReplyOption) apply(v url.Values) { o(v) }
+
+// CopyMessageOption is an option of CopyMessage
+type CopyMessageOption interface {
+	option
+	copyMessageOption()
+}
+
+func (Option) copyMessageOption()                         {}
+func (messageThreadIDOption) copyMessageOption()          {}
+func (captionOption) copyMessageOption()                  {}
+func (parseModeOption) copyMessageOption()                {}
+func (notificationOption) copyMessageOption()             {}
+func (protectContentOption) copyMessageOption()           {}
+func (replyOption) copyMessageOption()                    {}
+func (allowSendingWithoutReplyOption) copyMessageOption() {}
+func (inlineKeyboardOption) copyMessageOption()           {}
+func (replyMarkupOption) copyMessageOption()              {}
+
+// CopyMessage options
+var (
+	OptMessageThreadID = func(messageThreadID int) messageThreadIDOption {
+		return func(v url.Values) {
+			v.Set("message_thread_id", fmt.Sprint(messageThreadID))
+		}
+	}
+	OptProtectContent protectContentOption = func(v url.Values) {
+		v.Set("protect_content", "true")
+	}
+	OptAllowSendingWithoutReply allowSendingWithoutReplyOption = func(v url.Values) {
+		v.Set("allow_sending_without_reply", "true")
+	}
+)
+
+/*
+CopyMessage copies messages of any kind. Service messages and invoice messages can't be copied. A
+quiz poll can be copied only if the value of the field correct_option_id is known to the bot. The
+method is analogous to the method forwardMessage, but the copied message doesn't have a link to the
+original message. Available options:
+  - OptMessageThreadID(messageThreadID int)
+  - OptCaption(caption string)
+  - OptParseModeHTML
+  - OptParseModeMarkdown
+  - OptCaptionEntities(entities []*MessageEntity)
+  - OptDisableNotification
+  - OptProtectContent
+  - OptReplyToMessageID(id int)
+  - OptAllowSendingWithoutReply
+  - OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
+  - OptReplyKeyboardMarkup(markup *ReplyKeyboardMarkup)
+  - OptReplyKeyboardRemove
+  - OptReplyKeyboardRemoveSelective
+  - OptForceReply
+  - OptForceReplySelective
+  - OptReplyKeyboardRemoveMarkup(markup *ReplyKeyboardRemove)
+  - OptForceReplyMarkup(markup *ForceReply)
+*/
+func (c *Client) CopyMessage(chatID, fromChatID ChatID, messageID int, opts ...CopyMessageOption) (*MessageID, error) {
+	req := url.Values{}
+	req.Set("chat_id", string(chatID))
+	req.Set("from_chat_id", string(fromChatID))
+	req.Set("message_id", fmt.Sprint(messageID))
+	for _, opt := range opts {
+		opt.apply(req)
+	}
+	result := &MessageID{}
+	err := c.doRequest("copyMessage", req, result)
+	return result, err
+}
+
+// ForwardMessagesOption is an option of ForwardMessages
+type ForwardMessagesOption interface {
+	option
+	forwardMessagesOption()
+}
+
+func (Option) forwardMessagesOption()                {}
+func (messageThreadIDOption) forwardMessagesOption() {}
+func (notificationOption) forwardMessagesOption()    {}
+func (protectContentOption) forwardMessagesOption()  {}
+
+/*
+ForwardMessages forwards multiple messages of any kind. If some of the specified messages can't be
+found or forwarded, they are skipped. Service messages and messages with protected content can't be
+forwarded. Album grouping is kept for forwarded messages. Available options:
+  - OptMessageThreadID(messageThreadID int)
+  - OptDisableNotification
+  - OptProtectContent
+*/
+func (c *Client) ForwardMessages(chatID, fromChatID ChatID, messageIDs []int, opts ...ForwardMessagesOption) ([]*MessageID, error) {
+	req := url.Values{}
+	req.Set("chat_id", string(chatID))
+	req.Set("from_chat_id", string(fromChatID))
+	setJSON(req, "message_ids", messageIDs)
+	for _, opt := range opts {
+		opt.apply(req)
+	}
+	result := []*MessageID{}
+	err := c.doRequest("forwardMessages", req, &result)
+	return result, err
+}
+
+// CopyMessagesOption is an option of CopyMessages
+type CopyMessagesOption interface {
+	option
+	copyMessagesOption()
+}
+
+func (Option) copyMessagesOption()                {}
+func (messageThreadIDOption) copyMessagesOption() {}
+func (notificationOption) copyMessagesOption()    {}
+func (protectContentOption) copyMessagesOption()  {}
+func (copyOption) copyMessagesOption()            {}
+
+/*
+CopyMessages copies messages of any kind. If some of the specified messages can't be found or
+copied, they are skipped. Service messages, giveaway messages, giveaway winners messages, and
+invoice messages can't be copied. A quiz poll can be copied only if the value of the field
+correct_option_id is known to the bot. The method is analogous to the method forwardMessages, but
+the copied messages don't have a link to the original message. Album grouping is kept for copied
+messages. Available options:
+  - OptMessageThreadID(messageThreadID int)
+  - OptDisableNotification
+  - OptProtectContent
+  - OptRemoveCaption
+*/
+func (c *Client) CopyMessages(chatID, fromChatID ChatID, messageIDs []int, opts ...CopyMessagesOption) ([]*MessageID, error) {
+	req := url.Values{}
+	req.Set("chat_id", string(chatID))
+	req.Set("from_chat_id", string(fromChatID))
+	setJSON(req, "message_ids", messageIDs)
+	for _, opt := range opts {
+		opt.apply(req)
+	}
+	result := []*MessageID{}
+	err := c.doRequest("copyMessages", req, &result)
+	return result, err
+}
+
+/*
+DeleteMessages deletes multiple messages simultaneously. If some of the specified messages can't be
+found, they are skipped
+*/
+func (c *Client) DeleteMessages(chatID ChatID, messageIDs []int) error {
+	req := url.Values{}
+	req.Set("chat_id", string(chatID))
+	setJSON(req, "message_ids", messageIDs)
+	var ok bool
+	return c.doRequest("deleteMessages", req, &ok)
+}
@@ -0,0 +1,89 @@
+package tbot
+
+import "time"
+
+// Metrics receives instrumentation events from Server and Client, see WithMetrics.
+// Package github.com/yanzay/tbot/v2/metrics provides an implementation exporting
+// them with expvar and in Prometheus text format.
+type Metrics interface {
+	// ObserveRequest is called after every Bot API call. Code is the API error code,
+	// 200 for successful calls and 0 if there was no response.
+	ObserveRequest(method string, code int, duration time.Duration)
+	// ObserveUpdate is called for every incoming update, kind is the update field name, e.g. "message"
+	ObserveUpdate(kind string)
+	// ObserveHandler is called when middlewares and handlers finished processing the update
+	ObserveHandler(kind string, duration time.Duration)
+	// HandlersInFlight is called with +1 when update processing starts and with -1 when it ends
+	HandlersInFlight(delta int)
+	// ObservePollLag is called with the delay between the update creation and its receiving
+	ObservePollLag(lag time.Duration)
+}
+
+type nopMetrics struct{}
+
+func (nopMetrics) ObserveRequest(string, int, time.Duration) {}
+func (nopMetrics) ObserveUpdate(string)                      {}
+func (nopMetrics) ObserveHandler(string, time.Duration)      {}
+func (nopMetrics) HandlersInFlight(int)                      {}
+func (nopMetrics) ObservePollLag(time.Duration)              {}
+
+// Kind returns name of the update field which is set, e.g. "message" or "callback_query",
+// "unknown" for updates not modelled by the library
+func (u *Update) Kind() string {
+	switch {
+	case u.Message != nil:
+		return "message"
+	case u.EditedMessage != nil:
+		return "edited_message"
+	case u.ChannelPost != nil:
+		return "channel_post"
+	case u.EditedChannelPost != nil:
+		return "edited_channel_post"
+	case u.InlineQuery != nil:
+		return "inline_query"
+	case u.ChosenInlineResult != nil:
+		return "chosen_inline_result"
+	case u.CallbackQuery != nil:
+		return "callback_query"
+	case u.ShippingQuery != nil:
+		return "shipping_query"
+	case u.PreCheckoutQuery != nil:
+		return "pre_checkout_query"
+	case u.Poll != nil:
+		return "poll"
+	case u.PollAnswer != nil:
+		return "poll_answer"
+	case u.MyChatMember != nil:
+		return "my_chat_member"
+	case u.ChatMember != nil:
+		return "chat_member"
+	case u.ChatJoinRequest != nil:
+		return "chat_join_request"
+	}
+	return "unknown"
+}
+
+// date returns creation time of the update if it's known
+func (u *Update) date() (time.Time, bool) {
+	var date int64
+	switch {
+	case u.Message != nil:
+		date = u.Message.Date
+	case u.EditedMessage != nil:
+		date = u.EditedMessage.EditDate
+	case u.ChannelPost != nil:
+		date = u.ChannelPost.Date
+	case u.EditedChannelPost != nil:
+		date = u.EditedChannelPost.EditDate
+	case u.MyChatMember != nil:
+		date = u.MyChatMember.Date
+	case u.ChatMember != nil:
+		date = u.ChatMember.Date
+	case u.ChatJoinRequest != nil:
+		date = u.ChatJoinRequest.Date
+	}
+	if date == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(date, 0), true
+}
@@ -0,0 +1,262 @@
+/*
+Package metrics implements tbot.Metrics without external dependencies.
+
+Collected metrics can be published with expvar or served in Prometheus text exposition format:
+
+	m := metrics.New()
+	bot := tbot.New(token, tbot.WithMetrics(m))
+	m.PublishExpvar("tbot")
+	http.Handle("/metrics", m)
+
+Exported metrics:
+
+	tbot_api_requests_total{method,code}        counter
+	tbot_api_request_duration_seconds{method}   histogram
+	tbot_updates_total{type}                    counter
+	tbot_handler_duration_seconds{type}         histogram
+	tbot_handlers_in_flight                     gauge
+	tbot_poll_lag_seconds                       gauge
+*/
+package metrics
+
+import (
+	"expvar"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/yanzay/tbot/v2"
+)
+
+var _ tbot.Metrics = (*Metrics)(nil)
+
+// DefaultBuckets are upper bounds of latency histograms, in seconds
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Metrics collects tbot instrumentation events, it's safe for concurrent use
+type Metrics struct {
+	mu         sync.Mutex
+	buckets    []float64
+	requests   map[requestKey]int64
+	requestDur map[string]*Histogram
+	updates    map[string]int64
+	handlerDur map[string]*Histogram
+	inFlight   int64
+	pollLag    float64
+}
+
+type requestKey struct {
+	method string
+	code   int
+}
+
+// Histogram is a snapshot of observed durations
+type Histogram struct {
+	Buckets []float64 `json:"buckets"`
+	Counts  []int64   `json:"counts"` // cumulative count for every bucket
+	Count   int64     `json:"count"`
+	Sum     float64   `json:"sum"`
+}
+
+func (h *Histogram) observe(v float64) {
+	for i, le := range h.Buckets {
+		if v <= le {
+			h.Counts[i]++
+		}
+	}
+	h.Count++
+	h.Sum += v
+}
+
+func (h *Histogram) copy() *Histogram {
+	c := *h
+	c.Counts = append([]int64(nil), h.Counts...)
+	return &c
+}
+
+// New creates Metrics with DefaultBuckets
+func New() *Metrics {
+	return NewWithBuckets(DefaultBuckets)
+}
+
+// NewWithBuckets creates Metrics with custom histogram buckets, in seconds
+func NewWithBuckets(buckets []float64) *Metrics {
+	b := append([]float64(nil), buckets...)
+	sort.Float64s(b)
+	return &Metrics{
+		buckets:    b,
+		requests:   make(map[requestKey]int64),
+		requestDur: make(map[string]*Histogram),
+		updates:    make(map[string]int64),
+		handlerDur: make(map[string]*Histogram),
+	}
+}
+
+// ObserveRequest implements tbot.Metrics
+func (m *Metrics) ObserveRequest(method string, code int, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests[requestKey{method: method, code: code}]++
+	m.histogram(m.requestDur, method).observe(duration.Seconds())
+}
+
+// ObserveUpdate implements tbot.Metrics
+func (m *Metrics) ObserveUpdate(kind string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.updates[kind]++
+}
+
+// ObserveHandler implements tbot.Metrics
+func (m *Metrics) ObserveHandler(kind string, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.histogram(m.handlerDur, kind).observe(duration.Seconds())
+}
+
+// HandlersInFlight implements tbot.Metrics
+func (m *Metrics) HandlersInFlight(delta int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.inFlight += int64(delta)
+}
+
+// ObservePollLag implements tbot.Metrics
+func (m *Metrics) ObservePollLag(lag time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pollLag = lag.Seconds()
+}
+
+func (m *Metrics) histogram(hs map[string]*Histogram, key string) *Histogram {
+	h, ok := hs[key]
+	if !ok {
+		h = &Histogram{Buckets: m.buckets, Counts: make([]int64, len(m.buckets))}
+		hs[key] = h
+	}
+	return h
+}
+
+// Snapshot is a point-in-time copy of all metrics
+type Snapshot struct {
+	Requests         map[string]map[int]int64 `json:"requests"` // method -> code -> count
+	RequestDuration  map[string]*Histogram    `json:"request_duration"`
+	Updates          map[string]int64         `json:"updates"`
+	HandlerDuration  map[string]*Histogram    `json:"handler_duration"`
+	HandlersInFlight int64                    `json:"handlers_in_flight"`
+	PollLagSeconds   float64                  `json:"poll_lag_seconds"`
+}
+
+// Snapshot returns current values of all metrics
+func (m *Metrics) Snapshot() *Snapshot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := &Snapshot{
+		Requests:         make(map[string]map[int]int64),
+		RequestDuration:  make(map[string]*Histogram),
+		Updates:          make(map[string]int64),
+		HandlerDuration:  make(map[string]*Histogram),
+		HandlersInFlight: m.inFlight,
+		PollLagSeconds:   m.pollLag,
+	}
+	for k, v := range m.requests {
+		if s.Requests[k.method] == nil {
+			s.Requests[k.method] = make(map[int]int64)
+		}
+		s.Requests[k.method][k.code] = v
+	}
+	for k, h := range m.requestDur {
+		s.RequestDuration[k] = h.copy()
+	}
+	for k, v := range m.updates {
+		s.Updates[k] = v
+	}
+	for k, h := range m.handlerDur {
+		s.HandlerDuration[k] = h.copy()
+	}
+	return s
+}
+
+// PublishExpvar exports the snapshot as expvar variable with given name, it panics if the name is already in use
+func (m *Metrics) PublishExpvar(name string) {
+	expvar.Publish(name, expvar.Func(func() interface{} {
+		return m.Snapshot()
+	}))
+}
+
+// ServeHTTP serves metrics in Prometheus text exposition format
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	m.WritePrometheus(w)
+}
+
+// WritePrometheus writes metrics in Prometheus text exposition format
+func (m *Metrics) WritePrometheus(w io.Writer) {
+	s := m.Snapshot()
+
+	fmt.Fprintln(w, "# HELP tbot_api_requests_total Bot API calls by method and result code.")
+	fmt.Fprintln(w, "# TYPE tbot_api_requests_total counter")
+	for _, method := range sortedKeys(s.Requests) {
+		codes := s.Requests[method]
+		sorted := make([]int, 0, len(codes))
+		for code := range codes {
+			sorted = append(sorted, code)
+		}
+		sort.Ints(sorted)
+		for _, code := range sorted {
+			fmt.Fprintf(w, "tbot_api_requests_total{method=%q,code=\"%d\"} %d\n", method, code, codes[code])
+		}
+	}
+	writeHistograms(w, "tbot_api_request_duration_seconds", "Bot API call latency.", "method", s.RequestDuration)
+
+	fmt.Fprintln(w, "# HELP tbot_updates_total Incoming updates by type.")
+	fmt.Fprintln(w, "# TYPE tbot_updates_total counter")
+	for _, kind := range sortedKeys(s.Updates) {
+		fmt.Fprintf(w, "tbot_updates_total{type=%q} %d\n", kind, s.Updates[kind])
+	}
+	writeHistograms(w, "tbot_handler_duration_seconds", "Update processing time by update type.", "type", s.HandlerDuration)
+
+	fmt.Fprintln(w, "# HELP tbot_handlers_in_flight Updates being processed.")
+	fmt.Fprintln(w, "# TYPE tbot_handlers_in_flight gauge")
+	fmt.Fprintf(w, "tbot_handlers_in_flight %d\n", s.HandlersInFlight)
+	fmt.Fprintln(w, "# HELP tbot_poll_lag_seconds Delay between creation and receiving of the last update.")
+	fmt.Fprintln(w, "# TYPE tbot_poll_lag_seconds gauge")
+	fmt.Fprintf(w, "tbot_poll_lag_seconds %g\n", s.PollLagSeconds)
+}
+
+func writeHistograms(w io.Writer, name, help, label string, hs map[string]*Histogram) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s histogram\n", name)
+	for _, key := range sortedKeys(hs) {
+		h := hs[key]
+		for i, le := range h.Buckets {
+			fmt.Fprintf(w, "%s_bucket{%s=%q,le=\"%g\"} %d\n", name, label, key, le, h.Counts[i])
+		}
+		fmt.Fprintf(w, "%s_bucket{%s=%q,le=\"+Inf\"} %d\n", name, label, key, h.Count)
+		fmt.Fprintf(w, "%s_sum{%s=%q} %g\n", name, label, key, h.Sum)
+		fmt.Fprintf(w, "%s_count{%s=%q} %d\n", name, label, key, h.Count)
+	}
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch m := m.(type) {
+	case map[string]map[int]int64:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]int64:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]*Histogram:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,41 @@
+package metrics_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/yanzay/tbot/v2"
+	"github.com/yanzay/tbot/v2/metrics"
+	"github.com/yanzay/tbot/v2/tbottest"
+)
+
+func TestMetrics(t *testing.T) {
+	m := metrics.New()
+	d := tbottest.NewDriver(t, tbot.WithMetrics(m))
+	defer d.Close()
+	d.Bot.HandleMessage("", func(msg *tbot.Message) {
+		d.Bot.Client().SendMessage(msg.Chat.ID, "pong")
+	})
+	user := tbottest.User(1, "user")
+	d.API.FailNext("sendMessage", &tbottest.APIError{Code: 403, Description: "Forbidden"})
+	d.SendText(user, tbottest.PrivateChat(user), "ping")
+	d.SendText(user, tbottest.PrivateChat(user), "ping")
+
+	s := m.Snapshot()
+	if s.Requests["sendMessage"][200] != 1 || s.Requests["sendMessage"][403] != 1 {
+		t.Fatalf("wrong request counters: %v", s.Requests)
+	}
+	if s.Updates["message"] != 2 {
+		t.Fatalf("wrong update counters: %v", s.Updates)
+	}
+	if s.HandlerDuration["message"].Count != 2 || s.HandlersInFlight != 0 {
+		t.Fatalf("wrong handler metrics: %+v", s)
+	}
+
+	buf := &bytes.Buffer{}
+	m.WritePrometheus(buf)
+	if !strings.Contains(buf.String(), `tbot_api_requests_total{method="sendMessage",code="403"} 1`) {
+		t.Fatalf("unexpected prometheus output:\n%s", buf.String())
+	}
+}
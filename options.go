@@ -0,0 +1,540 @@
+package tbot
+
+import "net/url"
+
+/*
+option sets request parameters. Options are typed per method: a method accepts only options
+implementing its own option interface, so passing an option to a method which doesn't support it
+doesn't compile:
+
+	c.SendMessage(chatID, "hello", tbot.OptDisableNotification) // ok
+	c.SendMessage(chatID, "hello", tbot.OptShowAlert)           // compile error
+
+Opt* variables implement option interfaces of all methods they can be passed to.
+*/
+type option interface {
+	apply(v url.Values)
+}
+
+// Option sets arbitrary request parameters and can be passed to any method.
+// It is a compatibility layer for options written as plain functions and for parameters
+// which have no Opt* variable yet:
+//
+//	c.SendMessage(chatID, "hello", tbot.Option(func(v url.Values) {
+//		v.Set("protect_content", "true")
+//	}))
+type Option func(url.Values)
+
+func (o Option) apply(v url.Values) { o(v) }
+
+// ApplyOption sets request parameters of the option to v.
+// It lets mocks of capability interfaces check options they were called with.
+func ApplyOption(v url.Values, opt option) {
+	opt.apply(v)
+}
+
+// Option interfaces of methods
+type (
+	// SendMessageOption is an option of SendMessage and SendLongMessage
+	SendMessageOption interface {
+		option
+		sendMessageOption()
+	}
+
+	// ForwardMessageOption is an option of ForwardMessage
+	ForwardMessageOption interface {
+		option
+		forwardMessageOption()
+	}
+
+	// SendAudioOption is an option of SendAudio and SendAudioFile
+	SendAudioOption interface {
+		option
+		sendAudioOption()
+	}
+
+	// SendPhotoOption is an option of SendPhoto and SendPhotoFile
+	SendPhotoOption interface {
+		option
+		sendPhotoOption()
+	}
+
+	// SendDocumentOption is an option of SendDocument and SendDocumentFile
+	SendDocumentOption interface {
+		option
+		sendDocumentOption()
+	}
+
+	// SendVideoOption is an option of SendVideo and SendVideoFile
+	SendVideoOption interface {
+		option
+		sendVideoOption()
+	}
+
+	// SendAnimationOption is an option of SendAnimation and SendAnimationFile
+	SendAnimationOption interface {
+		option
+		sendAnimationOption()
+	}
+
+	// SendVoiceOption is an option of SendVoice and SendVoiceFile
+	SendVoiceOption interface {
+		option
+		sendVoiceOption()
+	}
+
+	// SendVideoNoteOption is an option of SendVideoNote and SendVideoNoteFile
+	SendVideoNoteOption interface {
+		option
+		sendVideoNoteOption()
+	}
+
+	// SendMediaGroupOption is an option of SendMediaGroup
+	SendMediaGroupOption interface {
+		option
+		sendMediaGroupOption()
+	}
+
+	// SendLocationOption is an option of SendLocation
+	SendLocationOption interface {
+		option
+		sendLocationOption()
+	}
+
+	// LiveLocationOption is an option of EditMessageLiveLocation, EditInlineMessageLiveLocation, StopMessageLiveLocation and StopInlineMessageLiveLocation
+	LiveLocationOption interface {
+		option
+		liveLocationOption()
+	}
+
+	// SendVenueOption is an option of SendVenue
+	SendVenueOption interface {
+		option
+		sendVenueOption()
+	}
+
+	// SendContactOption is an option of SendContact
+	SendContactOption interface {
+		option
+		sendContactOption()
+	}
+
+	// GetUserProfilePhotosOption is an option of GetUserProfilePhotos
+	GetUserProfilePhotosOption interface {
+		option
+		getUserProfilePhotosOption()
+	}
+
+	// KickChatMemberOption is an option of KickChatMember
+	KickChatMemberOption interface {
+		option
+		kickChatMemberOption()
+	}
+
+	// RestrictChatMemberOption is an option of RestrictChatMember
+	RestrictChatMemberOption interface {
+		option
+		restrictChatMemberOption()
+	}
+
+	// PinChatMessageOption is an option of PinChatMessage
+	PinChatMessageOption interface {
+		option
+		pinChatMessageOption()
+	}
+
+	// AnswerCallbackQueryOption is an option of AnswerCallbackQuery
+	AnswerCallbackQueryOption interface {
+		option
+		answerCallbackQueryOption()
+	}
+
+	// EditMessageTextOption is an option of EditMessageText and EditInlineMessageText
+	EditMessageTextOption interface {
+		option
+		editMessageTextOption()
+	}
+
+	// EditMessageCaptionOption is an option of EditMessageCaption and EditInlineMessageCaption
+	EditMessageCaptionOption interface {
+		option
+		editMessageCaptionOption()
+	}
+
+	// EditMessageReplyMarkupOption is an option of EditMessageReplyMarkup and EditInlineMessageReplyMarkup
+	EditMessageReplyMarkupOption interface {
+		option
+		editMessageReplyMarkupOption()
+	}
+
+	// SendStickerOption is an option of SendSticker and SendStickerFile
+	SendStickerOption interface {
+		option
+		sendStickerOption()
+	}
+
+	// CreateNewStickerSetOption is an option of CreateNewStickerSet and CreateNewStickerSetFile
+	CreateNewStickerSetOption interface {
+		option
+		createNewStickerSetOption()
+	}
+
+	// AddStickerToSetOption is an option of AddStickerToSet and AddStickerToSetFile
+	AddStickerToSetOption interface {
+		option
+		addStickerToSetOption()
+	}
+
+	// AnswerInlineQueryOption is an option of AnswerInlineQuery
+	AnswerInlineQueryOption interface {
+		option
+		answerInlineQueryOption()
+	}
+
+	// SendInvoiceOption is an option of SendInvoice
+	SendInvoiceOption interface {
+		option
+		sendInvoiceOption()
+	}
+
+	// AnswerShippingQueryOption is an option of AnswerShippingQuery
+	AnswerShippingQueryOption interface {
+		option
+		answerShippingQueryOption()
+	}
+
+	// AnswerPreCheckoutQueryOption is an option of AnswerPreCheckoutQuery
+	AnswerPreCheckoutQueryOption interface {
+		option
+		answerPreCheckoutQueryOption()
+	}
+
+	// SendGameOption is an option of SendGame
+	SendGameOption interface {
+		option
+		sendGameOption()
+	}
+
+	// SetGameScoreOption is an option of SetGameScore and SetInlineGameScore
+	SetGameScoreOption interface {
+		option
+		setGameScoreOption()
+	}
+
+	// SendPollOption is an option of SendPoll
+	SendPollOption interface {
+		option
+		sendPollOption()
+	}
+
+	// SendDiceOption is an option of SendDice
+	SendDiceOption interface {
+		option
+		sendDiceOption()
+	}
+
+	// StopPollOption is an option of StopPoll
+	StopPollOption interface {
+		option
+		stopPollOption()
+	}
+)
+
+func (Option) sendMessageOption()            {}
+func (Option) forwardMessageOption()         {}
+func (Option) sendAudioOption()              {}
+func (Option) sendPhotoOption()              {}
+func (Option) sendDocumentOption()           {}
+func (Option) sendVideoOption()              {}
+func (Option) sendAnimationOption()          {}
+func (Option) sendVoiceOption()              {}
+func (Option) sendVideoNoteOption()          {}
+func (Option) sendMediaGroupOption()         {}
+func (Option) sendLocationOption()           {}
+func (Option) liveLocationOption()           {}
+func (Option) sendVenueOption()              {}
+func (Option) sendContactOption()            {}
+func (Option) getUserProfilePhotosOption()   {}
+func (Option) kickChatMemberOption()         {}
+func (Option) restrictChatMemberOption()     {}
+func (Option) pinChatMessageOption()         {}
+func (Option) answerCallbackQueryOption()    {}
+func (Option) editMessageTextOption()        {}
+func (Option) editMessageCaptionOption()     {}
+func (Option) editMessageReplyMarkupOption() {}
+func (Option) sendStickerOption()            {}
+func (Option) createNewStickerSetOption()    {}
+func (Option) addStickerToSetOption()        {}
+func (Option) answerInlineQueryOption()      {}
+func (Option) sendInvoiceOption()            {}
+func (Option) answerShippingQueryOption()    {}
+func (Option) answerPreCheckoutQueryOption() {}
+func (Option) sendGameOption()               {}
+func (Option) setGameScoreOption()           {}
+func (Option) sendPollOption()               {}
+func (Option) sendDiceOption()               {}
+func (Option) stopPollOption()               {}
+
+// notificationOption sends the message silently: OptDisableNotification
+type notificationOption func(url.Values)
+
+func (o notificationOption) apply(v url.Values)  { o(v) }
+func (notificationOption) sendMessageOption()    {}
+func (notificationOption) forwardMessageOption() {}
+func (notificationOption) sendAudioOption()      {}
+func (notificationOption) sendPhotoOption()      {}
+func (notificationOption) sendDocumentOption()   {}
+func (notificationOption) sendVideoOption()      {}
+func (notificationOption) sendAnimationOption()  {}
+func (notificationOption) sendVoiceOption()      {}
+func (notificationOption) sendVideoNoteOption()  {}
+func (notificationOption) sendMediaGroupOption() {}
+func (notificationOption) sendLocationOption()   {}
+func (notificationOption) sendVenueOption()      {}
+func (notificationOption) sendContactOption()    {}
+func (notificationOption) pinChatMessageOption() {}
+func (notificationOption) sendStickerOption()    {}
+func (notificationOption) sendInvoiceOption()    {}
+func (notificationOption) sendGameOption()       {}
+func (notificationOption) sendPollOption()       {}
+func (notificationOption) sendDiceOption()       {}
+
+// replyOption sends the message as a reply: OptReplyToMessageID
+type replyOption func(url.Values)
+
+func (o replyOption) apply(v url.Values)  { o(v) }
+func (replyOption) sendMessageOption()    {}
+func (replyOption) sendAudioOption()      {}
+func (replyOption) sendPhotoOption()      {}
+func (replyOption) sendDocumentOption()   {}
+func (replyOption) sendVideoOption()      {}
+func (replyOption) sendAnimationOption()  {}
+func (replyOption) sendVoiceOption()      {}
+func (replyOption) sendVideoNoteOption()  {}
+func (replyOption) sendMediaGroupOption() {}
+func (replyOption) sendLocationOption()   {}
+func (replyOption) sendVenueOption()      {}
+func (replyOption) sendContactOption()    {}
+func (replyOption) sendStickerOption()    {}
+func (replyOption) sendInvoiceOption()    {}
+func (replyOption) sendGameOption()       {}
+func (replyOption) sendPollOption()       {}
+func (replyOption) sendDiceOption()       {}
+
+// replyMarkupOption sets reply keyboard or forces reply: OptReplyKeyboardMarkup, OptReplyKeyboardRemove, OptReplyKeyboardRemoveSelective, OptForceReply, OptForceReplySelective, OptReplyKeyboardRemoveMarkup, OptForceReplyMarkup
+type replyMarkupOption func(url.Values)
+
+func (o replyMarkupOption) apply(v url.Values) { o(v) }
+func (replyMarkupOption) sendMessageOption()   {}
+func (replyMarkupOption) sendAudioOption()     {}
+func (replyMarkupOption) sendPhotoOption()     {}
+func (replyMarkupOption) sendDocumentOption()  {}
+func (replyMarkupOption) sendVideoOption()     {}
+func (replyMarkupOption) sendAnimationOption() {}
+func (replyMarkupOption) sendVoiceOption()     {}
+func (replyMarkupOption) sendVideoNoteOption() {}
+func (replyMarkupOption) sendLocationOption()  {}
+func (replyMarkupOption) sendVenueOption()     {}
+func (replyMarkupOption) sendContactOption()   {}
+func (replyMarkupOption) sendStickerOption()   {}
+func (replyMarkupOption) sendPollOption()      {}
+func (replyMarkupOption) sendDiceOption()      {}
+
+// inlineKeyboardOption attaches inline keyboard to the message: OptInlineKeyboardMarkup
+type inlineKeyboardOption func(url.Values)
+
+func (o inlineKeyboardOption) apply(v url.Values)          { o(v) }
+func (inlineKeyboardOption) sendMessageOption()            {}
+func (inlineKeyboardOption) sendAudioOption()              {}
+func (inlineKeyboardOption) sendPhotoOption()              {}
+func (inlineKeyboardOption) sendDocumentOption()           {}
+func (inlineKeyboardOption) sendVideoOption()              {}
+func (inlineKeyboardOption) sendAnimationOption()          {}
+func (inlineKeyboardOption) sendVoiceOption()              {}
+func (inlineKeyboardOption) sendVideoNoteOption()          {}
+func (inlineKeyboardOption) sendLocationOption()           {}
+func (inlineKeyboardOption) liveLocationOption()           {}
+func (inlineKeyboardOption) sendVenueOption()              {}
+func (inlineKeyboardOption) sendContactOption()            {}
+func (inlineKeyboardOption) editMessageTextOption()        {}
+func (inlineKeyboardOption) editMessageCaptionOption()     {}
+func (inlineKeyboardOption) editMessageReplyMarkupOption() {}
+func (inlineKeyboardOption) sendStickerOption()            {}
+func (inlineKeyboardOption) sendInvoiceOption()            {}
+func (inlineKeyboardOption) sendGameOption()               {}
+func (inlineKeyboardOption) sendPollOption()               {}
+func (inlineKeyboardOption) sendDiceOption()               {}
+func (inlineKeyboardOption) stopPollOption()               {}
+
+// parseModeOption sets parse mode of text or caption: OptParseModeHTML, OptParseModeMarkdown
+type parseModeOption func(url.Values)
+
+func (o parseModeOption) apply(v url.Values)      { o(v) }
+func (parseModeOption) sendMessageOption()        {}
+func (parseModeOption) sendAudioOption()          {}
+func (parseModeOption) sendPhotoOption()          {}
+func (parseModeOption) sendDocumentOption()       {}
+func (parseModeOption) sendVideoOption()          {}
+func (parseModeOption) sendAnimationOption()      {}
+func (parseModeOption) sendVoiceOption()          {}
+func (parseModeOption) editMessageTextOption()    {}
+func (parseModeOption) editMessageCaptionOption() {}
+
+// textOption sets entities and link preview of message text: OptEntities, OptDisableWebPagePreview
+type textOption func(url.Values)
+
+func (o textOption) apply(v url.Values)   { o(v) }
+func (textOption) sendMessageOption()     {}
+func (textOption) editMessageTextOption() {}
+
+// captionOption sets media caption: OptCaption, OptCaptionEntities
+type captionOption func(url.Values)
+
+func (o captionOption) apply(v url.Values) { o(v) }
+func (captionOption) sendAudioOption()     {}
+func (captionOption) sendPhotoOption()     {}
+func (captionOption) sendDocumentOption()  {}
+func (captionOption) sendVideoOption()     {}
+func (captionOption) sendAnimationOption() {}
+func (captionOption) sendVoiceOption()     {}
+
+// durationOption sets duration of audio and video: OptDuration
+type durationOption func(url.Values)
+
+func (o durationOption) apply(v url.Values) { o(v) }
+func (durationOption) sendAudioOption()     {}
+func (durationOption) sendVideoOption()     {}
+func (durationOption) sendAnimationOption() {}
+func (durationOption) sendVoiceOption()     {}
+func (durationOption) sendVideoNoteOption() {}
+
+// sizeOption sets size of video and animation: OptWidth, OptHeight
+type sizeOption func(url.Values)
+
+func (o sizeOption) apply(v url.Values) { o(v) }
+func (sizeOption) sendVideoOption()     {}
+func (sizeOption) sendAnimationOption() {}
+
+// copyOption sets how messages are copied: OptRemoveCaption
+type copyOption func(url.Values)
+
+func (o copyOption) apply(v url.Values) { o(v) }
+
+// thumbOption uploads thumbnail: OptThumb
+type thumbOption func(url.Values)
+
+func (o thumbOption) apply(v url.Values) { o(v) }
+func (thumbOption) sendAnimationOption() {}
+func (thumbOption) sendVideoNoteOption() {}
+
+// audioOption sets audio metadata: OptPerformer, OptTitle
+type audioOption func(url.Values)
+
+func (o audioOption) apply(v url.Values) { o(v) }
+func (audioOption) sendAudioOption()     {}
+
+// videoOption sets video streaming: OptSupportsStreaming
+type videoOption func(url.Values)
+
+func (o videoOption) apply(v url.Values) { o(v) }
+func (videoOption) sendVideoOption()     {}
+
+// videoNoteOption sets video note size: OptLength
+type videoNoteOption func(url.Values)
+
+func (o videoNoteOption) apply(v url.Values) { o(v) }
+func (videoNoteOption) sendVideoNoteOption() {}
+
+// locationOption sets live location period: OptLivePeriod
+type locationOption func(url.Values)
+
+func (o locationOption) apply(v url.Values) { o(v) }
+func (locationOption) sendLocationOption()  {}
+
+// venueOption sets Foursquare venue: OptFoursquareID, OptFoursquareType
+type venueOption func(url.Values)
+
+func (o venueOption) apply(v url.Values) { o(v) }
+func (venueOption) sendVenueOption()     {}
+
+// contactOption sets contact details: OptLastName, OptVCard
+type contactOption func(url.Values)
+
+func (o contactOption) apply(v url.Values) { o(v) }
+func (contactOption) sendContactOption()   {}
+
+// profilePhotosOption pages profile photos: OptOffset, OptLimit
+type profilePhotosOption func(url.Values)
+
+func (o profilePhotosOption) apply(v url.Values)        { o(v) }
+func (profilePhotosOption) getUserProfilePhotosOption() {}
+
+// untilDateOption limits restriction period: OptUntilDate
+type untilDateOption func(url.Values)
+
+func (o untilDateOption) apply(v url.Values)      { o(v) }
+func (untilDateOption) kickChatMemberOption()     {}
+func (untilDateOption) restrictChatMemberOption() {}
+
+// callbackAnswerOption sets callback query answer: OptText, OptShowAlert, OptURL
+type callbackAnswerOption func(url.Values)
+
+func (o callbackAnswerOption) apply(v url.Values)       { o(v) }
+func (callbackAnswerOption) answerCallbackQueryOption() {}
+
+// cacheTimeOption sets caching time of the answer: OptCacheTime
+type cacheTimeOption func(url.Values)
+
+func (o cacheTimeOption) apply(v url.Values)       { o(v) }
+func (cacheTimeOption) answerCallbackQueryOption() {}
+func (cacheTimeOption) answerInlineQueryOption()   {}
+
+// stickerOption sets sticker format and mask position: OptMaskPosition, OptAnimatedSticker
+type stickerOption func(url.Values)
+
+func (o stickerOption) apply(v url.Values)       { o(v) }
+func (stickerOption) createNewStickerSetOption() {}
+func (stickerOption) addStickerToSetOption()     {}
+
+// stickerSetOption sets sticker set type: OptContainsMasks
+type stickerSetOption func(url.Values)
+
+func (o stickerSetOption) apply(v url.Values)       { o(v) }
+func (stickerSetOption) createNewStickerSetOption() {}
+
+// inlineAnswerOption sets inline query answer: OptIsPersonal, OptNextOffset, OptSwitchPmText, OptSwitchPmParameter
+type inlineAnswerOption func(url.Values)
+
+func (o inlineAnswerOption) apply(v url.Values)     { o(v) }
+func (inlineAnswerOption) answerInlineQueryOption() {}
+
+// invoiceOption sets invoice details: OptProviderData, OptPhotoURL, OptPhotoSize, OptPhotoWidth, OptPhotoHeight, OptNeedName, OptNeedPhoneNumber, OptNeedEmail, OptNeedShippingAddress, OptSendPhoneNumberToProvider, OptSendEmailToProvider, OptIsFlexible
+type invoiceOption func(url.Values)
+
+func (o invoiceOption) apply(v url.Values) { o(v) }
+func (invoiceOption) sendInvoiceOption()   {}
+
+// shippingAnswerOption sets shipping options: OptShippingOptions
+type shippingAnswerOption func(url.Values)
+
+func (o shippingAnswerOption) apply(v url.Values)       { o(v) }
+func (shippingAnswerOption) answerShippingQueryOption() {}
+
+// errorMessageOption explains why the query is declined: OptErrorMessage
+type errorMessageOption func(url.Values)
+
+func (o errorMessageOption) apply(v url.Values)          { o(v) }
+func (errorMessageOption) answerShippingQueryOption()    {}
+func (errorMessageOption) answerPreCheckoutQueryOption() {}
+
+// gameScoreOption sets game score update: OptForce, OptDisableEditMessage
+type gameScoreOption func(url.Values)
+
+func (o gameScoreOption) apply(v url.Values) { o(v) }
+func (gameScoreOption) setGameScoreOption()  {}
+
+// newPollOption sets poll settings: OptNotAnonymous, OptPollType, OptAllowMultipleAnswers, OptCorrectOptionID, OptClosedPoll
+type newPollOption func(url.Values)
+
+func (o newPollOption) apply(v url.Values) { o(v) }
+func (newPollOption) sendPollOption()      {}
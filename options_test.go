@@ -0,0 +1,51 @@
+package tbot_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/yanzay/tbot/v2"
+	"github.com/yanzay/tbot/v2/tbottest"
+)
+
+// options shared between methods implement option interfaces of all of them
+var (
+	_ tbot.SendMessageOption        = tbot.OptDisableNotification
+	_ tbot.PinChatMessageOption     = tbot.OptDisableNotification
+	_ tbot.EditMessageTextOption    = tbot.OptInlineKeyboardMarkup(nil)
+	_ tbot.StopPollOption           = tbot.OptInlineKeyboardMarkup(nil)
+	_ tbot.SendVideoNoteOption      = tbot.OptDuration(0)
+	_ tbot.AnswerInlineQueryOption  = tbot.OptCacheTime(0)
+	_ tbot.RestrictChatMemberOption = tbot.OptUntilDate(time.Time{})
+	_ tbot.BanChatMemberOption      = tbot.OptUntilDate(time.Time{})
+	_ tbot.EditForumTopicOption     = tbot.OptIconCustomEmojiID("")
+	_ tbot.SendDiceOption           = tbot.Option(nil)
+)
+
+func TestOptions(t *testing.T) {
+	api := tbottest.NewServer(token)
+	defer api.Close()
+	c := api.Client()
+
+	_, err := c.SendMessage("1", "<b>hi</b>", tbot.OptParseModeHTML, tbot.OptReplyToMessageID(3),
+		tbot.Option(func(v url.Values) {
+			v.Set("protect_content", "true")
+		}))
+	if err != nil {
+		t.Fatalf("unable to send message: %v", err)
+	}
+	params := api.CallsTo("sendMessage")[0].Params
+	if params.Get("parse_mode") != "HTML" || params.Get("reply_to_message_id") != "3" || params.Get("protect_content") != "true" {
+		t.Errorf("unexpected params %v", params)
+	}
+
+	err = c.AnswerCallbackQuery("cq1", tbot.OptText("done"), tbot.OptShowAlert)
+	if err != nil {
+		t.Fatalf("unable to answer callback query: %v", err)
+	}
+	params = api.CallsTo("answerCallbackQuery")[0].Params
+	if params.Get("text") != "done" || params.Get("show_alert") != "true" {
+		t.Errorf("unexpected params %v", params)
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Package otel adapts tbot.Tracer to OpenTelemetry.
+
+It's a separate module, so tbot itself stays free of dependencies.
+Import it as tbototel to avoid a clash with go.opentelemetry.io/otel:
+
+	bot := tbot.New(token, tbot.WithTracer(tbototel.New(otel.GetTracerProvider())))
+	bot.HandleMessage("", func(m *tbot.Message) {
+		bot.Client().WithContext(m.Context()).SendMessage(m.Chat.ID, "hi")
+	})
+
+Every update gets a span named "tbot.update <type>" covering middlewares and handlers,
+Bot API calls made with the update context get child spans named after the API method.
+*/
+package otel
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yanzay/tbot/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName is the name of the OpenTelemetry tracer used by the adapter
+const InstrumentationName = "github.com/yanzay/tbot/v2"
+
+var _ tbot.Tracer = (*Tracer)(nil)
+
+// Tracer implements tbot.Tracer with OpenTelemetry spans
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+// New creates Tracer getting OpenTelemetry tracer from the provider
+func New(provider trace.TracerProvider) *Tracer {
+	return &Tracer{tracer: provider.Tracer(InstrumentationName)}
+}
+
+// StartUpdate implements tbot.Tracer
+func (t *Tracer) StartUpdate(ctx context.Context, update *tbot.Update) (context.Context, tbot.Span) {
+	ctx, span := t.tracer.Start(ctx, "tbot.update "+update.Kind(), trace.WithSpanKind(trace.SpanKindConsumer))
+	return ctx, &Span{span: span}
+}
+
+// StartRequest implements tbot.Tracer
+func (t *Tracer) StartRequest(ctx context.Context, method string) (context.Context, tbot.Span) {
+	ctx, span := t.tracer.Start(ctx, method, trace.WithSpanKind(trace.SpanKindClient))
+	return ctx, &Span{span: span}
+}
+
+// Span implements tbot.Span wrapping OpenTelemetry span
+type Span struct {
+	span trace.Span
+}
+
+// SetAttribute implements tbot.Span
+func (s *Span) SetAttribute(key string, value interface{}) {
+	var kv attribute.KeyValue
+	switch v := value.(type) {
+	case string:
+		kv = attribute.String(key, v)
+	case bool:
+		kv = attribute.Bool(key, v)
+	case int:
+		kv = attribute.Int(key, v)
+	case int64:
+		kv = attribute.Int64(key, v)
+	case float64:
+		kv = attribute.Float64(key, v)
+	default:
+		kv = attribute.String(key, fmt.Sprint(v))
+	}
+	s.span.SetAttributes(kv)
+}
+
+// SetError implements tbot.Span
+func (s *Span) SetError(err error) {
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+// End implements tbot.Span
+func (s *Span) End() {
+	s.span.End()
+}
+
+// Unwrap returns the OpenTelemetry span
+func (s *Span) Unwrap() trace.Span {
+	return s.span
+}
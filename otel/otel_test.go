@@ -0,0 +1,53 @@
+package otel_test
+
+import (
+	"testing"
+
+	"github.com/yanzay/tbot/v2"
+	"github.com/yanzay/tbot/v2/otel"
+	"github.com/yanzay/tbot/v2/tbottest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracer(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	d := tbottest.NewDriver(t, tbot.WithTracer(otel.New(provider)))
+	defer d.Close()
+	d.Bot.HandleMessage("", func(m *tbot.Message) {
+		d.Bot.Client().WithContext(m.Context()).SendMessage(m.Chat.ID, "pong")
+	})
+	d.API.FailNext("sendMessage", &tbottest.APIError{Code: 403, Description: "Forbidden"})
+	user := tbottest.User(1, "alice")
+	d.SendText(user, tbottest.PrivateChat(user), "ping")
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	call, update := spans[0], spans[1]
+	if update.Name() != "tbot.update message" {
+		t.Errorf("unexpected update span name %q", update.Name())
+	}
+	if call.Name() != "sendMessage" {
+		t.Errorf("unexpected call span name %q", call.Name())
+	}
+	if call.Parent().SpanID() != update.SpanContext().SpanID() {
+		t.Errorf("call span is not a child of the update span")
+	}
+	if call.Status().Code != codes.Error {
+		t.Errorf("expected error status, got %v", call.Status())
+	}
+	found := false
+	for _, kv := range call.Attributes() {
+		if kv == attribute.Int("tbot.response_code", 403) {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("response code attribute is missing: %v", call.Attributes())
+	}
+}
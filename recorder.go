@@ -0,0 +1,190 @@
+package tbot
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// RecordedUpdate is one line of an update recording
+type RecordedUpdate struct {
+	Time   time.Time       `json:"time"`
+	Update json.RawMessage `json:"update"`
+}
+
+// Redactor modifies decoded update JSON before it's recorded, e.g. to remove personal data
+type Redactor func(update map[string]interface{})
+
+// UpdateRecorder writes raw updates received by the Server to a JSON-lines stream,
+// see WithUpdateRecorder
+type UpdateRecorder struct {
+	mu        sync.Mutex
+	w         io.Writer
+	redactors []Redactor
+}
+
+// NewUpdateRecorder creates recorder writing to w, redactors are applied to every update in order
+func NewUpdateRecorder(w io.Writer, redactors ...Redactor) *UpdateRecorder {
+	return &UpdateRecorder{w: w, redactors: redactors}
+}
+
+// RedactFields returns Redactor replacing values of given fields anywhere in the update with "[REDACTED]",
+// e.g. RedactFields("first_name", "last_name", "username", "phone_number")
+func RedactFields(fields ...string) Redactor {
+	set := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		set[f] = true
+	}
+	var redact func(v interface{})
+	redact = func(v interface{}) {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			for k, child := range v {
+				if set[k] {
+					v[k] = "[REDACTED]"
+					continue
+				}
+				redact(child)
+			}
+		case []interface{}:
+			for _, child := range v {
+				redact(child)
+			}
+		}
+	}
+	return func(update map[string]interface{}) {
+		redact(update)
+	}
+}
+
+// Record writes the update to the recording
+func (r *UpdateRecorder) Record(up *Update) error {
+	raw := up.Raw()
+	if raw == nil {
+		var err error
+		raw, err = json.Marshal(up)
+		if err != nil {
+			return fmt.Errorf("unable to encode update: %v", err)
+		}
+	}
+	if len(r.redactors) > 0 {
+		var decoded map[string]interface{}
+		err := json.Unmarshal(raw, &decoded)
+		if err != nil {
+			return fmt.Errorf("unable to decode update: %v", err)
+		}
+		for _, redact := range r.redactors {
+			redact(decoded)
+		}
+		raw, err = json.Marshal(decoded)
+		if err != nil {
+			return fmt.Errorf("unable to encode update: %v", err)
+		}
+	}
+	line, err := json.Marshal(RecordedUpdate{Time: time.Now(), Update: raw})
+	if err != nil {
+		return fmt.Errorf("unable to encode update: %v", err)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, err = r.w.Write(append(line, '\n'))
+	return err
+}
+
+// UpdateSource provides updates to the Server instead of long polling or webhook, see WithUpdateSource.
+// Server stops when the channel is closed.
+type UpdateSource interface {
+	Updates() (<-chan *Update, error)
+}
+
+// Replay is an UpdateSource feeding a recording made by UpdateRecorder back to the Server
+type Replay struct {
+	r      io.Reader
+	speed  float64
+	logger *libLogger
+	ctx    context.Context
+}
+
+// NewReplay creates Replay reading recording from r. Speed 1 keeps original intervals between updates,
+// 10 replays ten times faster, 0 sends updates without delays.
+func NewReplay(r io.Reader, speed float64) *Replay {
+	return &Replay{r: r, speed: speed, logger: newLibLogger(nopLogger{}, LevelInfo, "")}
+}
+
+// WithContext returns shallow copy of the replay which stops when ctx is done.
+// Server stops its replay itself when Server.Stop is called.
+func (rp *Replay) WithContext(ctx context.Context) *Replay {
+	rc := *rp
+	rc.ctx = ctx
+	return &rc
+}
+
+// serverReplay returns copy of the replay logging to the server logger and stopping with the server
+// or when the context given to WithContext is done
+func (rp *Replay) serverReplay(s *Server) *Replay {
+	rc := *rp
+	rc.logger = s.logger
+	rc.ctx = s.ctx
+	if rp.ctx != nil {
+		ctx, cancel := context.WithCancel(rp.ctx)
+		go func() {
+			select {
+			case <-s.ctx.Done():
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+		rc.ctx = ctx
+	}
+	return &rc
+}
+
+// Updates implements UpdateSource
+func (rp *Replay) Updates() (<-chan *Update, error) {
+	ctx := rp.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	updates := make(chan *Update)
+	scanner := bufio.NewScanner(rp.r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	go func() {
+		defer close(updates)
+		var last time.Time
+		for scanner.Scan() {
+			rec := &RecordedUpdate{}
+			err := json.Unmarshal(scanner.Bytes(), rec)
+			if err != nil {
+				rp.logger.error("unable to decode recorded update", "error", err)
+				continue
+			}
+			up := &Update{}
+			err = json.Unmarshal(rec.Update, up)
+			if err != nil {
+				rp.logger.error("unable to decode recorded update", "error", err)
+				continue
+			}
+			if rp.speed > 0 && !last.IsZero() && rec.Time.After(last) {
+				select {
+				case <-time.After(time.Duration(float64(rec.Time.Sub(last)) / rp.speed)):
+				case <-ctx.Done():
+					return
+				}
+			}
+			last = rec.Time
+			select {
+			case updates <- up:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			rp.logger.error("unable to read recording", "error", err)
+		}
+	}()
+	return updates, nil
+}
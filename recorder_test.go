@@ -0,0 +1,122 @@
+package tbot_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yanzay/tbot/v2"
+)
+
+func TestRecordReplay(t *testing.T) {
+	buf := &bytes.Buffer{}
+	rec := tbot.NewUpdateRecorder(buf, tbot.RedactFields("first_name"))
+	for _, data := range []string{
+		`{"update_id": 1, "message": {"message_id": 1, "text": "one", "chat": {"id": 1}, "from": {"id": 1, "first_name": "Alice"}}}`,
+		`{"update_id": 2, "message": {"message_id": 2, "text": "two", "chat": {"id": 1}}}`,
+	} {
+		up := &tbot.Update{}
+		err := json.Unmarshal([]byte(data), up)
+		if err != nil {
+			t.Fatalf("unable to decode update: %v", err)
+		}
+		err = rec.Record(up)
+		if err != nil {
+			t.Fatalf("unable to record update: %v", err)
+		}
+	}
+	if strings.Contains(buf.String(), "Alice") {
+		t.Fatalf("first_name is not redacted: %s", buf.String())
+	}
+
+	texts := make(chan string, 2)
+	bot := tbot.New("TOKEN", tbot.WithUpdateSource(tbot.NewReplay(buf, 0)))
+	bot.HandleMessage("", func(m *tbot.Message) {
+		texts <- m.Text
+	})
+	err := bot.Start()
+	if err != nil {
+		t.Fatalf("unable to start replay: %v", err)
+	}
+	got := map[string]bool{}
+	for i := 0; i < 2; i++ {
+		select {
+		case text := <-texts:
+			got[text] = true
+		case <-time.After(time.Second):
+			t.Fatalf("replayed updates are not handled")
+		}
+	}
+	if !got["one"] || !got["two"] {
+		t.Fatalf("wrong replayed messages: %v", got)
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		bot.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatalf("Stop blocks after the replay is finished")
+	}
+}
+
+func TestReplayStops(t *testing.T) {
+	recording := strings.Repeat(`{"time": "2020-01-01T00:00:00Z", "update": {"update_id": 1}}`+"\n", 100)
+	ctx, cancel := context.WithCancel(context.Background())
+	updates, err := tbot.NewReplay(strings.NewReader(recording), 0).WithContext(ctx).Updates()
+	if err != nil {
+		t.Fatalf("unable to start replay: %v", err)
+	}
+	<-updates
+	cancel()
+	received := 1
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-updates:
+			if !ok {
+				if received == 100 {
+					t.Errorf("replay is not stopped before the end of recording")
+				}
+				return
+			}
+			received++
+		case <-timeout:
+			t.Fatalf("replay is not stopped")
+		}
+	}
+}
+
+func TestServerReplayContext(t *testing.T) {
+	recording := `{"time": "2020-01-01T00:00:00Z", "update": {"update_id": 1, "message": {"text": "one", "chat": {"id": 1}}}}` + "\n" +
+		`{"time": "2020-01-01T01:00:00Z", "update": {"update_id": 2, "message": {"text": "two", "chat": {"id": 1}}}}` + "\n"
+	ctx, cancel := context.WithCancel(context.Background())
+	replay := tbot.NewReplay(strings.NewReader(recording), 1).WithContext(ctx)
+	handled := make(chan struct{}, 2)
+	bot := tbot.New("TOKEN", tbot.WithUpdateSource(replay))
+	defer bot.Stop()
+	bot.HandleMessage("", func(m *tbot.Message) {
+		handled <- struct{}{}
+	})
+	stopped := make(chan error)
+	go func() {
+		stopped <- bot.Start()
+	}()
+	select {
+	case <-handled:
+	case <-time.After(time.Second):
+		t.Fatalf("replayed update is not handled")
+	}
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatalf("replay is not stopped by its context")
+	}
+}
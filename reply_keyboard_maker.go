@@ -1,52 +1,93 @@
 package tbot
 
-type replyRow struct {
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// ReplyRow is a row of a reply keyboard
+type ReplyRow struct {
 	buttons []KeyboardButton
 }
 
-// Short version for adding a new button to a replyRow,
+// Short version for adding a new button to a ReplyRow,
 // just accepts a text and ignore other properties of a button,
 // which is the common case.
-func (r *replyRow) AddButton(text string) {
+func (r *ReplyRow) AddButton(text string) {
 	r.buttons = append(r.buttons, KeyboardButton{
 		Text: text,
 	})
 }
 
 // Full versions of adding a new button
-func (r *replyRow) AddButtonFull(button KeyboardButton) {
+func (r *ReplyRow) AddButtonFull(button KeyboardButton) {
 	r.buttons = append(r.buttons, button)
 }
 
-type replyKeyboardMarker struct {
-	replyRows []*replyRow
-	resize    bool
-	oneTime   bool
-	selective bool
+// ReplyKeyboardMaker is a builder for reply keyboards
+type ReplyKeyboardMaker struct {
+	replyRows   []*ReplyRow
+	resize      bool
+	oneTime     bool
+	selective   bool
+	placeholder string
+	columns     int
+	maxWidth    int
 }
 
-// NewReplyKeyboardMaker makes a replyKeyboardMarker (builder design pattern)
+// NewReplyKeyboardMaker makes a ReplyKeyboardMaker (builder design pattern)
 // which then can be used for making reply keyboards,
 //
 // It has some methods for adding new rows and you can call Build method at the end and use returned Keyboard.
-func NewReplyKeyboardMaker() *replyKeyboardMarker {
-	return &replyKeyboardMarker{}
+func NewReplyKeyboardMaker() *ReplyKeyboardMaker {
+	return &ReplyKeyboardMaker{}
 }
 
 // Add a new row to a Reply keyboard and returns a pointer to it,
 // then you can use this pointer to add new buttons using `.AddButton` or `.AddButtonFull`
-func (km *replyKeyboardMarker) AddRow() *replyRow {
-	newReplyRow := new(replyRow)
+func (km *ReplyKeyboardMaker) AddRow() *ReplyRow {
+	newReplyRow := new(ReplyRow)
 	km.replyRows = append(km.replyRows, newReplyRow)
 	return newReplyRow
 }
 
-func (km *replyKeyboardMarker) Build() *ReplyKeyboardMarkup {
+// Columns sets maximum number of buttons in a row for the buttons added with Add
+func (km *ReplyKeyboardMaker) Columns(n int) *ReplyKeyboardMaker {
+	km.columns = n
+	return km
+}
+
+// MaxRowWidth sets maximum total length of button texts in a row for the buttons added with Add.
+// Buttons wrap to the next row when the texts don't fit.
+func (km *ReplyKeyboardMaker) MaxRowWidth(chars int) *ReplyKeyboardMaker {
+	km.maxWidth = chars
+	return km
+}
+
+// Add adds buttons to the keyboard, placing them in rows according to Columns and MaxRowWidth.
+// Without layout settings every button gets its own row.
+func (km *ReplyKeyboardMaker) Add(buttons ...KeyboardButton) *ReplyKeyboardMaker {
+	for _, button := range buttons {
+		var last *ReplyRow
+		if len(km.replyRows) > 0 {
+			last = km.replyRows[len(km.replyRows)-1]
+		}
+		if last == nil || !fitsRow(len(last.buttons), replyRowWidth(last.buttons), button.Text, km.columns, km.maxWidth) {
+			last = km.AddRow()
+		}
+		last.AddButtonFull(button)
+	}
+	return km
+}
+
+// Build returns the reply keyboard without checking it, see BuildChecked
+func (km *ReplyKeyboardMaker) Build() *ReplyKeyboardMarkup {
 	ikm := &ReplyKeyboardMarkup{
-		Keyboard:        make([][]KeyboardButton, len(km.replyRows)),
-		ResizeKeyboard:  km.resize,
-		OneTimeKeyboard: km.oneTime,
-		Selective:       km.selective,
+		Keyboard:              make([][]KeyboardButton, len(km.replyRows)),
+		ResizeKeyboard:        km.resize,
+		OneTimeKeyboard:       km.oneTime,
+		InputFieldPlaceholder: km.placeholder,
+		Selective:             km.selective,
 	}
 	for i, replyRow := range km.replyRows {
 		ikm.Keyboard[i] = replyRow.buttons
@@ -54,14 +95,101 @@ func (km *replyKeyboardMarker) Build() *ReplyKeyboardMarkup {
 	return ikm
 }
 
-func (km *replyKeyboardMarker) SetResize(resize bool) {
+// BuildChecked returns the reply keyboard, or an error if any of its buttons or the placeholder is invalid, see Validate
+func (km *ReplyKeyboardMaker) BuildChecked() (*ReplyKeyboardMarkup, error) {
+	rkm := km.Build()
+	if err := rkm.Validate(); err != nil {
+		return nil, err
+	}
+	return rkm, nil
+}
+
+func (km *ReplyKeyboardMaker) SetResize(resize bool) {
 	km.resize = resize
 }
 
-func (km *replyKeyboardMarker) SetSelective(selective bool) {
+func (km *ReplyKeyboardMaker) SetSelective(selective bool) {
 	km.selective = selective
 }
 
-func (km *replyKeyboardMarker) SetOneTime(oneTime bool) {
+func (km *ReplyKeyboardMaker) SetOneTime(oneTime bool) {
 	km.oneTime = oneTime
 }
+
+// SetPlaceholder sets the placeholder shown in the input field when the keyboard is active, 1-64 characters
+func (km *ReplyKeyboardMaker) SetPlaceholder(placeholder string) {
+	km.placeholder = placeholder
+}
+
+// ReplyTextButton creates a button sending its text as a message
+func ReplyTextButton(text string) KeyboardButton {
+	return KeyboardButton{Text: text}
+}
+
+// ReplyContactButton creates a button sending the user's phone number
+func ReplyContactButton(text string) KeyboardButton {
+	return KeyboardButton{Text: text, RequestContact: true}
+}
+
+// ReplyLocationButton creates a button sending the user's current location
+func ReplyLocationButton(text string) KeyboardButton {
+	return KeyboardButton{Text: text, RequestLocation: true}
+}
+
+// ReplyPollButton creates a button asking the user to create a poll of given type, empty type allows any poll
+func ReplyPollButton(text string, pollType PollType) KeyboardButton {
+	return KeyboardButton{Text: text, RequestPoll: &KeyboardButtonPollType{Type: string(pollType)}}
+}
+
+// ReplyWebAppButton creates a button launching the Web App
+func ReplyWebAppButton(text, url string) KeyboardButton {
+	return KeyboardButton{Text: text, WebApp: &WebAppInfo{URL: url}}
+}
+
+// ReplyRequestUserButton creates a button asking the user to share a user, requestID comes back in Message.UserShared
+func ReplyRequestUserButton(text string, requestID int, isBot bool) KeyboardButton {
+	return KeyboardButton{Text: text, RequestUser: &KeyboardButtonRequestUser{RequestID: requestID, UserIsBot: &isBot}}
+}
+
+// ReplyRequestChatButton creates a button asking the user to share a chat, requestID comes back in Message.ChatShared
+func ReplyRequestChatButton(text string, requestID int, isChannel bool) KeyboardButton {
+	return KeyboardButton{Text: text, RequestChat: &KeyboardButtonRequestChat{RequestID: requestID, ChatIsChannel: isChannel}}
+}
+
+// Validate checks that the button has at most one action
+func (b KeyboardButton) Validate() error {
+	actions := 0
+	for _, set := range []bool{b.RequestUser != nil, b.RequestChat != nil, b.RequestContact, b.RequestLocation, b.RequestPoll != nil, b.WebApp != nil} {
+		if set {
+			actions++
+		}
+	}
+	if actions > 1 {
+		return fmt.Errorf("button %q must have at most one action, got %d", b.Text, actions)
+	}
+	return nil
+}
+
+// Validate checks all buttons of the keyboard and the input field placeholder
+func (m *ReplyKeyboardMarkup) Validate() error {
+	if utf8.RuneCountInString(m.InputFieldPlaceholder) > 64 {
+		return fmt.Errorf("input field placeholder is longer than 64 characters")
+	}
+	for _, row := range m.Keyboard {
+		for _, button := range row {
+			err := button.Validate()
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func replyRowWidth(buttons []KeyboardButton) int {
+	width := 0
+	for _, b := range buttons {
+		width += utf8.RuneCountInString(b.Text)
+	}
+	return width
+}
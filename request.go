@@ -0,0 +1,94 @@
+package tbot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Reserved request values, they are never sent
+const (
+	// jsonParams lists parameters holding JSON, they are embedded into JSON requests as is
+	jsonParams = "\x00json"
+	// encodeErrors holds errors of encoding parameters, the request fails with them
+	encodeErrors = "\x00errors"
+)
+
+// setJSON sets parameter to JSON encoding of value, nested objects make the request sent as JSON.
+// Encoding error is reported when the request is sent.
+func setJSON(v url.Values, name string, value interface{}) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		v.Add(encodeErrors, fmt.Sprintf("%s: %v", name, err))
+		return
+	}
+	setRawJSON(v, name, data)
+}
+
+func setRawJSON(v url.Values, name string, data []byte) {
+	v.Set(name, string(data))
+	for _, p := range v[jsonParams] {
+		if p == name {
+			return
+		}
+	}
+	v.Add(jsonParams, name)
+}
+
+/*
+encodeRequest returns JSON body of a request: fields of request, a struct with json tags which can be nil,
+and values set by options. Options override fields of the struct like they override positional parameters:
+
+	{"chat_id":-100123,"media":[{"type":"photo","media":"AgAD..."}],"disable_notification":"true"}
+*/
+func encodeRequest(request interface{}, values url.Values) ([]byte, error) {
+	if errs := values[encodeErrors]; len(errs) > 0 {
+		return nil, errors.New(strings.Join(errs, "; "))
+	}
+	body := map[string]json.RawMessage{}
+	if request != nil {
+		data, err := json.Marshal(request)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal(data, &body)
+		if err != nil {
+			return nil, fmt.Errorf("request must be encoded as JSON object: %v", err)
+		}
+	}
+	raw := map[string]bool{}
+	for _, name := range values[jsonParams] {
+		raw[name] = true
+	}
+	for name := range values {
+		if name == jsonParams {
+			continue
+		}
+		value := values.Get(name)
+		if raw[name] {
+			body[name] = json.RawMessage(value)
+			continue
+		}
+		body[name], _ = json.Marshal(value)
+	}
+	return json.Marshal(body)
+}
+
+// formValues returns values to send in a form, without reserved values
+func formValues(values url.Values) (url.Values, error) {
+	if errs := values[encodeErrors]; len(errs) > 0 {
+		return nil, errors.New(strings.Join(errs, "; "))
+	}
+	if values[jsonParams] == nil {
+		return values, nil
+	}
+	form := url.Values{}
+	for name, v := range values {
+		if name != jsonParams {
+			form[name] = v
+		}
+	}
+	return form, nil
+}
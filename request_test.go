@@ -0,0 +1,117 @@
+package tbot_test
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/yanzay/tbot/v2"
+	"github.com/yanzay/tbot/v2/tbottest"
+)
+
+func TestJSONRequest(t *testing.T) {
+	api := tbottest.NewServer(token)
+	defer api.Close()
+	api.Handle("sendMediaGroup", func(call tbottest.Call) (interface{}, *tbottest.APIError) {
+		return []interface{}{}, nil
+	})
+	c := api.Client()
+
+	_, err := c.SendMediaGroup(tbot.NewChatID(-100123), []tbot.InputMedia{
+		tbot.InputMediaPhoto{Type: "photo", Media: "AgAD1", Caption: "first"},
+		tbot.InputMediaPhoto{Type: "photo", Media: "AgAD2"},
+	}, tbot.OptDisableNotification)
+	if err != nil {
+		t.Fatalf("unable to send media group: %v", err)
+	}
+	call := api.CallsTo("sendMediaGroup")[0]
+	var body struct {
+		ChatID              int64  `json:"chat_id"`
+		DisableNotification string `json:"disable_notification"`
+		Media               []struct {
+			Type    string `json:"type"`
+			Media   string `json:"media"`
+			Caption string `json:"caption"`
+		} `json:"media"`
+	}
+	err = json.Unmarshal(call.Body, &body)
+	if err != nil {
+		t.Fatalf("request is not JSON: %v", err)
+	}
+	if body.ChatID != -100123 || body.DisableNotification != "true" {
+		t.Errorf("unexpected request %s", call.Body)
+	}
+	if len(body.Media) != 2 || body.Media[0].Media != "AgAD1" || body.Media[0].Caption != "first" {
+		t.Errorf("unexpected media %s", call.Body)
+	}
+	if call.Params.Get("chat_id") != "-100123" {
+		t.Errorf("unexpected chat_id param %q", call.Params.Get("chat_id"))
+	}
+
+	err = c.SetChatPermissions("1", &tbot.ChatPermissions{CanSendMessages: true})
+	if err != nil {
+		t.Fatalf("unable to set permissions: %v", err)
+	}
+	permissions := api.CallsTo("setChatPermissions")[0].Params.Get("permissions")
+	if permissions != `{"can_send_messages":true}` {
+		t.Errorf("unexpected permissions %s", permissions)
+	}
+}
+
+func TestJSONRequestOption(t *testing.T) {
+	api := tbottest.NewServer(token)
+	defer api.Close()
+	c := api.Client()
+
+	_, err := c.SendMessage("1", "choose", tbot.OptInlineKeyboardMarkup(&tbot.InlineKeyboardMarkup{
+		InlineKeyboard: [][]tbot.InlineKeyboardButton{{{Text: "yes", CallbackData: "y"}}},
+	}))
+	if err != nil {
+		t.Fatalf("unable to send message: %v", err)
+	}
+	call := api.CallsTo("sendMessage")[0]
+	var body struct {
+		Text        string                     `json:"text"`
+		ReplyMarkup *tbot.InlineKeyboardMarkup `json:"reply_markup"`
+	}
+	err = json.Unmarshal(call.Body, &body)
+	if err != nil {
+		t.Fatalf("request is not JSON: %v", err)
+	}
+	if body.Text != "choose" || body.ReplyMarkup == nil || body.ReplyMarkup.InlineKeyboard[0][0].CallbackData != "y" {
+		t.Errorf("unexpected request %s", call.Body)
+	}
+
+	_, err = c.SendMessage("1", "plain")
+	if err != nil {
+		t.Fatalf("unable to send message: %v", err)
+	}
+	if call := api.CallsTo("sendMessage")[1]; call.Body != nil || call.Params.Get("text") != "plain" {
+		t.Errorf("flat request is expected to be sent as form, got %+v", call)
+	}
+}
+
+func TestJSONRequestError(t *testing.T) {
+	api := tbottest.NewServer(token)
+	defer api.Close()
+	c := api.Client()
+
+	err := c.AnswerInlineQuery("q1", []tbot.InlineQueryResult{
+		tbot.InlineQueryResultLocation{Type: "location", ID: "1", Latitude: math.NaN(), Title: "nowhere"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "unable to encode answerInlineQuery request") {
+		t.Errorf("expected encoding error, got %v", err)
+	}
+
+	err = c.AddStickerToSet(1, "masks", "CAAD1", "😷", tbot.OptMaskPosition(&tbot.MaskPosition{
+		Point:  "eyes",
+		XShift: float32(math.NaN()),
+	}))
+	if err == nil || !strings.Contains(err.Error(), "mask_position") {
+		t.Errorf("expected option encoding error, got %v", err)
+	}
+	if len(api.Calls()) != 0 {
+		t.Errorf("requests with encoding errors must not be sent, got %v", api.Calls())
+	}
+}
@@ -1,12 +1,15 @@
 package tbot
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
 	"net/url"
 	"regexp"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -16,19 +19,38 @@ var (
 
 // Server will connect and serve all updates from Telegram
 type Server struct {
-	webhookURL    string
-	listenAddr    string
-	baseURL       string
-	httpClient    *http.Client
-	client        *Client
-	token         string
-	logger        Logger
-	stop          chan struct{}
-	updatesParams url.Values
-	bufferSize    int
-	nextOffset    int
-
-	callbackQueryMatcher map[string]func(*CallbackQuery)
+	webhookURL     string
+	listenAddr     string
+	allowedUpdates []string
+	baseURL        string
+	httpClient     *http.Client
+	pollHTTPClient *http.Client
+	uploadClient   *http.Client
+	transport      transportOptions
+	requestTimeout time.Duration
+	uploadTimeout  time.Duration
+	pollTimeout    time.Duration
+	client         *Client
+	token          string
+	logger         *libLogger
+	logOutput      StructuredLogger
+	logLevel       Level
+	localMode      bool
+	dryRun         *dryRun
+	stop           chan struct{}
+	stopOnce       sync.Once
+	ctx            context.Context
+	cancel         context.CancelFunc
+	updatesParams  url.Values
+	recorder       *UpdateRecorder
+	updateSource   UpdateSource
+	metrics        Metrics
+	tracer         Tracer
+	bufferSize     int
+	nextOffset     int
+
+	callbackQueryMatcher  map[string]func(*CallbackQuery)
+	callbackPrefixMatcher []callbackPrefixHandler
 
 	messageHandlers        []messageHandler
 	editMessageHandler     handlerFunc
@@ -41,6 +63,11 @@ type Server struct {
 	preCheckoutHandler     func(*PreCheckoutQuery)
 	pollHandler            func(*Poll)
 	pollAnswerHandler      func(*PollAnswer)
+	myChatMemberHandler    func(*ChatMemberUpdated)
+	chatMemberHandler      func(*ChatMemberUpdated)
+	chatJoinRequestHandler func(*ChatJoinRequest)
+	rawUpdateHandler       func(json.RawMessage)
+	pollErrorHandler       func(error)
 
 	middlewares []Middleware
 }
@@ -56,6 +83,11 @@ type ServerOption func(*Server)
 
 type handlerFunc func(*Message)
 
+type callbackPrefixHandler struct {
+	prefix string
+	f      func(*CallbackQuery)
+}
+
 type messageHandler struct {
 	rx *regexp.Regexp
 	f  handlerFunc
@@ -67,13 +99,36 @@ New creates new Server. Available options:
 	WithWebhook(url, addr string)
 	WithHTTPClient(client *http.Client)
 	WithBaseURL(baseURL string)
+	WithAllowedUpdates(updates ...string)
+	WithUpdateRecorder(recorder *UpdateRecorder)
+	WithUpdateSource(source UpdateSource)
+	WithMetrics(metrics Metrics)
+	WithTracer(tracer Tracer)
+	WithLogger(logger Logger)
+	WithStructuredLogger(logger StructuredLogger)
+	WithLogLevel(level Level)
+	WithLocalMode()
+	WithRequestTimeout(timeout time.Duration)
+	WithUploadTimeout(timeout time.Duration)
+	WithPollTimeout(timeout time.Duration)
+	WithPollingHTTPClient(client *http.Client)
+	WithUploadHTTPClient(client *http.Client)
+	WithProxy(proxy *url.URL)
+	WithConnectionPool(maxIdle, maxIdlePerHost int, idleTimeout, keepAlive time.Duration)
+	WithDryRun()
+	WithDryRunFixture(method string, result interface{})
 */
 func New(token string, options ...ServerOption) *Server {
 	s := &Server{
-		httpClient: http.DefaultClient,
-		token:      token,
-		logger:     nopLogger{},
-		baseURL:    apiBaseURL,
+		token:     token,
+		logOutput: nopLogger{},
+		logLevel:  LevelInfo,
+		metrics:   nopMetrics{},
+		tracer:    nopTracer{},
+		baseURL:   apiBaseURL,
+
+		requestTimeout: defaultRequestTimeout,
+		pollTimeout:    defaultPollTimeout,
 
 		editMessageHandler:     func(*Message) {},
 		channelPostHandler:     func(*Message) {},
@@ -85,16 +140,31 @@ func New(token string, options ...ServerOption) *Server {
 		preCheckoutHandler:     func(*PreCheckoutQuery) {},
 		pollHandler:            func(*Poll) {},
 		pollAnswerHandler:      func(*PollAnswer) {},
+		myChatMemberHandler:    func(*ChatMemberUpdated) {},
+		chatMemberHandler:      func(*ChatMemberUpdated) {},
+		chatJoinRequestHandler: func(*ChatJoinRequest) {},
+		rawUpdateHandler:       func(json.RawMessage) {},
 
 		callbackQueryMatcher: make(map[string]func(*CallbackQuery)),
 
 		stop: make(chan struct{}),
 	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
 	for _, opt := range options {
 		opt(s)
 	}
+	s.logger = newLibLogger(s.logOutput, s.logLevel, token)
 	// bot, err :=  tgbotapi.NewBotAPIWithClient(token, s.httpClient)
+	s.setupHTTPClients()
 	s.client = NewClient(token, s.httpClient, s.baseURL)
+	s.client.uploadClient = s.uploadClient
+	s.client.timeout = s.requestTimeout
+	s.client.uploadTimeout = s.uploadTimeout
+	s.client.logger = s.logger
+	s.client.localMode = s.localMode
+	s.client.dryRun = s.dryRun
+	s.client.metrics = s.metrics
+	s.client.tracer = s.tracer
 	return s
 }
 
@@ -115,17 +185,91 @@ func WithBaseURL(baseURL string) ServerOption {
 	}
 }
 
+// WithLocalMode makes server work with a self-hosted Bot API server started with --local,
+// use it together with WithBaseURL. Files are passed to the server as file:// URIs instead of uploading,
+// upload size limit is raised to LocalMaxUploadSize, GetFile returns absolute paths
+// which DownloadFile and FileHandler read from the disk.
+// Use Client.LogOut and Client.Close to move the bot between servers.
+func WithLocalMode() ServerOption {
+	return func(s *Server) {
+		s.localMode = true
+	}
+}
+
 // WithHTTPClient sets custom http client for server.
+// It's used for all requests unless WithPollingHTTPClient or WithUploadHTTPClient are set,
+// WithProxy and WithConnectionPool don't change it.
 func WithHTTPClient(client *http.Client) ServerOption {
 	return func(s *Server) {
 		s.httpClient = client
 	}
 }
 
-// WithLogger sets logger for tbot
+// WithAllowedUpdates sets the list of update types the bot wants to receive,
+// e.g. WithAllowedUpdates("message", "callback_query", "chat_member").
+// Telegram doesn't send chat_member updates unless they are listed explicitly.
+func WithAllowedUpdates(updates ...string) ServerOption {
+	return func(s *Server) {
+		s.allowedUpdates = updates
+	}
+}
+
+// WithUpdateRecorder records every update received with long polling or webhook,
+// e.g. WithUpdateRecorder(tbot.NewUpdateRecorder(file, tbot.RedactFields("phone_number")))
+func WithUpdateRecorder(recorder *UpdateRecorder) ServerOption {
+	return func(s *Server) {
+		s.recorder = recorder
+	}
+}
+
+// WithUpdateSource makes server read updates from the source instead of Telegram,
+// e.g. WithUpdateSource(tbot.NewReplay(file, 1)) to replay a recording
+func WithUpdateSource(source UpdateSource) ServerOption {
+	return func(s *Server) {
+		s.updateSource = source
+	}
+}
+
+// WithMetrics sets instrumentation for Bot API calls, updates and handlers
+func WithMetrics(metrics Metrics) ServerOption {
+	return func(s *Server) {
+		s.metrics = metrics
+	}
+}
+
+// WithTracer sets tracing hooks: a span for every update and child spans for Bot API calls
+// made with the update context, see Client.WithContext
+func WithTracer(tracer Tracer) ServerOption {
+	return func(s *Server) {
+		s.tracer = tracer
+	}
+}
+
+// WithLogger sets logger for tbot, log fields are appended to messages as key=value.
+// Loggers implementing StructuredLogger receive fields as is.
 func WithLogger(logger Logger) ServerOption {
 	return func(s *Server) {
-		s.logger = logger
+		if sl, ok := logger.(StructuredLogger); ok {
+			s.logOutput = sl
+			return
+		}
+		s.logOutput = printfLogger{l: logger}
+	}
+}
+
+// WithStructuredLogger sets logger receiving key/value fields, e.g.
+// WithStructuredLogger(tbot.NewSlogLogger(slog.Default())) or WithStructuredLogger(tbot.NewZapLogger(z.Sugar()))
+func WithStructuredLogger(logger StructuredLogger) ServerOption {
+	return func(s *Server) {
+		s.logOutput = logger
+	}
+}
+
+// WithLogLevel sets minimum level of library logs, LevelInfo by default.
+// Every update and Bot API call is logged with LevelDebug.
+func WithLogLevel(level Level) ServerOption {
+	return func(s *Server) {
+		s.logLevel = level
 	}
 }
 
@@ -141,48 +285,86 @@ func (s *Server) Start() error {
 	}
 	updates, err := s.getUpdates()
 	if err != nil {
-		return err
+		return redactToken(err, s.token)
 	}
 	for {
 		select {
-		case update := <-updates:
-			handleUpdate := func(update *Update) {
-				switch {
-				case update.Message != nil:
-					s.handleMessage(update.Message)
-				case update.EditedMessage != nil:
-					s.editMessageHandler(update.EditedMessage)
-				case update.ChannelPost != nil:
-					s.channelPostHandler(update.ChannelPost)
-				case update.EditedChannelPost != nil:
-					s.editChannelPostHandler(update.EditedChannelPost)
-				case update.InlineQuery != nil:
-					s.inlineQueryHandler(update.InlineQuery)
-				case update.ChosenInlineResult != nil:
-					s.inlineResultHandler(update.ChosenInlineResult)
-				case update.CallbackQuery != nil:
-					s.callbackHandler(update.CallbackQuery)
-				case update.ShippingQuery != nil:
-					s.shippingHandler(update.ShippingQuery)
-				case update.PreCheckoutQuery != nil:
-					s.preCheckoutHandler(update.PreCheckoutQuery)
-				case update.Poll != nil:
-					s.pollHandler(update.Poll)
-				case update.PollAnswer != nil:
-					s.pollAnswerHandler(update.PollAnswer)
-				}
+		case update, ok := <-updates:
+			if !ok {
+				return nil
 			}
-			var f = handleUpdate
-			for i := len(s.middlewares) - 1; i >= 0; i-- {
-				f = s.middlewares[i](f)
-			}
-			go f(update)
+			go s.ProcessUpdate(update)
 		case <-s.stop:
 			return nil
 		}
 	}
 }
 
+// ProcessUpdate runs the update through middlewares and handlers synchronously.
+// Start calls it for every incoming update, it's also useful for testing.
+func (s *Server) ProcessUpdate(update *Update) {
+	kind := update.Kind()
+	s.metrics.ObserveUpdate(kind)
+	s.metrics.HandlersInFlight(1)
+	start := time.Now()
+	ctx, span := s.tracer.StartUpdate(update.Context(), update)
+	span.SetAttribute("tbot.update_id", update.UpdateID)
+	span.SetAttribute("tbot.update_type", kind)
+	update.ctx = ctx
+	chatID := string(update.chatID())
+	if chatID != "" {
+		span.SetAttribute("tbot.chat_id", chatID)
+	}
+	defer func() {
+		span.End()
+		latency := time.Since(start)
+		s.metrics.ObserveHandler(kind, latency)
+		s.metrics.HandlersInFlight(-1)
+		s.logger.debug("update processed", "update_id", update.UpdateID, "update_type", kind, "chat_id", chatID, "latency", latency)
+	}()
+	var f UpdateHandler = s.handleUpdate
+	for i := len(s.middlewares) - 1; i >= 0; i-- {
+		f = s.middlewares[i](f)
+	}
+	f(update)
+}
+
+func (s *Server) handleUpdate(update *Update) {
+	update.propagateContext()
+	switch {
+	case update.Message != nil:
+		s.handleMessage(update.Message)
+	case update.EditedMessage != nil:
+		s.editMessageHandler(update.EditedMessage)
+	case update.ChannelPost != nil:
+		s.channelPostHandler(update.ChannelPost)
+	case update.EditedChannelPost != nil:
+		s.editChannelPostHandler(update.EditedChannelPost)
+	case update.InlineQuery != nil:
+		s.inlineQueryHandler(update.InlineQuery)
+	case update.ChosenInlineResult != nil:
+		s.inlineResultHandler(update.ChosenInlineResult)
+	case update.CallbackQuery != nil:
+		s.handleCallback(update.CallbackQuery)
+	case update.ShippingQuery != nil:
+		s.shippingHandler(update.ShippingQuery)
+	case update.PreCheckoutQuery != nil:
+		s.preCheckoutHandler(update.PreCheckoutQuery)
+	case update.Poll != nil:
+		s.pollHandler(update.Poll)
+	case update.PollAnswer != nil:
+		s.pollAnswerHandler(update.PollAnswer)
+	case update.MyChatMember != nil:
+		s.myChatMemberHandler(update.MyChatMember)
+	case update.ChatMember != nil:
+		s.chatMemberHandler(update.ChatMember)
+	case update.ChatJoinRequest != nil:
+		s.chatJoinRequestHandler(update.ChatJoinRequest)
+	default:
+		s.rawUpdateHandler(update.Raw())
+	}
+}
+
 // Client returns Telegram API Client
 func (s *Server) Client() *Client {
 	return s.client
@@ -190,19 +372,30 @@ func (s *Server) Client() *Client {
 
 // Stop listening for updates
 func (s *Server) Stop() {
-	s.stop <- struct{}{}
+	s.cancel()
+	s.stopOnce.Do(func() {
+		close(s.stop)
+	})
 }
 
-func (s *Server) getUpdates() (chan *Update, error) {
+func (s *Server) getUpdates() (<-chan *Update, error) {
+	if s.updateSource != nil {
+		if replay, ok := s.updateSource.(*Replay); ok {
+			return replay.serverReplay(s).Updates()
+		}
+		return s.updateSource.Updates()
+	}
 	if s.webhookURL != "" && s.listenAddr != "" {
+		s.logger.info("starting webhook", "url", s.webhookURL, "addr", s.listenAddr)
 		return s.listenUpdates()
 	}
 	s.client.deleteWebhook()
+	s.logger.info("starting long polling")
 	return s.longPoolUpdates()
 }
 
 func (s *Server) listenUpdates() (chan *Update, error) {
-	err := s.client.setWebhook(s.webhookURL)
+	err := s.client.setWebhook(s.webhookURL, s.allowedUpdates, "")
 	if err != nil {
 		return nil, fmt.Errorf("unable to set webhook: %v", err)
 	}
@@ -211,9 +404,10 @@ func (s *Server) listenUpdates() (chan *Update, error) {
 		up := &Update{}
 		err := json.NewDecoder(r.Body).Decode(up)
 		if err != nil {
-			s.logger.Errorf("unable to decode update: %v", err)
+			s.logger.error("unable to decode update", "error", err)
 			return
 		}
+		s.received(up)
 		updates <- up
 	}
 	l, err := net.Listen("tcp", s.listenAddr)
@@ -225,7 +419,6 @@ func (s *Server) listenUpdates() (chan *Update, error) {
 }
 
 func (s *Server) longPoolUpdates() (chan *Update, error) {
-	s.logger.Debugf("fetching updates...")
 	endpoint := fmt.Sprintf("%s/bot%s/%s", s.baseURL, s.token, "getUpdates")
 	req, err := http.NewRequest("GET", endpoint, nil)
 	if err != nil {
@@ -235,48 +428,98 @@ func (s *Server) longPoolUpdates() (chan *Update, error) {
 	if params == nil {
 		params = url.Values{}
 	}
-	params.Set("timeout", fmt.Sprint(3600))
+	params.Set("timeout", fmt.Sprint(int(s.pollTimeout/time.Second)))
+	if len(s.allowedUpdates) > 0 {
+		allowed, err := json.Marshal(s.allowedUpdates)
+		if err != nil {
+			return nil, err
+		}
+		params.Set("allowed_updates", string(allowed))
+	}
 	req.URL.RawQuery = params.Encode()
 	updates := make(chan *Update, s.bufferSize)
 	go func() {
+		defer close(updates)
 		for {
 			params.Set("offset", fmt.Sprint(s.nextOffset))
 			req.URL.RawQuery = params.Encode()
-			resp, err := s.httpClient.Do(req)
-			if err != nil {
-				s.logger.Errorf("unable to perform request: %v", err)
-				time.Sleep(1 * time.Second)
-				continue
+			result, err := s.fetchUpdates(req)
+			if s.ctx.Err() != nil {
+				return
 			}
-			var updatesResp *struct {
-				OK          bool      `json:"ok"`
-				Result      []*Update `json:"result"`
-				Description string    `json:"description"`
-			}
-			err = json.NewDecoder(resp.Body).Decode(&updatesResp)
 			if err != nil {
-				s.logger.Errorf("unable to decode response: %v", err)
-				time.Sleep(1 * time.Second)
+				s.pollFailed(err)
 				continue
 			}
-			err = resp.Body.Close()
-			if err != nil {
-				s.logger.Errorf("unable to close response body: %v", err)
-			}
-			if !updatesResp.OK {
-				s.logger.Errorf("updates query fail: %s", updatesResp.Description)
-				time.Sleep(1 * time.Second)
-				continue
-			}
-			for _, up := range updatesResp.Result {
+			for _, up := range result {
 				s.nextOffset = up.UpdateID + 1
-				updates <- up
+				s.received(up)
+				select {
+				case updates <- up:
+				case <-s.ctx.Done():
+					return
+				}
 			}
 		}
 	}()
 	return updates, nil
 }
 
+// fetchUpdates makes one long polling request, it fails if there is no response
+// in pollTimeoutGrace after the long polling timeout
+func (s *Server) fetchUpdates(req *http.Request) ([]*Update, error) {
+	ctx, cancel := context.WithTimeout(s.ctx, s.pollTimeout+pollTimeoutGrace)
+	defer cancel()
+	resp, err := s.pollHTTPClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("unable to get updates: %v", err)
+	}
+	defer resp.Body.Close()
+	var updatesResp *struct {
+		OK          bool      `json:"ok"`
+		Result      []*Update `json:"result"`
+		Description string    `json:"description"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&updatesResp)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode updates: %v", err)
+	}
+	if !updatesResp.OK {
+		return nil, fmt.Errorf("updates query fail: %s", updatesResp.Description)
+	}
+	return updatesResp.Result, nil
+}
+
+// pollFailed reports long polling error and waits before the next attempt
+func (s *Server) pollFailed(err error) {
+	s.logger.error("long polling failed", "method", "getUpdates", "error", err)
+	if s.pollErrorHandler != nil {
+		s.pollErrorHandler(err)
+	}
+	select {
+	case <-time.After(time.Second):
+	case <-s.ctx.Done():
+	}
+}
+
+// received is called for every update coming from Telegram
+func (s *Server) received(up *Update) {
+	if date, ok := up.date(); ok {
+		s.metrics.ObservePollLag(time.Since(date))
+	}
+	s.recordUpdate(up)
+}
+
+func (s *Server) recordUpdate(up *Update) {
+	if s.recorder == nil {
+		return
+	}
+	err := s.recorder.Record(up)
+	if err != nil {
+		s.logger.error("unable to record update", "update_id", up.UpdateID, "error", err)
+	}
+}
+
 // HandleMessage sets handler for incoming messages
 func (s *Server) HandleMessage(pattern string, handler func(*Message)) {
 	rx := regexp.MustCompile(pattern)
@@ -311,23 +554,39 @@ func (s *Server) HandleInlineResult(handler func(*ChosenInlineResult)) {
 // HandleCallback set default callback handler for inline buttons
 // Use RegisterCallbackHandler if you want to define handlers for specific callback query data
 func (s *Server) HandleCallback(defaultCallbackHandler func(*CallbackQuery)) {
-	generalCallbackHandler := func(cq *CallbackQuery) {
-		handler, ok := s.callbackQueryMatcher[cq.Data]
-		if !ok {
-			handler = defaultCallbackHandler
-		}
-		handler(cq)
-	}
-	s.callbackHandler = generalCallbackHandler
+	s.callbackHandler = defaultCallbackHandler
 }
 
-// Define callback handlers per key, and the key is actually the cq.Data we attach to our buttons
-//
-// Note: It only works if you call HandleCallback along this function.
+// RegisterCallbackHandler defines callback handlers per key, and the key is actually the cq.Data we attach to our buttons.
+// Queries without registered handler go to the default handler set by HandleCallback.
 func (s *Server) RegisterCallbackHandler(key string, handler func(*CallbackQuery)) {
 	s.callbackQueryMatcher[key] = handler
 }
 
+// RegisterCallbackPrefixHandler defines callback handler for all cq.Data starting with prefix.
+// Handlers registered with RegisterCallbackHandler take precedence, the longest matching prefix wins.
+func (s *Server) RegisterCallbackPrefixHandler(prefix string, handler func(*CallbackQuery)) {
+	s.callbackPrefixMatcher = append(s.callbackPrefixMatcher, callbackPrefixHandler{prefix: prefix, f: handler})
+}
+
+func (s *Server) handleCallback(cq *CallbackQuery) {
+	if handler, ok := s.callbackQueryMatcher[cq.Data]; ok {
+		handler(cq)
+		return
+	}
+	var match *callbackPrefixHandler
+	for i, h := range s.callbackPrefixMatcher {
+		if strings.HasPrefix(cq.Data, h.prefix) && (match == nil || len(h.prefix) > len(match.prefix)) {
+			match = &s.callbackPrefixMatcher[i]
+		}
+	}
+	if match != nil {
+		match.f(cq)
+		return
+	}
+	s.callbackHandler(cq)
+}
+
 // HandleShipping set handler for shipping queries
 func (s *Server) HandleShipping(handler func(*ShippingQuery)) {
 	s.shippingHandler = handler
@@ -348,6 +607,30 @@ func (s *Server) HandlePollAnswer(handler func(*PollAnswer)) {
 	s.pollAnswerHandler = handler
 }
 
+// HandleMyChatMember set handler for changes of the bot's own member status in a chat
+func (s *Server) HandleMyChatMember(handler func(*ChatMemberUpdated)) {
+	s.myChatMemberHandler = handler
+}
+
+// HandleChatMember set handler for changes of a chat member status.
+// The bot must be an administrator in the chat and "chat_member" must be in the allowed updates,
+// see WithAllowedUpdates
+func (s *Server) HandleChatMember(handler func(*ChatMemberUpdated)) {
+	s.chatMemberHandler = handler
+}
+
+// HandleChatJoinRequest set handler for requests to join the chat
+func (s *Server) HandleChatJoinRequest(handler func(*ChatJoinRequest)) {
+	s.chatJoinRequestHandler = handler
+}
+
+// HandleRawUpdate set catch-all handler for updates of kinds not modelled by the library.
+// Handler receives the original update JSON, so newer Bot API features can be used
+// before they are supported by tbot.
+func (s *Server) HandleRawUpdate(handler func(json.RawMessage)) {
+	s.rawUpdateHandler = handler
+}
+
 func (s *Server) handleMessage(msg *Message) {
 	for _, handler := range s.messageHandlers {
 		if handler.rx.MatchString(msg.Text) {
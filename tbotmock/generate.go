@@ -0,0 +1,3 @@
+package tbotmock
+
+//go:generate go run ../internal/genmock -dir .. -interface API -out mock_gen.go
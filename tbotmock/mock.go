@@ -0,0 +1,65 @@
+/*
+Package tbotmock provides Client, a recording mock of tbot.API for unit-testing handlers
+that depend on tbot.API or capability interfaces like tbot.Messaging instead of *tbot.Client:
+
+	c := &tbotmock.Client{}
+	c.SendMessageFunc = func(chatID tbot.ChatID, text string, opts ...tbot.SendMessageOption) (*tbot.Message, error) {
+		return &tbot.Message{MessageID: 42}, nil
+	}
+	handler := newGreeter(c)
+	handler.handle(&tbot.Message{Chat: tbot.Chat{ID: "1"}})
+	calls := c.CallsTo("SendMessage")
+
+Methods without a <Method>Func return zero results, pointers to empty values instead of nil pointers.
+*/
+package tbotmock
+
+import (
+	"net/url"
+	"sync"
+)
+
+// Call is a recorded method call
+type Call struct {
+	Method string
+	Args   []interface{} // arguments except options
+	Params url.Values    // request parameters set by options
+}
+
+type recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+func (r *recorder) record(method string, params url.Values, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, Call{Method: method, Args: args, Params: params})
+}
+
+// Calls returns all recorded calls in order
+func (r *recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	calls := make([]Call, len(r.calls))
+	copy(calls, r.calls)
+	return calls
+}
+
+// CallsTo returns recorded calls of the method, e.g. "SendMessage"
+func (r *recorder) CallsTo(method string) []Call {
+	var calls []Call
+	for _, call := range r.Calls() {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Reset forgets recorded calls
+func (r *recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = nil
+}
@@ -0,0 +1,1387 @@
+// Code generated by internal/genmock from tbot.API; DO NOT EDIT.
+
+package tbotmock
+
+import (
+	"context"
+	"io"
+	"net/url"
+
+	"github.com/yanzay/tbot/v2"
+)
+
+// Client is a recording mock of tbot.API
+type Client struct {
+	recorder
+
+	SendMessageFunc                     func(chatID tbot.ChatID, text string, opts ...tbot.SendMessageOption) (*tbot.Message, error)
+	SendLongMessageFunc                 func(chatID tbot.ChatID, text string, opts ...tbot.SendMessageOption) ([]*tbot.Message, error)
+	ForwardMessageFunc                  func(chatID, fromChatID tbot.ChatID, messageID int, opts ...tbot.ForwardMessageOption) (*tbot.Message, error)
+	ForwardMessagesFunc                 func(chatID, fromChatID tbot.ChatID, messageIDs []int, opts ...tbot.ForwardMessagesOption) ([]*tbot.MessageID, error)
+	ForwardMessagesBulkFunc             func(chatID, fromChatID tbot.ChatID, messageIDs []int, opts ...tbot.ForwardMessagesOption) ([]tbot.BulkResult, error)
+	CopyMessageFunc                     func(chatID, fromChatID tbot.ChatID, messageID int, opts ...tbot.CopyMessageOption) (*tbot.MessageID, error)
+	CopyMessagesFunc                    func(chatID, fromChatID tbot.ChatID, messageIDs []int, opts ...tbot.CopyMessagesOption) ([]*tbot.MessageID, error)
+	CopyMessagesBulkFunc                func(chatID, fromChatID tbot.ChatID, messageIDs []int, opts ...tbot.CopyMessagesOption) ([]tbot.BulkResult, error)
+	SendAudioFunc                       func(chatID tbot.ChatID, fileID string, opts ...tbot.SendAudioOption) (*tbot.Message, error)
+	SendAudioFileFunc                   func(chatID tbot.ChatID, filename string, opts ...tbot.SendAudioOption) (*tbot.Message, error)
+	SendPhotoFunc                       func(chatID tbot.ChatID, fileID string, opts ...tbot.SendPhotoOption) (*tbot.Message, error)
+	SendPhotoFileFunc                   func(chatID tbot.ChatID, filename string, opts ...tbot.SendPhotoOption) (*tbot.Message, error)
+	SendDocumentFunc                    func(chatID tbot.ChatID, fileID string, opts ...tbot.SendDocumentOption) (*tbot.Message, error)
+	SendDocumentFileFunc                func(chatID tbot.ChatID, filename string, opts ...tbot.SendDocumentOption) (*tbot.Message, error)
+	SendVideoFunc                       func(chatID tbot.ChatID, fileID string, opts ...tbot.SendVideoOption) (*tbot.Message, error)
+	SendVideoFileFunc                   func(chatID tbot.ChatID, filename string, opts ...tbot.SendVideoOption) (*tbot.Message, error)
+	SendAnimationFunc                   func(chatID tbot.ChatID, fileID string, opts ...tbot.SendAnimationOption) (*tbot.Message, error)
+	SendAnimationFileFunc               func(chatID tbot.ChatID, filename string, opts ...tbot.SendAnimationOption) (*tbot.Message, error)
+	SendVoiceFunc                       func(chatID tbot.ChatID, fileID string, opts ...tbot.SendVoiceOption) (*tbot.Message, error)
+	SendVoiceFileFunc                   func(chatID tbot.ChatID, filename string, opts ...tbot.SendVoiceOption) (*tbot.Message, error)
+	SendVideoNoteFunc                   func(chatID tbot.ChatID, fileID string, opts ...tbot.SendVideoNoteOption) (*tbot.Message, error)
+	SendVideoNoteFileFunc               func(chatID tbot.ChatID, filename string, opts ...tbot.SendVideoNoteOption) (*tbot.Message, error)
+	SendMediaGroupFunc                  func(chatID tbot.ChatID, media []tbot.InputMedia, opts ...tbot.SendMediaGroupOption) ([]*tbot.Message, error)
+	SendLocationFunc                    func(chatID tbot.ChatID, latitude, longitude float64, opts ...tbot.SendLocationOption) (*tbot.Message, error)
+	EditMessageLiveLocationFunc         func(chatID tbot.ChatID, messageID int, latitude, longitude float64, opts ...tbot.LiveLocationOption) (*tbot.Message, error)
+	StopMessageLiveLocationFunc         func(chatID tbot.ChatID, messageID int, opts ...tbot.LiveLocationOption) (*tbot.Message, error)
+	SendVenueFunc                       func(chatID tbot.ChatID, latitude, longitude float64, title, address string, opts ...tbot.SendVenueOption) (*tbot.Message, error)
+	SendContactFunc                     func(chatID tbot.ChatID, phoneNumber, firstName string, opts ...tbot.SendContactOption) (*tbot.Message, error)
+	SendStickerFunc                     func(chatID tbot.ChatID, fileID string, opts ...tbot.SendStickerOption) (*tbot.Message, error)
+	SendStickerFileFunc                 func(chatID tbot.ChatID, filename string, opts ...tbot.SendStickerOption) (*tbot.Message, error)
+	SendPollFunc                        func(chatID tbot.ChatID, question string, options []string, opts ...tbot.SendPollOption) (*tbot.Message, error)
+	StopPollFunc                        func(chatID tbot.ChatID, messageID int, opts ...tbot.StopPollOption) (*tbot.Poll, error)
+	SendDiceFunc                        func(chatID tbot.ChatID, emoji string, opts ...tbot.SendDiceOption) (*tbot.Dice, error)
+	SendGameFunc                        func(chatID tbot.ChatID, gameShortName string, opts ...tbot.SendGameOption) (*tbot.Message, error)
+	SetGameScoreFunc                    func(chatID tbot.ChatID, messageID int, userID int64, score int, opts ...tbot.SetGameScoreOption) (*tbot.Message, error)
+	GetGameHighScoresFunc               func(chatID tbot.ChatID, messageID int, userID int64) ([]*tbot.GameHighScore, error)
+	SendChatActionFunc                  func(chatID tbot.ChatID, action tbot.ChatAction) error
+	EditMessageTextFunc                 func(chatID tbot.ChatID, messageID int, text string, opts ...tbot.EditMessageTextOption) (*tbot.Message, error)
+	EditMessageCaptionFunc              func(chatID tbot.ChatID, messageID int, caption string, opts ...tbot.EditMessageCaptionOption) (*tbot.Message, error)
+	EditMessageReplyMarkupFunc          func(chatID tbot.ChatID, messageID int, opts ...tbot.EditMessageReplyMarkupOption) (*tbot.Message, error)
+	DeleteMessageFunc                   func(chatID tbot.ChatID, messageID int) error
+	DeleteMessagesFunc                  func(chatID tbot.ChatID, messageIDs []int) error
+	DeleteMessagesBulkFunc              func(chatID tbot.ChatID, messageIDs []int) ([]tbot.BulkResult, error)
+	GetChatFunc                         func(chatID tbot.ChatID) (*tbot.Chat, error)
+	GetChatAdministratorsFunc           func(chatID tbot.ChatID) ([]*tbot.ChatMember, error)
+	GetChatMembersCountFunc             func(chatID tbot.ChatID) (int, error)
+	GetChatMemberCountFunc              func(chatID tbot.ChatID) (int, error)
+	GetChatMemberFunc                   func(chatID tbot.ChatID, userID int64) (*tbot.ChatMember, error)
+	KickChatMemberFunc                  func(chatID tbot.ChatID, userID int64, opts ...tbot.KickChatMemberOption) error
+	BanChatMemberFunc                   func(chatID tbot.ChatID, userID int64, opts ...tbot.BanChatMemberOption) error
+	UnbanChatMemberFunc                 func(chatID tbot.ChatID, userID int64) error
+	RestrictChatMemberFunc              func(chatID tbot.ChatID, userID int64, perm *tbot.ChatPermissions, opts ...tbot.RestrictChatMemberOption) error
+	PromoteChatMemberFunc               func(chatID tbot.ChatID, userID int64, p *tbot.Promotions) error
+	SetChatAdministratorCustomTitleFunc func(chatID tbot.ChatID, userID int64, customTitle string) error
+	SetChatPermissionsFunc              func(chatID tbot.ChatID, permissions *tbot.ChatPermissions) error
+	BanChatSenderChatFunc               func(chatID tbot.ChatID, senderChatID int64) error
+	UnbanChatSenderChatFunc             func(chatID tbot.ChatID, senderChatID int64) error
+	ApproveChatJoinRequestFunc          func(chatID tbot.ChatID, userID int64) error
+	DeclineChatJoinRequestFunc          func(chatID tbot.ChatID, userID int64) error
+	ExportChatInviteLinkFunc            func(chatID tbot.ChatID) (string, error)
+	SetChatPhotoFunc                    func(chatID tbot.ChatID, filename string) error
+	DeleteChatPhotoFunc                 func(chatID tbot.ChatID) error
+	SetChatTitleFunc                    func(chatID tbot.ChatID, title string) error
+	SetChatDescriptionFunc              func(chatID tbot.ChatID, description string) error
+	PinChatMessageFunc                  func(chatID tbot.ChatID, messageID int, opts ...tbot.PinChatMessageOption) error
+	UnpinChatMessageFunc                func(chatID tbot.ChatID) error
+	UnpinAllChatMessagesFunc            func(chatID tbot.ChatID) error
+	SetChatStickerSetFunc               func(chatID tbot.ChatID, stickerSetName string) error
+	DeleteChatStickerSetFunc            func(chatID tbot.ChatID) error
+	LeaveChatFunc                       func(chatID tbot.ChatID) error
+	GetForumTopicIconStickersFunc       func() ([]*tbot.Sticker, error)
+	CreateForumTopicFunc                func(chatID tbot.ChatID, name string, opts ...tbot.CreateForumTopicOption) (*tbot.ForumTopic, error)
+	EditForumTopicFunc                  func(chatID tbot.ChatID, messageThreadID int, opts ...tbot.EditForumTopicOption) error
+	CloseForumTopicFunc                 func(chatID tbot.ChatID, messageThreadID int) error
+	ReopenForumTopicFunc                func(chatID tbot.ChatID, messageThreadID int) error
+	DeleteForumTopicFunc                func(chatID tbot.ChatID, messageThreadID int) error
+	UnpinAllForumTopicMessagesFunc      func(chatID tbot.ChatID, messageThreadID int) error
+	GetFileFunc                         func(fileID string) (*tbot.File, error)
+	FileURLFunc                         func(file *tbot.File) string
+	DownloadFileFunc                    func(fileID string) (io.ReadCloser, error)
+	GetUserProfilePhotosFunc            func(userID int64, opts ...tbot.GetUserProfilePhotosOption) (*tbot.UserProfilePhotos, error)
+	GetStickerSetFunc                   func(name string) (*tbot.StickerSet, error)
+	UploadStickerFileFunc               func(userID int64, filename string) (*tbot.File, error)
+	CreateNewStickerSetFunc             func(userID int64, name, title, fileID, emojis string, opts ...tbot.CreateNewStickerSetOption) error
+	CreateNewStickerSetFileFunc         func(userID int64, name, title, stickerFilename, emojis string, opts ...tbot.CreateNewStickerSetOption) error
+	AddStickerToSetFunc                 func(userID int64, name, fileID, emojis string, opts ...tbot.AddStickerToSetOption) error
+	AddStickerToSetFileFunc             func(userID int64, name, filename, emojis string, opts ...tbot.AddStickerToSetOption) error
+	SetStickerPositionInSetFunc         func(fileID string, pos int) error
+	DeleteStickerFromSetFunc            func(fileID string) error
+	SetStickerSetThumbFunc              func(userID int64, name, thumb string) error
+	SetStickerSetThumbFileFunc          func(userID int64, name, thumbnailFilename string) error
+	AnswerCallbackQueryFunc             func(callbackQueryID string, opts ...tbot.AnswerCallbackQueryOption) error
+	AnswerInlineQueryFunc               func(inlineQueryID string, results []tbot.InlineQueryResult, opts ...tbot.AnswerInlineQueryOption) error
+	EditInlineMessageTextFunc           func(inlineMessageID, text string, opts ...tbot.EditMessageTextOption) error
+	EditInlineMessageCaptionFunc        func(inlineMessageID, caption string, opts ...tbot.EditMessageCaptionOption) error
+	EditInlineMessageReplyMarkupFunc    func(inlineMessageID string, opts ...tbot.EditMessageReplyMarkupOption) error
+	EditInlineMessageLiveLocationFunc   func(inlineMessageID string, latitude, longitude float64, opts ...tbot.LiveLocationOption) error
+	StopInlineMessageLiveLocationFunc   func(inlineMessageID string, opts ...tbot.LiveLocationOption) error
+	SetInlineGameScoreFunc              func(inlineMessageID string, userID int64, score int, opts ...tbot.SetGameScoreOption) error
+	GetInlineGameHighScoresFunc         func(inlineMessageID string, userID int64) ([]*tbot.GameHighScore, error)
+	SendInvoiceFunc                     func(chatID tbot.ChatID, payload, providerToken string, invoice *tbot.Invoice, prices []tbot.LabeledPrice, opts ...tbot.SendInvoiceOption) (*tbot.Message, error)
+	AnswerShippingQueryFunc             func(shippingQueryID string, ok bool, opts ...tbot.AnswerShippingQueryOption) error
+	AnswerPreCheckoutQueryFunc          func(preCheckoutQueryID string, ok bool, opts ...tbot.AnswerPreCheckoutQueryOption) error
+	GetMeFunc                           func() (*tbot.User, error)
+	GetMyCommandsFunc                   func() (*[]tbot.BotCommand, error)
+	SetMyCommandsFunc                   func(commands []tbot.BotCommand) error
+	SetPassportDataErrorsFunc           func(userID int64, errors []tbot.PassportElementError) error
+	CallFunc                            func(ctx context.Context, method string, params interface{}, result interface{}) error
+}
+
+var _ tbot.API = (*Client)(nil)
+
+// SendMessage records the call and calls SendMessageFunc if it is set
+func (c *Client) SendMessage(chatID tbot.ChatID, text string, opts ...tbot.SendMessageOption) (*tbot.Message, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("SendMessage", values, chatID, text)
+	if c.SendMessageFunc != nil {
+		return c.SendMessageFunc(chatID, text, opts...)
+	}
+	return &tbot.Message{}, nil
+}
+
+// SendLongMessage records the call and calls SendLongMessageFunc if it is set
+func (c *Client) SendLongMessage(chatID tbot.ChatID, text string, opts ...tbot.SendMessageOption) ([]*tbot.Message, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("SendLongMessage", values, chatID, text)
+	if c.SendLongMessageFunc != nil {
+		return c.SendLongMessageFunc(chatID, text, opts...)
+	}
+	return nil, nil
+}
+
+// ForwardMessage records the call and calls ForwardMessageFunc if it is set
+func (c *Client) ForwardMessage(chatID, fromChatID tbot.ChatID, messageID int, opts ...tbot.ForwardMessageOption) (*tbot.Message, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("ForwardMessage", values, chatID, fromChatID, messageID)
+	if c.ForwardMessageFunc != nil {
+		return c.ForwardMessageFunc(chatID, fromChatID, messageID, opts...)
+	}
+	return &tbot.Message{}, nil
+}
+
+// ForwardMessages records the call and calls ForwardMessagesFunc if it is set
+func (c *Client) ForwardMessages(chatID, fromChatID tbot.ChatID, messageIDs []int, opts ...tbot.ForwardMessagesOption) ([]*tbot.MessageID, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("ForwardMessages", values, chatID, fromChatID, messageIDs)
+	if c.ForwardMessagesFunc != nil {
+		return c.ForwardMessagesFunc(chatID, fromChatID, messageIDs, opts...)
+	}
+	return nil, nil
+}
+
+// ForwardMessagesBulk records the call and calls ForwardMessagesBulkFunc if it is set
+func (c *Client) ForwardMessagesBulk(chatID, fromChatID tbot.ChatID, messageIDs []int, opts ...tbot.ForwardMessagesOption) ([]tbot.BulkResult, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("ForwardMessagesBulk", values, chatID, fromChatID, messageIDs)
+	if c.ForwardMessagesBulkFunc != nil {
+		return c.ForwardMessagesBulkFunc(chatID, fromChatID, messageIDs, opts...)
+	}
+	return nil, nil
+}
+
+// CopyMessage records the call and calls CopyMessageFunc if it is set
+func (c *Client) CopyMessage(chatID, fromChatID tbot.ChatID, messageID int, opts ...tbot.CopyMessageOption) (*tbot.MessageID, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("CopyMessage", values, chatID, fromChatID, messageID)
+	if c.CopyMessageFunc != nil {
+		return c.CopyMessageFunc(chatID, fromChatID, messageID, opts...)
+	}
+	return &tbot.MessageID{}, nil
+}
+
+// CopyMessages records the call and calls CopyMessagesFunc if it is set
+func (c *Client) CopyMessages(chatID, fromChatID tbot.ChatID, messageIDs []int, opts ...tbot.CopyMessagesOption) ([]*tbot.MessageID, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("CopyMessages", values, chatID, fromChatID, messageIDs)
+	if c.CopyMessagesFunc != nil {
+		return c.CopyMessagesFunc(chatID, fromChatID, messageIDs, opts...)
+	}
+	return nil, nil
+}
+
+// CopyMessagesBulk records the call and calls CopyMessagesBulkFunc if it is set
+func (c *Client) CopyMessagesBulk(chatID, fromChatID tbot.ChatID, messageIDs []int, opts ...tbot.CopyMessagesOption) ([]tbot.BulkResult, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("CopyMessagesBulk", values, chatID, fromChatID, messageIDs)
+	if c.CopyMessagesBulkFunc != nil {
+		return c.CopyMessagesBulkFunc(chatID, fromChatID, messageIDs, opts...)
+	}
+	return nil, nil
+}
+
+// SendAudio records the call and calls SendAudioFunc if it is set
+func (c *Client) SendAudio(chatID tbot.ChatID, fileID string, opts ...tbot.SendAudioOption) (*tbot.Message, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("SendAudio", values, chatID, fileID)
+	if c.SendAudioFunc != nil {
+		return c.SendAudioFunc(chatID, fileID, opts...)
+	}
+	return &tbot.Message{}, nil
+}
+
+// SendAudioFile records the call and calls SendAudioFileFunc if it is set
+func (c *Client) SendAudioFile(chatID tbot.ChatID, filename string, opts ...tbot.SendAudioOption) (*tbot.Message, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("SendAudioFile", values, chatID, filename)
+	if c.SendAudioFileFunc != nil {
+		return c.SendAudioFileFunc(chatID, filename, opts...)
+	}
+	return &tbot.Message{}, nil
+}
+
+// SendPhoto records the call and calls SendPhotoFunc if it is set
+func (c *Client) SendPhoto(chatID tbot.ChatID, fileID string, opts ...tbot.SendPhotoOption) (*tbot.Message, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("SendPhoto", values, chatID, fileID)
+	if c.SendPhotoFunc != nil {
+		return c.SendPhotoFunc(chatID, fileID, opts...)
+	}
+	return &tbot.Message{}, nil
+}
+
+// SendPhotoFile records the call and calls SendPhotoFileFunc if it is set
+func (c *Client) SendPhotoFile(chatID tbot.ChatID, filename string, opts ...tbot.SendPhotoOption) (*tbot.Message, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("SendPhotoFile", values, chatID, filename)
+	if c.SendPhotoFileFunc != nil {
+		return c.SendPhotoFileFunc(chatID, filename, opts...)
+	}
+	return &tbot.Message{}, nil
+}
+
+// SendDocument records the call and calls SendDocumentFunc if it is set
+func (c *Client) SendDocument(chatID tbot.ChatID, fileID string, opts ...tbot.SendDocumentOption) (*tbot.Message, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("SendDocument", values, chatID, fileID)
+	if c.SendDocumentFunc != nil {
+		return c.SendDocumentFunc(chatID, fileID, opts...)
+	}
+	return &tbot.Message{}, nil
+}
+
+// SendDocumentFile records the call and calls SendDocumentFileFunc if it is set
+func (c *Client) SendDocumentFile(chatID tbot.ChatID, filename string, opts ...tbot.SendDocumentOption) (*tbot.Message, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("SendDocumentFile", values, chatID, filename)
+	if c.SendDocumentFileFunc != nil {
+		return c.SendDocumentFileFunc(chatID, filename, opts...)
+	}
+	return &tbot.Message{}, nil
+}
+
+// SendVideo records the call and calls SendVideoFunc if it is set
+func (c *Client) SendVideo(chatID tbot.ChatID, fileID string, opts ...tbot.SendVideoOption) (*tbot.Message, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("SendVideo", values, chatID, fileID)
+	if c.SendVideoFunc != nil {
+		return c.SendVideoFunc(chatID, fileID, opts...)
+	}
+	return &tbot.Message{}, nil
+}
+
+// SendVideoFile records the call and calls SendVideoFileFunc if it is set
+func (c *Client) SendVideoFile(chatID tbot.ChatID, filename string, opts ...tbot.SendVideoOption) (*tbot.Message, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("SendVideoFile", values, chatID, filename)
+	if c.SendVideoFileFunc != nil {
+		return c.SendVideoFileFunc(chatID, filename, opts...)
+	}
+	return &tbot.Message{}, nil
+}
+
+// SendAnimation records the call and calls SendAnimationFunc if it is set
+func (c *Client) SendAnimation(chatID tbot.ChatID, fileID string, opts ...tbot.SendAnimationOption) (*tbot.Message, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("SendAnimation", values, chatID, fileID)
+	if c.SendAnimationFunc != nil {
+		return c.SendAnimationFunc(chatID, fileID, opts...)
+	}
+	return &tbot.Message{}, nil
+}
+
+// SendAnimationFile records the call and calls SendAnimationFileFunc if it is set
+func (c *Client) SendAnimationFile(chatID tbot.ChatID, filename string, opts ...tbot.SendAnimationOption) (*tbot.Message, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("SendAnimationFile", values, chatID, filename)
+	if c.SendAnimationFileFunc != nil {
+		return c.SendAnimationFileFunc(chatID, filename, opts...)
+	}
+	return &tbot.Message{}, nil
+}
+
+// SendVoice records the call and calls SendVoiceFunc if it is set
+func (c *Client) SendVoice(chatID tbot.ChatID, fileID string, opts ...tbot.SendVoiceOption) (*tbot.Message, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("SendVoice", values, chatID, fileID)
+	if c.SendVoiceFunc != nil {
+		return c.SendVoiceFunc(chatID, fileID, opts...)
+	}
+	return &tbot.Message{}, nil
+}
+
+// SendVoiceFile records the call and calls SendVoiceFileFunc if it is set
+func (c *Client) SendVoiceFile(chatID tbot.ChatID, filename string, opts ...tbot.SendVoiceOption) (*tbot.Message, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("SendVoiceFile", values, chatID, filename)
+	if c.SendVoiceFileFunc != nil {
+		return c.SendVoiceFileFunc(chatID, filename, opts...)
+	}
+	return &tbot.Message{}, nil
+}
+
+// SendVideoNote records the call and calls SendVideoNoteFunc if it is set
+func (c *Client) SendVideoNote(chatID tbot.ChatID, fileID string, opts ...tbot.SendVideoNoteOption) (*tbot.Message, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("SendVideoNote", values, chatID, fileID)
+	if c.SendVideoNoteFunc != nil {
+		return c.SendVideoNoteFunc(chatID, fileID, opts...)
+	}
+	return &tbot.Message{}, nil
+}
+
+// SendVideoNoteFile records the call and calls SendVideoNoteFileFunc if it is set
+func (c *Client) SendVideoNoteFile(chatID tbot.ChatID, filename string, opts ...tbot.SendVideoNoteOption) (*tbot.Message, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("SendVideoNoteFile", values, chatID, filename)
+	if c.SendVideoNoteFileFunc != nil {
+		return c.SendVideoNoteFileFunc(chatID, filename, opts...)
+	}
+	return &tbot.Message{}, nil
+}
+
+// SendMediaGroup records the call and calls SendMediaGroupFunc if it is set
+func (c *Client) SendMediaGroup(chatID tbot.ChatID, media []tbot.InputMedia, opts ...tbot.SendMediaGroupOption) ([]*tbot.Message, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("SendMediaGroup", values, chatID, media)
+	if c.SendMediaGroupFunc != nil {
+		return c.SendMediaGroupFunc(chatID, media, opts...)
+	}
+	return nil, nil
+}
+
+// SendLocation records the call and calls SendLocationFunc if it is set
+func (c *Client) SendLocation(chatID tbot.ChatID, latitude, longitude float64, opts ...tbot.SendLocationOption) (*tbot.Message, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("SendLocation", values, chatID, latitude, longitude)
+	if c.SendLocationFunc != nil {
+		return c.SendLocationFunc(chatID, latitude, longitude, opts...)
+	}
+	return &tbot.Message{}, nil
+}
+
+// EditMessageLiveLocation records the call and calls EditMessageLiveLocationFunc if it is set
+func (c *Client) EditMessageLiveLocation(chatID tbot.ChatID, messageID int, latitude, longitude float64, opts ...tbot.LiveLocationOption) (*tbot.Message, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("EditMessageLiveLocation", values, chatID, messageID, latitude, longitude)
+	if c.EditMessageLiveLocationFunc != nil {
+		return c.EditMessageLiveLocationFunc(chatID, messageID, latitude, longitude, opts...)
+	}
+	return &tbot.Message{}, nil
+}
+
+// StopMessageLiveLocation records the call and calls StopMessageLiveLocationFunc if it is set
+func (c *Client) StopMessageLiveLocation(chatID tbot.ChatID, messageID int, opts ...tbot.LiveLocationOption) (*tbot.Message, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("StopMessageLiveLocation", values, chatID, messageID)
+	if c.StopMessageLiveLocationFunc != nil {
+		return c.StopMessageLiveLocationFunc(chatID, messageID, opts...)
+	}
+	return &tbot.Message{}, nil
+}
+
+// SendVenue records the call and calls SendVenueFunc if it is set
+func (c *Client) SendVenue(chatID tbot.ChatID, latitude, longitude float64, title, address string, opts ...tbot.SendVenueOption) (*tbot.Message, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("SendVenue", values, chatID, latitude, longitude, title, address)
+	if c.SendVenueFunc != nil {
+		return c.SendVenueFunc(chatID, latitude, longitude, title, address, opts...)
+	}
+	return &tbot.Message{}, nil
+}
+
+// SendContact records the call and calls SendContactFunc if it is set
+func (c *Client) SendContact(chatID tbot.ChatID, phoneNumber, firstName string, opts ...tbot.SendContactOption) (*tbot.Message, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("SendContact", values, chatID, phoneNumber, firstName)
+	if c.SendContactFunc != nil {
+		return c.SendContactFunc(chatID, phoneNumber, firstName, opts...)
+	}
+	return &tbot.Message{}, nil
+}
+
+// SendSticker records the call and calls SendStickerFunc if it is set
+func (c *Client) SendSticker(chatID tbot.ChatID, fileID string, opts ...tbot.SendStickerOption) (*tbot.Message, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("SendSticker", values, chatID, fileID)
+	if c.SendStickerFunc != nil {
+		return c.SendStickerFunc(chatID, fileID, opts...)
+	}
+	return &tbot.Message{}, nil
+}
+
+// SendStickerFile records the call and calls SendStickerFileFunc if it is set
+func (c *Client) SendStickerFile(chatID tbot.ChatID, filename string, opts ...tbot.SendStickerOption) (*tbot.Message, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("SendStickerFile", values, chatID, filename)
+	if c.SendStickerFileFunc != nil {
+		return c.SendStickerFileFunc(chatID, filename, opts...)
+	}
+	return &tbot.Message{}, nil
+}
+
+// SendPoll records the call and calls SendPollFunc if it is set
+func (c *Client) SendPoll(chatID tbot.ChatID, question string, options []string, opts ...tbot.SendPollOption) (*tbot.Message, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("SendPoll", values, chatID, question, options)
+	if c.SendPollFunc != nil {
+		return c.SendPollFunc(chatID, question, options, opts...)
+	}
+	return &tbot.Message{}, nil
+}
+
+// StopPoll records the call and calls StopPollFunc if it is set
+func (c *Client) StopPoll(chatID tbot.ChatID, messageID int, opts ...tbot.StopPollOption) (*tbot.Poll, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("StopPoll", values, chatID, messageID)
+	if c.StopPollFunc != nil {
+		return c.StopPollFunc(chatID, messageID, opts...)
+	}
+	return &tbot.Poll{}, nil
+}
+
+// SendDice records the call and calls SendDiceFunc if it is set
+func (c *Client) SendDice(chatID tbot.ChatID, emoji string, opts ...tbot.SendDiceOption) (*tbot.Dice, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("SendDice", values, chatID, emoji)
+	if c.SendDiceFunc != nil {
+		return c.SendDiceFunc(chatID, emoji, opts...)
+	}
+	return &tbot.Dice{}, nil
+}
+
+// SendGame records the call and calls SendGameFunc if it is set
+func (c *Client) SendGame(chatID tbot.ChatID, gameShortName string, opts ...tbot.SendGameOption) (*tbot.Message, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("SendGame", values, chatID, gameShortName)
+	if c.SendGameFunc != nil {
+		return c.SendGameFunc(chatID, gameShortName, opts...)
+	}
+	return &tbot.Message{}, nil
+}
+
+// SetGameScore records the call and calls SetGameScoreFunc if it is set
+func (c *Client) SetGameScore(chatID tbot.ChatID, messageID int, userID int64, score int, opts ...tbot.SetGameScoreOption) (*tbot.Message, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("SetGameScore", values, chatID, messageID, userID, score)
+	if c.SetGameScoreFunc != nil {
+		return c.SetGameScoreFunc(chatID, messageID, userID, score, opts...)
+	}
+	return &tbot.Message{}, nil
+}
+
+// GetGameHighScores records the call and calls GetGameHighScoresFunc if it is set
+func (c *Client) GetGameHighScores(chatID tbot.ChatID, messageID int, userID int64) ([]*tbot.GameHighScore, error) {
+	values := url.Values{}
+	c.record("GetGameHighScores", values, chatID, messageID, userID)
+	if c.GetGameHighScoresFunc != nil {
+		return c.GetGameHighScoresFunc(chatID, messageID, userID)
+	}
+	return nil, nil
+}
+
+// SendChatAction records the call and calls SendChatActionFunc if it is set
+func (c *Client) SendChatAction(chatID tbot.ChatID, action tbot.ChatAction) error {
+	values := url.Values{}
+	c.record("SendChatAction", values, chatID, action)
+	if c.SendChatActionFunc != nil {
+		return c.SendChatActionFunc(chatID, action)
+	}
+	return nil
+}
+
+// EditMessageText records the call and calls EditMessageTextFunc if it is set
+func (c *Client) EditMessageText(chatID tbot.ChatID, messageID int, text string, opts ...tbot.EditMessageTextOption) (*tbot.Message, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("EditMessageText", values, chatID, messageID, text)
+	if c.EditMessageTextFunc != nil {
+		return c.EditMessageTextFunc(chatID, messageID, text, opts...)
+	}
+	return &tbot.Message{}, nil
+}
+
+// EditMessageCaption records the call and calls EditMessageCaptionFunc if it is set
+func (c *Client) EditMessageCaption(chatID tbot.ChatID, messageID int, caption string, opts ...tbot.EditMessageCaptionOption) (*tbot.Message, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("EditMessageCaption", values, chatID, messageID, caption)
+	if c.EditMessageCaptionFunc != nil {
+		return c.EditMessageCaptionFunc(chatID, messageID, caption, opts...)
+	}
+	return &tbot.Message{}, nil
+}
+
+// EditMessageReplyMarkup records the call and calls EditMessageReplyMarkupFunc if it is set
+func (c *Client) EditMessageReplyMarkup(chatID tbot.ChatID, messageID int, opts ...tbot.EditMessageReplyMarkupOption) (*tbot.Message, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("EditMessageReplyMarkup", values, chatID, messageID)
+	if c.EditMessageReplyMarkupFunc != nil {
+		return c.EditMessageReplyMarkupFunc(chatID, messageID, opts...)
+	}
+	return &tbot.Message{}, nil
+}
+
+// DeleteMessage records the call and calls DeleteMessageFunc if it is set
+func (c *Client) DeleteMessage(chatID tbot.ChatID, messageID int) error {
+	values := url.Values{}
+	c.record("DeleteMessage", values, chatID, messageID)
+	if c.DeleteMessageFunc != nil {
+		return c.DeleteMessageFunc(chatID, messageID)
+	}
+	return nil
+}
+
+// DeleteMessages records the call and calls DeleteMessagesFunc if it is set
+func (c *Client) DeleteMessages(chatID tbot.ChatID, messageIDs []int) error {
+	values := url.Values{}
+	c.record("DeleteMessages", values, chatID, messageIDs)
+	if c.DeleteMessagesFunc != nil {
+		return c.DeleteMessagesFunc(chatID, messageIDs)
+	}
+	return nil
+}
+
+// DeleteMessagesBulk records the call and calls DeleteMessagesBulkFunc if it is set
+func (c *Client) DeleteMessagesBulk(chatID tbot.ChatID, messageIDs []int) ([]tbot.BulkResult, error) {
+	values := url.Values{}
+	c.record("DeleteMessagesBulk", values, chatID, messageIDs)
+	if c.DeleteMessagesBulkFunc != nil {
+		return c.DeleteMessagesBulkFunc(chatID, messageIDs)
+	}
+	return nil, nil
+}
+
+// GetChat records the call and calls GetChatFunc if it is set
+func (c *Client) GetChat(chatID tbot.ChatID) (*tbot.Chat, error) {
+	values := url.Values{}
+	c.record("GetChat", values, chatID)
+	if c.GetChatFunc != nil {
+		return c.GetChatFunc(chatID)
+	}
+	return &tbot.Chat{}, nil
+}
+
+// GetChatAdministrators records the call and calls GetChatAdministratorsFunc if it is set
+func (c *Client) GetChatAdministrators(chatID tbot.ChatID) ([]*tbot.ChatMember, error) {
+	values := url.Values{}
+	c.record("GetChatAdministrators", values, chatID)
+	if c.GetChatAdministratorsFunc != nil {
+		return c.GetChatAdministratorsFunc(chatID)
+	}
+	return nil, nil
+}
+
+// GetChatMembersCount records the call and calls GetChatMembersCountFunc if it is set
+func (c *Client) GetChatMembersCount(chatID tbot.ChatID) (int, error) {
+	values := url.Values{}
+	c.record("GetChatMembersCount", values, chatID)
+	if c.GetChatMembersCountFunc != nil {
+		return c.GetChatMembersCountFunc(chatID)
+	}
+	return 0, nil
+}
+
+// GetChatMemberCount records the call and calls GetChatMemberCountFunc if it is set
+func (c *Client) GetChatMemberCount(chatID tbot.ChatID) (int, error) {
+	values := url.Values{}
+	c.record("GetChatMemberCount", values, chatID)
+	if c.GetChatMemberCountFunc != nil {
+		return c.GetChatMemberCountFunc(chatID)
+	}
+	return 0, nil
+}
+
+// GetChatMember records the call and calls GetChatMemberFunc if it is set
+func (c *Client) GetChatMember(chatID tbot.ChatID, userID int64) (*tbot.ChatMember, error) {
+	values := url.Values{}
+	c.record("GetChatMember", values, chatID, userID)
+	if c.GetChatMemberFunc != nil {
+		return c.GetChatMemberFunc(chatID, userID)
+	}
+	return &tbot.ChatMember{}, nil
+}
+
+// KickChatMember records the call and calls KickChatMemberFunc if it is set
+func (c *Client) KickChatMember(chatID tbot.ChatID, userID int64, opts ...tbot.KickChatMemberOption) error {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("KickChatMember", values, chatID, userID)
+	if c.KickChatMemberFunc != nil {
+		return c.KickChatMemberFunc(chatID, userID, opts...)
+	}
+	return nil
+}
+
+// BanChatMember records the call and calls BanChatMemberFunc if it is set
+func (c *Client) BanChatMember(chatID tbot.ChatID, userID int64, opts ...tbot.BanChatMemberOption) error {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("BanChatMember", values, chatID, userID)
+	if c.BanChatMemberFunc != nil {
+		return c.BanChatMemberFunc(chatID, userID, opts...)
+	}
+	return nil
+}
+
+// UnbanChatMember records the call and calls UnbanChatMemberFunc if it is set
+func (c *Client) UnbanChatMember(chatID tbot.ChatID, userID int64) error {
+	values := url.Values{}
+	c.record("UnbanChatMember", values, chatID, userID)
+	if c.UnbanChatMemberFunc != nil {
+		return c.UnbanChatMemberFunc(chatID, userID)
+	}
+	return nil
+}
+
+// RestrictChatMember records the call and calls RestrictChatMemberFunc if it is set
+func (c *Client) RestrictChatMember(chatID tbot.ChatID, userID int64, perm *tbot.ChatPermissions, opts ...tbot.RestrictChatMemberOption) error {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("RestrictChatMember", values, chatID, userID, perm)
+	if c.RestrictChatMemberFunc != nil {
+		return c.RestrictChatMemberFunc(chatID, userID, perm, opts...)
+	}
+	return nil
+}
+
+// PromoteChatMember records the call and calls PromoteChatMemberFunc if it is set
+func (c *Client) PromoteChatMember(chatID tbot.ChatID, userID int64, p *tbot.Promotions) error {
+	values := url.Values{}
+	c.record("PromoteChatMember", values, chatID, userID, p)
+	if c.PromoteChatMemberFunc != nil {
+		return c.PromoteChatMemberFunc(chatID, userID, p)
+	}
+	return nil
+}
+
+// SetChatAdministratorCustomTitle records the call and calls SetChatAdministratorCustomTitleFunc if it is set
+func (c *Client) SetChatAdministratorCustomTitle(chatID tbot.ChatID, userID int64, customTitle string) error {
+	values := url.Values{}
+	c.record("SetChatAdministratorCustomTitle", values, chatID, userID, customTitle)
+	if c.SetChatAdministratorCustomTitleFunc != nil {
+		return c.SetChatAdministratorCustomTitleFunc(chatID, userID, customTitle)
+	}
+	return nil
+}
+
+// SetChatPermissions records the call and calls SetChatPermissionsFunc if it is set
+func (c *Client) SetChatPermissions(chatID tbot.ChatID, permissions *tbot.ChatPermissions) error {
+	values := url.Values{}
+	c.record("SetChatPermissions", values, chatID, permissions)
+	if c.SetChatPermissionsFunc != nil {
+		return c.SetChatPermissionsFunc(chatID, permissions)
+	}
+	return nil
+}
+
+// BanChatSenderChat records the call and calls BanChatSenderChatFunc if it is set
+func (c *Client) BanChatSenderChat(chatID tbot.ChatID, senderChatID int64) error {
+	values := url.Values{}
+	c.record("BanChatSenderChat", values, chatID, senderChatID)
+	if c.BanChatSenderChatFunc != nil {
+		return c.BanChatSenderChatFunc(chatID, senderChatID)
+	}
+	return nil
+}
+
+// UnbanChatSenderChat records the call and calls UnbanChatSenderChatFunc if it is set
+func (c *Client) UnbanChatSenderChat(chatID tbot.ChatID, senderChatID int64) error {
+	values := url.Values{}
+	c.record("UnbanChatSenderChat", values, chatID, senderChatID)
+	if c.UnbanChatSenderChatFunc != nil {
+		return c.UnbanChatSenderChatFunc(chatID, senderChatID)
+	}
+	return nil
+}
+
+// ApproveChatJoinRequest records the call and calls ApproveChatJoinRequestFunc if it is set
+func (c *Client) ApproveChatJoinRequest(chatID tbot.ChatID, userID int64) error {
+	values := url.Values{}
+	c.record("ApproveChatJoinRequest", values, chatID, userID)
+	if c.ApproveChatJoinRequestFunc != nil {
+		return c.ApproveChatJoinRequestFunc(chatID, userID)
+	}
+	return nil
+}
+
+// DeclineChatJoinRequest records the call and calls DeclineChatJoinRequestFunc if it is set
+func (c *Client) DeclineChatJoinRequest(chatID tbot.ChatID, userID int64) error {
+	values := url.Values{}
+	c.record("DeclineChatJoinRequest", values, chatID, userID)
+	if c.DeclineChatJoinRequestFunc != nil {
+		return c.DeclineChatJoinRequestFunc(chatID, userID)
+	}
+	return nil
+}
+
+// ExportChatInviteLink records the call and calls ExportChatInviteLinkFunc if it is set
+func (c *Client) ExportChatInviteLink(chatID tbot.ChatID) (string, error) {
+	values := url.Values{}
+	c.record("ExportChatInviteLink", values, chatID)
+	if c.ExportChatInviteLinkFunc != nil {
+		return c.ExportChatInviteLinkFunc(chatID)
+	}
+	return "", nil
+}
+
+// SetChatPhoto records the call and calls SetChatPhotoFunc if it is set
+func (c *Client) SetChatPhoto(chatID tbot.ChatID, filename string) error {
+	values := url.Values{}
+	c.record("SetChatPhoto", values, chatID, filename)
+	if c.SetChatPhotoFunc != nil {
+		return c.SetChatPhotoFunc(chatID, filename)
+	}
+	return nil
+}
+
+// DeleteChatPhoto records the call and calls DeleteChatPhotoFunc if it is set
+func (c *Client) DeleteChatPhoto(chatID tbot.ChatID) error {
+	values := url.Values{}
+	c.record("DeleteChatPhoto", values, chatID)
+	if c.DeleteChatPhotoFunc != nil {
+		return c.DeleteChatPhotoFunc(chatID)
+	}
+	return nil
+}
+
+// SetChatTitle records the call and calls SetChatTitleFunc if it is set
+func (c *Client) SetChatTitle(chatID tbot.ChatID, title string) error {
+	values := url.Values{}
+	c.record("SetChatTitle", values, chatID, title)
+	if c.SetChatTitleFunc != nil {
+		return c.SetChatTitleFunc(chatID, title)
+	}
+	return nil
+}
+
+// SetChatDescription records the call and calls SetChatDescriptionFunc if it is set
+func (c *Client) SetChatDescription(chatID tbot.ChatID, description string) error {
+	values := url.Values{}
+	c.record("SetChatDescription", values, chatID, description)
+	if c.SetChatDescriptionFunc != nil {
+		return c.SetChatDescriptionFunc(chatID, description)
+	}
+	return nil
+}
+
+// PinChatMessage records the call and calls PinChatMessageFunc if it is set
+func (c *Client) PinChatMessage(chatID tbot.ChatID, messageID int, opts ...tbot.PinChatMessageOption) error {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("PinChatMessage", values, chatID, messageID)
+	if c.PinChatMessageFunc != nil {
+		return c.PinChatMessageFunc(chatID, messageID, opts...)
+	}
+	return nil
+}
+
+// UnpinChatMessage records the call and calls UnpinChatMessageFunc if it is set
+func (c *Client) UnpinChatMessage(chatID tbot.ChatID) error {
+	values := url.Values{}
+	c.record("UnpinChatMessage", values, chatID)
+	if c.UnpinChatMessageFunc != nil {
+		return c.UnpinChatMessageFunc(chatID)
+	}
+	return nil
+}
+
+// UnpinAllChatMessages records the call and calls UnpinAllChatMessagesFunc if it is set
+func (c *Client) UnpinAllChatMessages(chatID tbot.ChatID) error {
+	values := url.Values{}
+	c.record("UnpinAllChatMessages", values, chatID)
+	if c.UnpinAllChatMessagesFunc != nil {
+		return c.UnpinAllChatMessagesFunc(chatID)
+	}
+	return nil
+}
+
+// SetChatStickerSet records the call and calls SetChatStickerSetFunc if it is set
+func (c *Client) SetChatStickerSet(chatID tbot.ChatID, stickerSetName string) error {
+	values := url.Values{}
+	c.record("SetChatStickerSet", values, chatID, stickerSetName)
+	if c.SetChatStickerSetFunc != nil {
+		return c.SetChatStickerSetFunc(chatID, stickerSetName)
+	}
+	return nil
+}
+
+// DeleteChatStickerSet records the call and calls DeleteChatStickerSetFunc if it is set
+func (c *Client) DeleteChatStickerSet(chatID tbot.ChatID) error {
+	values := url.Values{}
+	c.record("DeleteChatStickerSet", values, chatID)
+	if c.DeleteChatStickerSetFunc != nil {
+		return c.DeleteChatStickerSetFunc(chatID)
+	}
+	return nil
+}
+
+// LeaveChat records the call and calls LeaveChatFunc if it is set
+func (c *Client) LeaveChat(chatID tbot.ChatID) error {
+	values := url.Values{}
+	c.record("LeaveChat", values, chatID)
+	if c.LeaveChatFunc != nil {
+		return c.LeaveChatFunc(chatID)
+	}
+	return nil
+}
+
+// GetForumTopicIconStickers records the call and calls GetForumTopicIconStickersFunc if it is set
+func (c *Client) GetForumTopicIconStickers() ([]*tbot.Sticker, error) {
+	values := url.Values{}
+	c.record("GetForumTopicIconStickers", values)
+	if c.GetForumTopicIconStickersFunc != nil {
+		return c.GetForumTopicIconStickersFunc()
+	}
+	return nil, nil
+}
+
+// CreateForumTopic records the call and calls CreateForumTopicFunc if it is set
+func (c *Client) CreateForumTopic(chatID tbot.ChatID, name string, opts ...tbot.CreateForumTopicOption) (*tbot.ForumTopic, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("CreateForumTopic", values, chatID, name)
+	if c.CreateForumTopicFunc != nil {
+		return c.CreateForumTopicFunc(chatID, name, opts...)
+	}
+	return &tbot.ForumTopic{}, nil
+}
+
+// EditForumTopic records the call and calls EditForumTopicFunc if it is set
+func (c *Client) EditForumTopic(chatID tbot.ChatID, messageThreadID int, opts ...tbot.EditForumTopicOption) error {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("EditForumTopic", values, chatID, messageThreadID)
+	if c.EditForumTopicFunc != nil {
+		return c.EditForumTopicFunc(chatID, messageThreadID, opts...)
+	}
+	return nil
+}
+
+// CloseForumTopic records the call and calls CloseForumTopicFunc if it is set
+func (c *Client) CloseForumTopic(chatID tbot.ChatID, messageThreadID int) error {
+	values := url.Values{}
+	c.record("CloseForumTopic", values, chatID, messageThreadID)
+	if c.CloseForumTopicFunc != nil {
+		return c.CloseForumTopicFunc(chatID, messageThreadID)
+	}
+	return nil
+}
+
+// ReopenForumTopic records the call and calls ReopenForumTopicFunc if it is set
+func (c *Client) ReopenForumTopic(chatID tbot.ChatID, messageThreadID int) error {
+	values := url.Values{}
+	c.record("ReopenForumTopic", values, chatID, messageThreadID)
+	if c.ReopenForumTopicFunc != nil {
+		return c.ReopenForumTopicFunc(chatID, messageThreadID)
+	}
+	return nil
+}
+
+// DeleteForumTopic records the call and calls DeleteForumTopicFunc if it is set
+func (c *Client) DeleteForumTopic(chatID tbot.ChatID, messageThreadID int) error {
+	values := url.Values{}
+	c.record("DeleteForumTopic", values, chatID, messageThreadID)
+	if c.DeleteForumTopicFunc != nil {
+		return c.DeleteForumTopicFunc(chatID, messageThreadID)
+	}
+	return nil
+}
+
+// UnpinAllForumTopicMessages records the call and calls UnpinAllForumTopicMessagesFunc if it is set
+func (c *Client) UnpinAllForumTopicMessages(chatID tbot.ChatID, messageThreadID int) error {
+	values := url.Values{}
+	c.record("UnpinAllForumTopicMessages", values, chatID, messageThreadID)
+	if c.UnpinAllForumTopicMessagesFunc != nil {
+		return c.UnpinAllForumTopicMessagesFunc(chatID, messageThreadID)
+	}
+	return nil
+}
+
+// GetFile records the call and calls GetFileFunc if it is set
+func (c *Client) GetFile(fileID string) (*tbot.File, error) {
+	values := url.Values{}
+	c.record("GetFile", values, fileID)
+	if c.GetFileFunc != nil {
+		return c.GetFileFunc(fileID)
+	}
+	return &tbot.File{}, nil
+}
+
+// FileURL records the call and calls FileURLFunc if it is set
+func (c *Client) FileURL(file *tbot.File) string {
+	values := url.Values{}
+	c.record("FileURL", values, file)
+	if c.FileURLFunc != nil {
+		return c.FileURLFunc(file)
+	}
+	return ""
+}
+
+// DownloadFile records the call and calls DownloadFileFunc if it is set
+func (c *Client) DownloadFile(fileID string) (io.ReadCloser, error) {
+	values := url.Values{}
+	c.record("DownloadFile", values, fileID)
+	if c.DownloadFileFunc != nil {
+		return c.DownloadFileFunc(fileID)
+	}
+	return nil, nil
+}
+
+// GetUserProfilePhotos records the call and calls GetUserProfilePhotosFunc if it is set
+func (c *Client) GetUserProfilePhotos(userID int64, opts ...tbot.GetUserProfilePhotosOption) (*tbot.UserProfilePhotos, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("GetUserProfilePhotos", values, userID)
+	if c.GetUserProfilePhotosFunc != nil {
+		return c.GetUserProfilePhotosFunc(userID, opts...)
+	}
+	return &tbot.UserProfilePhotos{}, nil
+}
+
+// GetStickerSet records the call and calls GetStickerSetFunc if it is set
+func (c *Client) GetStickerSet(name string) (*tbot.StickerSet, error) {
+	values := url.Values{}
+	c.record("GetStickerSet", values, name)
+	if c.GetStickerSetFunc != nil {
+		return c.GetStickerSetFunc(name)
+	}
+	return &tbot.StickerSet{}, nil
+}
+
+// UploadStickerFile records the call and calls UploadStickerFileFunc if it is set
+func (c *Client) UploadStickerFile(userID int64, filename string) (*tbot.File, error) {
+	values := url.Values{}
+	c.record("UploadStickerFile", values, userID, filename)
+	if c.UploadStickerFileFunc != nil {
+		return c.UploadStickerFileFunc(userID, filename)
+	}
+	return &tbot.File{}, nil
+}
+
+// CreateNewStickerSet records the call and calls CreateNewStickerSetFunc if it is set
+func (c *Client) CreateNewStickerSet(userID int64, name, title, fileID, emojis string, opts ...tbot.CreateNewStickerSetOption) error {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("CreateNewStickerSet", values, userID, name, title, fileID, emojis)
+	if c.CreateNewStickerSetFunc != nil {
+		return c.CreateNewStickerSetFunc(userID, name, title, fileID, emojis, opts...)
+	}
+	return nil
+}
+
+// CreateNewStickerSetFile records the call and calls CreateNewStickerSetFileFunc if it is set
+func (c *Client) CreateNewStickerSetFile(userID int64, name, title, stickerFilename, emojis string, opts ...tbot.CreateNewStickerSetOption) error {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("CreateNewStickerSetFile", values, userID, name, title, stickerFilename, emojis)
+	if c.CreateNewStickerSetFileFunc != nil {
+		return c.CreateNewStickerSetFileFunc(userID, name, title, stickerFilename, emojis, opts...)
+	}
+	return nil
+}
+
+// AddStickerToSet records the call and calls AddStickerToSetFunc if it is set
+func (c *Client) AddStickerToSet(userID int64, name, fileID, emojis string, opts ...tbot.AddStickerToSetOption) error {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("AddStickerToSet", values, userID, name, fileID, emojis)
+	if c.AddStickerToSetFunc != nil {
+		return c.AddStickerToSetFunc(userID, name, fileID, emojis, opts...)
+	}
+	return nil
+}
+
+// AddStickerToSetFile records the call and calls AddStickerToSetFileFunc if it is set
+func (c *Client) AddStickerToSetFile(userID int64, name, filename, emojis string, opts ...tbot.AddStickerToSetOption) error {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("AddStickerToSetFile", values, userID, name, filename, emojis)
+	if c.AddStickerToSetFileFunc != nil {
+		return c.AddStickerToSetFileFunc(userID, name, filename, emojis, opts...)
+	}
+	return nil
+}
+
+// SetStickerPositionInSet records the call and calls SetStickerPositionInSetFunc if it is set
+func (c *Client) SetStickerPositionInSet(fileID string, pos int) error {
+	values := url.Values{}
+	c.record("SetStickerPositionInSet", values, fileID, pos)
+	if c.SetStickerPositionInSetFunc != nil {
+		return c.SetStickerPositionInSetFunc(fileID, pos)
+	}
+	return nil
+}
+
+// DeleteStickerFromSet records the call and calls DeleteStickerFromSetFunc if it is set
+func (c *Client) DeleteStickerFromSet(fileID string) error {
+	values := url.Values{}
+	c.record("DeleteStickerFromSet", values, fileID)
+	if c.DeleteStickerFromSetFunc != nil {
+		return c.DeleteStickerFromSetFunc(fileID)
+	}
+	return nil
+}
+
+// SetStickerSetThumb records the call and calls SetStickerSetThumbFunc if it is set
+func (c *Client) SetStickerSetThumb(userID int64, name, thumb string) error {
+	values := url.Values{}
+	c.record("SetStickerSetThumb", values, userID, name, thumb)
+	if c.SetStickerSetThumbFunc != nil {
+		return c.SetStickerSetThumbFunc(userID, name, thumb)
+	}
+	return nil
+}
+
+// SetStickerSetThumbFile records the call and calls SetStickerSetThumbFileFunc if it is set
+func (c *Client) SetStickerSetThumbFile(userID int64, name, thumbnailFilename string) error {
+	values := url.Values{}
+	c.record("SetStickerSetThumbFile", values, userID, name, thumbnailFilename)
+	if c.SetStickerSetThumbFileFunc != nil {
+		return c.SetStickerSetThumbFileFunc(userID, name, thumbnailFilename)
+	}
+	return nil
+}
+
+// AnswerCallbackQuery records the call and calls AnswerCallbackQueryFunc if it is set
+func (c *Client) AnswerCallbackQuery(callbackQueryID string, opts ...tbot.AnswerCallbackQueryOption) error {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("AnswerCallbackQuery", values, callbackQueryID)
+	if c.AnswerCallbackQueryFunc != nil {
+		return c.AnswerCallbackQueryFunc(callbackQueryID, opts...)
+	}
+	return nil
+}
+
+// AnswerInlineQuery records the call and calls AnswerInlineQueryFunc if it is set
+func (c *Client) AnswerInlineQuery(inlineQueryID string, results []tbot.InlineQueryResult, opts ...tbot.AnswerInlineQueryOption) error {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("AnswerInlineQuery", values, inlineQueryID, results)
+	if c.AnswerInlineQueryFunc != nil {
+		return c.AnswerInlineQueryFunc(inlineQueryID, results, opts...)
+	}
+	return nil
+}
+
+// EditInlineMessageText records the call and calls EditInlineMessageTextFunc if it is set
+func (c *Client) EditInlineMessageText(inlineMessageID, text string, opts ...tbot.EditMessageTextOption) error {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("EditInlineMessageText", values, inlineMessageID, text)
+	if c.EditInlineMessageTextFunc != nil {
+		return c.EditInlineMessageTextFunc(inlineMessageID, text, opts...)
+	}
+	return nil
+}
+
+// EditInlineMessageCaption records the call and calls EditInlineMessageCaptionFunc if it is set
+func (c *Client) EditInlineMessageCaption(inlineMessageID, caption string, opts ...tbot.EditMessageCaptionOption) error {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("EditInlineMessageCaption", values, inlineMessageID, caption)
+	if c.EditInlineMessageCaptionFunc != nil {
+		return c.EditInlineMessageCaptionFunc(inlineMessageID, caption, opts...)
+	}
+	return nil
+}
+
+// EditInlineMessageReplyMarkup records the call and calls EditInlineMessageReplyMarkupFunc if it is set
+func (c *Client) EditInlineMessageReplyMarkup(inlineMessageID string, opts ...tbot.EditMessageReplyMarkupOption) error {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("EditInlineMessageReplyMarkup", values, inlineMessageID)
+	if c.EditInlineMessageReplyMarkupFunc != nil {
+		return c.EditInlineMessageReplyMarkupFunc(inlineMessageID, opts...)
+	}
+	return nil
+}
+
+// EditInlineMessageLiveLocation records the call and calls EditInlineMessageLiveLocationFunc if it is set
+func (c *Client) EditInlineMessageLiveLocation(inlineMessageID string, latitude, longitude float64, opts ...tbot.LiveLocationOption) error {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("EditInlineMessageLiveLocation", values, inlineMessageID, latitude, longitude)
+	if c.EditInlineMessageLiveLocationFunc != nil {
+		return c.EditInlineMessageLiveLocationFunc(inlineMessageID, latitude, longitude, opts...)
+	}
+	return nil
+}
+
+// StopInlineMessageLiveLocation records the call and calls StopInlineMessageLiveLocationFunc if it is set
+func (c *Client) StopInlineMessageLiveLocation(inlineMessageID string, opts ...tbot.LiveLocationOption) error {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("StopInlineMessageLiveLocation", values, inlineMessageID)
+	if c.StopInlineMessageLiveLocationFunc != nil {
+		return c.StopInlineMessageLiveLocationFunc(inlineMessageID, opts...)
+	}
+	return nil
+}
+
+// SetInlineGameScore records the call and calls SetInlineGameScoreFunc if it is set
+func (c *Client) SetInlineGameScore(inlineMessageID string, userID int64, score int, opts ...tbot.SetGameScoreOption) error {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("SetInlineGameScore", values, inlineMessageID, userID, score)
+	if c.SetInlineGameScoreFunc != nil {
+		return c.SetInlineGameScoreFunc(inlineMessageID, userID, score, opts...)
+	}
+	return nil
+}
+
+// GetInlineGameHighScores records the call and calls GetInlineGameHighScoresFunc if it is set
+func (c *Client) GetInlineGameHighScores(inlineMessageID string, userID int64) ([]*tbot.GameHighScore, error) {
+	values := url.Values{}
+	c.record("GetInlineGameHighScores", values, inlineMessageID, userID)
+	if c.GetInlineGameHighScoresFunc != nil {
+		return c.GetInlineGameHighScoresFunc(inlineMessageID, userID)
+	}
+	return nil, nil
+}
+
+// SendInvoice records the call and calls SendInvoiceFunc if it is set
+func (c *Client) SendInvoice(chatID tbot.ChatID, payload, providerToken string, invoice *tbot.Invoice, prices []tbot.LabeledPrice, opts ...tbot.SendInvoiceOption) (*tbot.Message, error) {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("SendInvoice", values, chatID, payload, providerToken, invoice, prices)
+	if c.SendInvoiceFunc != nil {
+		return c.SendInvoiceFunc(chatID, payload, providerToken, invoice, prices, opts...)
+	}
+	return &tbot.Message{}, nil
+}
+
+// AnswerShippingQuery records the call and calls AnswerShippingQueryFunc if it is set
+func (c *Client) AnswerShippingQuery(shippingQueryID string, ok bool, opts ...tbot.AnswerShippingQueryOption) error {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("AnswerShippingQuery", values, shippingQueryID, ok)
+	if c.AnswerShippingQueryFunc != nil {
+		return c.AnswerShippingQueryFunc(shippingQueryID, ok, opts...)
+	}
+	return nil
+}
+
+// AnswerPreCheckoutQuery records the call and calls AnswerPreCheckoutQueryFunc if it is set
+func (c *Client) AnswerPreCheckoutQuery(preCheckoutQueryID string, ok bool, opts ...tbot.AnswerPreCheckoutQueryOption) error {
+	values := url.Values{}
+	for _, opt := range opts {
+		tbot.ApplyOption(values, opt)
+	}
+	c.record("AnswerPreCheckoutQuery", values, preCheckoutQueryID, ok)
+	if c.AnswerPreCheckoutQueryFunc != nil {
+		return c.AnswerPreCheckoutQueryFunc(preCheckoutQueryID, ok, opts...)
+	}
+	return nil
+}
+
+// GetMe records the call and calls GetMeFunc if it is set
+func (c *Client) GetMe() (*tbot.User, error) {
+	values := url.Values{}
+	c.record("GetMe", values)
+	if c.GetMeFunc != nil {
+		return c.GetMeFunc()
+	}
+	return &tbot.User{}, nil
+}
+
+// GetMyCommands records the call and calls GetMyCommandsFunc if it is set
+func (c *Client) GetMyCommands() (*[]tbot.BotCommand, error) {
+	values := url.Values{}
+	c.record("GetMyCommands", values)
+	if c.GetMyCommandsFunc != nil {
+		return c.GetMyCommandsFunc()
+	}
+	return &[]tbot.BotCommand{}, nil
+}
+
+// SetMyCommands records the call and calls SetMyCommandsFunc if it is set
+func (c *Client) SetMyCommands(commands []tbot.BotCommand) error {
+	values := url.Values{}
+	c.record("SetMyCommands", values, commands)
+	if c.SetMyCommandsFunc != nil {
+		return c.SetMyCommandsFunc(commands)
+	}
+	return nil
+}
+
+// SetPassportDataErrors records the call and calls SetPassportDataErrorsFunc if it is set
+func (c *Client) SetPassportDataErrors(userID int64, errors []tbot.PassportElementError) error {
+	values := url.Values{}
+	c.record("SetPassportDataErrors", values, userID, errors)
+	if c.SetPassportDataErrorsFunc != nil {
+		return c.SetPassportDataErrorsFunc(userID, errors)
+	}
+	return nil
+}
+
+// Call records the call and calls CallFunc if it is set
+func (c *Client) Call(ctx context.Context, method string, params interface{}, result interface{}) error {
+	values := url.Values{}
+	c.record("Call", values, ctx, method, params, result)
+	if c.CallFunc != nil {
+		return c.CallFunc(ctx, method, params, result)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package tbotmock_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/yanzay/tbot/v2"
+	"github.com/yanzay/tbot/v2/tbotmock"
+)
+
+type greeter struct {
+	client tbot.Messaging
+}
+
+func (g *greeter) handle(m *tbot.Message) error {
+	msg, err := g.client.SendMessage(m.Chat.ID, "<b>hello</b>", tbot.OptParseModeHTML)
+	if err != nil {
+		return err
+	}
+	return g.client.DeleteMessage(m.Chat.ID, msg.MessageID)
+}
+
+func TestClient(t *testing.T) {
+	c := &tbotmock.Client{}
+	c.SendMessageFunc = func(chatID tbot.ChatID, text string, opts ...tbot.SendMessageOption) (*tbot.Message, error) {
+		return &tbot.Message{MessageID: 42}, nil
+	}
+	g := &greeter{client: c}
+	err := g.handle(&tbot.Message{Chat: tbot.Chat{ID: "1"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	calls := c.Calls()
+	if len(calls) != 2 {
+		t.Fatalf("expected 2 calls, got %v", calls)
+	}
+	send := c.CallsTo("SendMessage")[0]
+	if send.Args[0] != tbot.ChatID("1") || send.Args[1] != "<b>hello</b>" || send.Params.Get("parse_mode") != "HTML" {
+		t.Errorf("unexpected call %+v", send)
+	}
+	if del := c.CallsTo("DeleteMessage")[0]; del.Args[1] != 42 {
+		t.Errorf("unexpected call %+v", del)
+	}
+
+	c.Reset()
+	c.SendMessageFunc = nil
+	c.DeleteMessageFunc = func(chatID tbot.ChatID, messageID int) error {
+		return errors.New("message not found")
+	}
+	err = g.handle(&tbot.Message{Chat: tbot.Chat{ID: "1"}})
+	if err == nil || err.Error() != "message not found" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if len(c.Calls()) != 2 {
+		t.Errorf("calls must be recorded after reset, got %v", c.Calls())
+	}
+}
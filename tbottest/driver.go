@@ -0,0 +1,250 @@
+package tbottest
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	"github.com/yanzay/tbot/v2"
+)
+
+/*
+Driver pushes synthetic updates through tbot.Server routing and middlewares synchronously
+and collects the resulting Bot API calls:
+
+	d := tbottest.NewDriver(t)
+	defer d.Close()
+	d.Bot.HandleMessage("/start", func(m *tbot.Message) {
+		d.Bot.Client().SendMessage(m.Chat.ID, "welcome")
+	})
+	user := tbottest.User(7, "alice")
+	d.SendText(user, tbottest.PrivateChat(user), "/start").ExpectReply("welcome")
+*/
+type Driver struct {
+	t   testing.TB
+	API *Server
+	Bot *tbot.Server
+
+	mu       sync.Mutex
+	updateID int
+	queryID  int
+}
+
+// NewDriver creates fake Bot API and tbot.Server connected to it, call Close when the test finishes
+func NewDriver(t testing.TB, options ...tbot.ServerOption) *Driver {
+	t.Helper()
+	api := NewServer("TEST_TOKEN")
+	options = append([]tbot.ServerOption{tbot.WithBaseURL(api.URL()), tbot.WithHTTPClient(api.HTTPClient())}, options...)
+	return &Driver{
+		t:   t,
+		API: api,
+		Bot: tbot.New("TEST_TOKEN", options...),
+	}
+}
+
+// Close shuts down the fake Bot API
+func (d *Driver) Close() {
+	d.API.Close()
+}
+
+// User creates a user with given ID and username
+func User(id int64, username string) *tbot.User {
+	return &tbot.User{ID: id, FirstName: username, Username: username, LanguageCode: "en"}
+}
+
+// PrivateChat returns private chat with the user
+func PrivateChat(user *tbot.User) tbot.Chat {
+	return tbot.Chat{ID: tbot.NewChatID(user.ID), Type: "private", FirstName: user.FirstName, Username: user.Username}
+}
+
+// GroupChat returns supergroup with given ID (usually negative) and title
+func GroupChat(id int64, title string) tbot.Chat {
+	return tbot.Chat{ID: tbot.NewChatID(id), Type: "supergroup", Title: title}
+}
+
+// Send runs the update through the bot synchronously and returns Bot API calls made while handling it
+func (d *Driver) Send(update *tbot.Update) *Result {
+	d.t.Helper()
+	d.mu.Lock()
+	if update.UpdateID == 0 {
+		d.updateID++
+		update.UpdateID = d.updateID
+	}
+	d.mu.Unlock()
+	// round-trip through JSON to get the update as the bot would receive it
+	data, err := json.Marshal(update)
+	if err != nil {
+		d.t.Fatalf("unable to encode update: %v", err)
+	}
+	received := &tbot.Update{}
+	err = json.Unmarshal(data, received)
+	if err != nil {
+		d.t.Fatalf("unable to decode update: %v", err)
+	}
+	before := len(d.API.Calls())
+	d.Bot.ProcessUpdate(received)
+	return &Result{t: d.t, Update: received, Calls: d.API.Calls()[before:]}
+}
+
+// SendText sends text message from the user to the chat. Message is stored in the fake API,
+// so the bot can reply to it or forward it. Bot commands at the beginning of the text get an entity.
+func (d *Driver) SendText(from *tbot.User, chat tbot.Chat, text string) *Result {
+	d.t.Helper()
+	msg := &tbot.Message{From: from, Chat: chat, Date: time.Now().Unix(), Text: text}
+	if strings.HasPrefix(text, "/") {
+		command := strings.Fields(text)[0]
+		msg.Entities = []*tbot.MessageEntity{{
+			Type:   tbot.EntityBotCommand,
+			Length: len(utf16.Encode([]rune(command))),
+		}}
+	}
+	d.API.AddMessage(msg)
+	return d.Send(&tbot.Update{Message: msg})
+}
+
+// PressButton sends callback query with data from the user pressing inline button of the message
+func (d *Driver) PressButton(from *tbot.User, msg *tbot.Message, data string) *Result {
+	d.t.Helper()
+	return d.Send(&tbot.Update{CallbackQuery: &tbot.CallbackQuery{
+		ID:           d.nextQueryID(),
+		From:         from,
+		Message:      msg,
+		ChatInstance: string(msg.Chat.ID),
+		Data:         data,
+	}})
+}
+
+// InlineQuery sends inline query from the user
+func (d *Driver) InlineQuery(from *tbot.User, query string) *Result {
+	d.t.Helper()
+	return d.Send(&tbot.Update{InlineQuery: &tbot.InlineQuery{
+		ID:    d.nextQueryID(),
+		From:  from,
+		Query: query,
+	}})
+}
+
+func (d *Driver) nextQueryID() string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.queryID++
+	return fmt.Sprint(d.queryID)
+}
+
+// Result holds Bot API calls made while handling one update
+type Result struct {
+	t      testing.TB
+	Update *tbot.Update
+	Calls  []Call
+}
+
+// ExpectCall asserts that the method was called and returns the first call
+func (r *Result) ExpectCall(method string) Call {
+	r.t.Helper()
+	for _, call := range r.Calls {
+		if call.Method == method {
+			return call
+		}
+	}
+	r.t.Errorf("expected %s call, got %s", method, r.methods())
+	return Call{Method: method}
+}
+
+// ExpectNoCalls asserts that the bot made no Bot API calls
+func (r *Result) ExpectNoCalls() {
+	r.t.Helper()
+	if len(r.Calls) > 0 {
+		r.t.Errorf("expected no calls, got %s", r.methods())
+	}
+}
+
+// ExpectReply asserts that the bot sent message with the text to the chat of the update
+func (r *Result) ExpectReply(text string) Call {
+	r.t.Helper()
+	return r.expectMessage(func(got string) bool { return got == text }, fmt.Sprintf("%q", text))
+}
+
+// ExpectReplyMatching asserts that the bot sent message matching the pattern to the chat of the update
+func (r *Result) ExpectReplyMatching(pattern string) Call {
+	r.t.Helper()
+	rx := regexp.MustCompile(pattern)
+	return r.expectMessage(rx.MatchString, "matching "+pattern)
+}
+
+// ExpectCallbackAnswered asserts that the callback query of the update was answered
+func (r *Result) ExpectCallbackAnswered() Call {
+	r.t.Helper()
+	if r.Update.CallbackQuery == nil {
+		r.t.Errorf("update has no callback query")
+		return Call{}
+	}
+	for _, call := range r.Calls {
+		if call.Method == "answerCallbackQuery" && call.Params.Get("callback_query_id") == r.Update.CallbackQuery.ID {
+			return call
+		}
+	}
+	r.t.Errorf("callback query %s is not answered, got %s", r.Update.CallbackQuery.ID, r.methods())
+	return Call{}
+}
+
+// ExpectInlineQueryAnswered asserts that the inline query of the update was answered
+func (r *Result) ExpectInlineQueryAnswered() Call {
+	r.t.Helper()
+	if r.Update.InlineQuery == nil {
+		r.t.Errorf("update has no inline query")
+		return Call{}
+	}
+	for _, call := range r.Calls {
+		if call.Method == "answerInlineQuery" && call.Params.Get("inline_query_id") == r.Update.InlineQuery.ID {
+			return call
+		}
+	}
+	r.t.Errorf("inline query %s is not answered, got %s", r.Update.InlineQuery.ID, r.methods())
+	return Call{}
+}
+
+func (r *Result) expectMessage(match func(string) bool, want string) Call {
+	chatID := r.chatID()
+	for _, call := range r.Calls {
+		if call.Method != "sendMessage" {
+			continue
+		}
+		if chatID != "" && call.Params.Get("chat_id") != chatID {
+			continue
+		}
+		if match(call.Params.Get("text")) {
+			return call
+		}
+	}
+	var texts []string
+	for _, call := range r.Calls {
+		if call.Method == "sendMessage" {
+			texts = append(texts, fmt.Sprintf("%q", call.Params.Get("text")))
+		}
+	}
+	r.t.Errorf("expected reply %s to chat %s, got [%s]", want, chatID, strings.Join(texts, ", "))
+	return Call{}
+}
+
+func (r *Result) chatID() string {
+	switch {
+	case r.Update.Message != nil:
+		return string(r.Update.Message.Chat.ID)
+	case r.Update.CallbackQuery != nil && r.Update.CallbackQuery.Message != nil:
+		return string(r.Update.CallbackQuery.Message.Chat.ID)
+	}
+	return ""
+}
+
+func (r *Result) methods() string {
+	methods := make([]string, len(r.Calls))
+	for i, call := range r.Calls {
+		methods[i] = call.Method
+	}
+	return "[" + strings.Join(methods, ", ") + "]"
+}
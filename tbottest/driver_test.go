@@ -0,0 +1,37 @@
+package tbottest_test
+
+import (
+	"testing"
+
+	"github.com/yanzay/tbot/v2"
+	"github.com/yanzay/tbot/v2/tbottest"
+)
+
+func TestDriver(t *testing.T) {
+	d := tbottest.NewDriver(t)
+	defer d.Close()
+	c := d.Bot.Client()
+	d.Bot.HandleMessage("/start", func(m *tbot.Message) {
+		kb := tbot.NewKeyboardMaker().Add(tbot.InlineCallbackButton("Go", "go")).Build()
+		c.SendMessage(m.Chat.ID, "welcome, "+m.From.Username, tbot.OptInlineKeyboardMarkup(kb))
+	})
+	d.Bot.HandleCallback(func(cq *tbot.CallbackQuery) {
+		c.AnswerCallbackQuery(cq.ID, tbot.OptText("gone"))
+		c.EditMessageText(cq.Message.Chat.ID, cq.Message.MessageID, "done")
+	})
+
+	user := tbottest.User(7, "alice")
+	res := d.SendText(user, tbottest.PrivateChat(user), "/start")
+	res.ExpectReply("welcome, alice")
+
+	sent := d.API.Messages("7")
+	msg := sent[len(sent)-1]
+	res = d.PressButton(user, msg, "go")
+	res.ExpectCallbackAnswered()
+	res.ExpectCall("editMessageText")
+	if stored, _ := d.API.Message("7", msg.MessageID); stored.Text != "done" {
+		t.Fatalf("message is not edited: %q", stored.Text)
+	}
+
+	d.InlineQuery(user, "query").ExpectNoCalls()
+}
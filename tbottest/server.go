@@ -0,0 +1,578 @@
+/*
+Package tbottest provides an in-process fake Telegram Bot API for testing bots without network access.
+
+The fake records every method call with decoded parameters, keeps chats and messages in memory,
+serves getUpdates from a queue and can inject API errors:
+
+	api := tbottest.NewServer("TOKEN")
+	defer api.Close()
+	bot := tbot.New("TOKEN", tbot.WithBaseURL(api.URL()), tbot.WithHTTPClient(api.HTTPClient()))
+	bot.HandleMessage("/start", func(m *tbot.Message) {
+		bot.Client().SendMessage(m.Chat.ID, "hello")
+	})
+	go bot.Start()
+	api.PushUpdate(&tbot.Update{Message: &tbot.Message{Text: "/start", Chat: tbot.Chat{ID: "1"}}})
+	call, err := api.WaitForCall("sendMessage", time.Second)
+*/
+package tbottest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yanzay/tbot/v2"
+)
+
+// Call is a recorded Bot API method call
+type Call struct {
+	Method string
+	Params url.Values
+	Files  map[string]string // form field to uploaded file name
+	Body   json.RawMessage   // request body for calls sent as JSON
+	Time   time.Time
+}
+
+// APIError is an error returned by the fake instead of the method result
+type APIError struct {
+	Code        int
+	Description string
+	RetryAfter  int
+}
+
+// MethodHandler computes result of a method call, see Server.Handle
+type MethodHandler func(call Call) (interface{}, *APIError)
+
+// Server is a fake Telegram Bot API server
+type Server struct {
+	token string
+	srv   *httptest.Server
+	done  chan struct{}
+
+	mu       sync.Mutex
+	me       tbot.User
+	calls    []Call
+	callSent chan struct{}
+	chats    map[string]*chatState
+	updates  []*tbot.Update
+	queued   chan struct{}
+	lastID   int
+	errors   map[string][]*APIError
+	handlers map[string]MethodHandler
+}
+
+type chatState struct {
+	chat     tbot.Chat
+	messages map[int]*tbot.Message
+	nextID   int
+}
+
+// NewServer starts new fake Bot API server accepting given token
+func NewServer(token string) *Server {
+	s := &Server{
+		token:    token,
+		done:     make(chan struct{}),
+		me:       tbot.User{ID: 1, IsBot: true, FirstName: "Test", Username: "test_bot"},
+		callSent: make(chan struct{}),
+		chats:    make(map[string]*chatState),
+		queued:   make(chan struct{}),
+		errors:   make(map[string][]*APIError),
+		handlers: make(map[string]MethodHandler),
+	}
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// URL returns base URL of the server, use it with tbot.WithBaseURL
+func (s *Server) URL() string {
+	return s.srv.URL
+}
+
+// HTTPClient returns http client configured for the server
+func (s *Server) HTTPClient() *http.Client {
+	return s.srv.Client()
+}
+
+// Client returns tbot.Client connected to the server
+func (s *Server) Client() *tbot.Client {
+	return tbot.NewClient(s.token, s.HTTPClient(), s.URL())
+}
+
+// Close stops the server, pending getUpdates requests return immediately
+func (s *Server) Close() {
+	close(s.done)
+	s.srv.Close()
+}
+
+// SetMe sets the bot user returned by getMe and used as author of sent messages
+func (s *Server) SetMe(me tbot.User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.me = me
+}
+
+// Handle overrides result of the method. Handler is called instead of the built-in behaviour.
+func (s *Server) Handle(method string, handler MethodHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[method] = handler
+}
+
+// FailNext makes the next call of the method return the error,
+// e.g. FailNext("sendMessage", &APIError{Code: 429, Description: "Too Many Requests", RetryAfter: 5}).
+// Errors are queued, each one is returned once.
+func (s *Server) FailNext(method string, err *APIError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errors[method] = append(s.errors[method], err)
+}
+
+// PushUpdate adds update to the getUpdates queue, UpdateID is assigned if empty
+func (s *Server) PushUpdate(update *tbot.Update) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if update.UpdateID == 0 {
+		update.UpdateID = s.lastID + 1
+	}
+	if update.UpdateID > s.lastID {
+		s.lastID = update.UpdateID
+	}
+	s.updates = append(s.updates, update)
+	close(s.queued)
+	s.queued = make(chan struct{})
+}
+
+// Calls returns all recorded calls
+func (s *Server) Calls() []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Call(nil), s.calls...)
+}
+
+// CallsTo returns recorded calls of the method
+func (s *Server) CallsTo(method string) []Call {
+	var calls []Call
+	for _, call := range s.Calls() {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// ResetCalls forgets all recorded calls
+func (s *Server) ResetCalls() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = nil
+}
+
+// WaitForCall waits for the first call of the method made after the last ResetCalls
+func (s *Server) WaitForCall(method string, timeout time.Duration) (Call, error) {
+	deadline := time.After(timeout)
+	for {
+		s.mu.Lock()
+		sent := s.callSent
+		for _, call := range s.calls {
+			if call.Method == method {
+				s.mu.Unlock()
+				return call, nil
+			}
+		}
+		s.mu.Unlock()
+		select {
+		case <-sent:
+		case <-deadline:
+			return Call{}, fmt.Errorf("no %s call in %v", method, timeout)
+		}
+	}
+}
+
+// Message returns copy of the message stored in the chat
+func (s *Server) Message(chatID tbot.ChatID, messageID int) (*tbot.Message, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	chat, ok := s.chats[string(chatID)]
+	if !ok {
+		return nil, false
+	}
+	msg, ok := chat.messages[messageID]
+	if !ok {
+		return nil, false
+	}
+	return snapshot(msg), true
+}
+
+// Messages returns copies of all messages stored in the chat, ordered by ID
+func (s *Server) Messages(chatID tbot.ChatID) []*tbot.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	chat, ok := s.chats[string(chatID)]
+	if !ok {
+		return nil
+	}
+	var msgs []*tbot.Message
+	for id := 1; id < chat.nextID; id++ {
+		if msg, ok := chat.messages[id]; ok {
+			msgs = append(msgs, snapshot(msg))
+		}
+	}
+	return msgs
+}
+
+// AddMessage stores the message in its chat, e.g. to make it editable by the bot.
+// MessageID is assigned if empty.
+func (s *Server) AddMessage(msg *tbot.Message) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	chat := s.chat(string(msg.Chat.ID))
+	if msg.MessageID == 0 {
+		msg.MessageID = chat.nextID
+	}
+	if msg.MessageID >= chat.nextID {
+		chat.nextID = msg.MessageID + 1
+	}
+	chat.messages[msg.MessageID] = msg
+}
+
+type apiResponse struct {
+	OK          bool                `json:"ok"`
+	Result      interface{}         `json:"result,omitempty"`
+	ErrorCode   int                 `json:"error_code,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Parameters  *responseParameters `json:"parameters,omitempty"`
+}
+
+type responseParameters struct {
+	RetryAfter int `json:"retry_after,omitempty"`
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	prefix := "/bot" + s.token + "/"
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		writeResponse(w, nil, &APIError{Code: http.StatusUnauthorized, Description: "Unauthorized"})
+		return
+	}
+	call, err := decodeCall(strings.TrimPrefix(r.URL.Path, prefix), r)
+	if err != nil {
+		writeResponse(w, nil, &APIError{Code: http.StatusBadRequest, Description: "Bad Request: " + err.Error()})
+		return
+	}
+	if call.Method == "getUpdates" {
+		s.serveUpdates(w, r, call)
+		return
+	}
+	s.record(call)
+	result, apiErr := s.handle(call)
+	writeResponse(w, result, apiErr)
+}
+
+func decodeCall(method string, r *http.Request) (Call, error) {
+	call := Call{Method: method, Params: url.Values{}, Files: map[string]string{}, Time: time.Now()}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		return decodeJSONCall(call, r)
+	}
+	err := r.ParseForm()
+	if err != nil {
+		return call, err
+	}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		err = r.ParseMultipartForm(32 << 20)
+		if err != nil {
+			return call, err
+		}
+		for field, headers := range r.MultipartForm.File {
+			call.Files[field] = headers[0].Filename
+		}
+	}
+	for k, v := range r.Form {
+		call.Params[k] = v
+	}
+	return call, nil
+}
+
+// decodeJSONCall decodes call sent as JSON, top level fields are set to Params
+// as strings or JSON of nested values, so they look the same as in form calls
+func decodeJSONCall(call Call, r *http.Request) (Call, error) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return call, err
+	}
+	fields := map[string]json.RawMessage{}
+	err = json.Unmarshal(body, &fields)
+	if err != nil {
+		return call, err
+	}
+	call.Body = body
+	for name, value := range fields {
+		var s string
+		if json.Unmarshal(value, &s) == nil {
+			call.Params.Set(name, s)
+			continue
+		}
+		if string(value) != "null" {
+			call.Params.Set(name, string(value))
+		}
+	}
+	return call, nil
+}
+
+func (s *Server) record(call Call) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = append(s.calls, call)
+	close(s.callSent)
+	s.callSent = make(chan struct{})
+}
+
+func (s *Server) handle(call Call) (interface{}, *APIError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if errs := s.errors[call.Method]; len(errs) > 0 {
+		s.errors[call.Method] = errs[1:]
+		return nil, errs[0]
+	}
+	if handler, ok := s.handlers[call.Method]; ok {
+		s.mu.Unlock()
+		defer s.mu.Lock()
+		return handler(call)
+	}
+	p := call.Params
+	switch call.Method {
+	case "getMe":
+		me := s.me
+		return &me, nil
+	case "sendMessage", "sendPhoto", "sendAudio", "sendDocument", "sendVideo", "sendAnimation",
+		"sendVoice", "sendVideoNote", "sendLocation", "sendVenue", "sendContact", "sendPoll",
+		"sendDice", "sendSticker", "sendGame", "sendInvoice":
+		if p.Get("chat_id") == "" {
+			return nil, badRequest("chat_id is empty")
+		}
+		return snapshot(s.newMessage(p.Get("chat_id"), p)), nil
+	case "forwardMessage", "copyMessage":
+		orig, apiErr := s.findMessage(p.Get("from_chat_id"), p.Get("message_id"))
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		msg := s.newMessage(p.Get("chat_id"), p)
+		msg.Text, msg.Caption = orig.Text, orig.Caption
+		if call.Method == "copyMessage" {
+			if _, ok := p["caption"]; ok {
+				msg.Caption = p.Get("caption")
+			}
+			return &tbot.MessageID{MessageID: msg.MessageID}, nil
+		}
+		msg.ForwardFrom = orig.From
+		msg.ForwardDate = orig.Date
+		return snapshot(msg), nil
+	case "forwardMessages", "copyMessages":
+		ids, apiErr := messageIDs(p)
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		sent := []tbot.MessageID{}
+		for _, id := range ids {
+			orig, apiErr := s.findMessage(p.Get("from_chat_id"), strconv.Itoa(id))
+			if apiErr != nil {
+				continue
+			}
+			msg := s.newMessage(p.Get("chat_id"), url.Values{})
+			msg.Text, msg.Caption = orig.Text, orig.Caption
+			if call.Method == "forwardMessages" {
+				msg.ForwardFrom = orig.From
+				msg.ForwardDate = orig.Date
+			} else if p.Get("remove_caption") == "true" {
+				msg.Caption = ""
+			}
+			sent = append(sent, tbot.MessageID{MessageID: msg.MessageID})
+		}
+		return sent, nil
+	case "editMessageText", "editMessageCaption", "editMessageReplyMarkup":
+		if p.Get("inline_message_id") != "" {
+			return true, nil
+		}
+		msg, apiErr := s.findMessage(p.Get("chat_id"), p.Get("message_id"))
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		switch call.Method {
+		case "editMessageText":
+			msg.Text = p.Get("text")
+		case "editMessageCaption":
+			msg.Caption = p.Get("caption")
+		}
+		msg.ReplyMarkup = inlineMarkup(p)
+		msg.EditDate = time.Now().Unix()
+		return snapshot(msg), nil
+	case "deleteMessage":
+		msg, apiErr := s.findMessage(p.Get("chat_id"), p.Get("message_id"))
+		if apiErr != nil {
+			return nil, badRequest("message to delete not found")
+		}
+		delete(s.chats[p.Get("chat_id")].messages, msg.MessageID)
+		return true, nil
+	case "deleteMessages":
+		ids, apiErr := messageIDs(p)
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		if chat, ok := s.chats[p.Get("chat_id")]; ok {
+			for _, id := range ids {
+				delete(chat.messages, id)
+			}
+		}
+		return true, nil
+	case "getChat":
+		chat := s.chat(p.Get("chat_id")).chat
+		return &chat, nil
+	}
+	return true, nil
+}
+
+func (s *Server) serveUpdates(w http.ResponseWriter, r *http.Request, call Call) {
+	offset, _ := strconv.Atoi(call.Params.Get("offset"))
+	timeout, _ := strconv.Atoi(call.Params.Get("timeout"))
+	wait := time.After(time.Duration(timeout) * time.Second)
+	for {
+		s.mu.Lock()
+		if errs := s.errors[call.Method]; len(errs) > 0 {
+			s.errors[call.Method] = errs[1:]
+			s.mu.Unlock()
+			writeResponse(w, nil, errs[0])
+			return
+		}
+		var pending []*tbot.Update
+		for _, up := range s.updates {
+			if up.UpdateID >= offset {
+				pending = append(pending, up)
+			}
+		}
+		s.updates = pending
+		queued := s.queued
+		s.mu.Unlock()
+		if len(pending) > 0 {
+			writeResponse(w, pending, nil)
+			return
+		}
+		select {
+		case <-queued:
+		case <-wait:
+			writeResponse(w, []*tbot.Update{}, nil)
+			return
+		case <-r.Context().Done():
+			return
+		case <-s.done:
+			writeResponse(w, []*tbot.Update{}, nil)
+			return
+		}
+	}
+}
+
+func (s *Server) chat(chatID string) *chatState {
+	chat, ok := s.chats[chatID]
+	if !ok {
+		chatType := "private"
+		if strings.HasPrefix(chatID, "-") || strings.HasPrefix(chatID, "@") {
+			chatType = "supergroup"
+		}
+		chat = &chatState{
+			chat:     tbot.Chat{ID: tbot.ChatID(chatID), Type: chatType},
+			messages: make(map[int]*tbot.Message),
+			nextID:   1,
+		}
+		s.chats[chatID] = chat
+	}
+	return chat
+}
+
+func (s *Server) newMessage(chatID string, p url.Values) *tbot.Message {
+	chat := s.chat(chatID)
+	me := s.me
+	msg := &tbot.Message{
+		MessageID:   chat.nextID,
+		From:        &me,
+		Date:        time.Now().Unix(),
+		Chat:        chat.chat,
+		Text:        p.Get("text"),
+		Caption:     p.Get("caption"),
+		ReplyMarkup: inlineMarkup(p),
+	}
+	if replyTo, err := strconv.Atoi(p.Get("reply_to_message_id")); err == nil {
+		msg.ReplyToMessage = chat.messages[replyTo]
+	}
+	chat.messages[msg.MessageID] = msg
+	chat.nextID++
+	return msg
+}
+
+func (s *Server) findMessage(chatID, messageID string) (*tbot.Message, *APIError) {
+	id, err := strconv.Atoi(messageID)
+	if err != nil {
+		return nil, badRequest("message_id is invalid")
+	}
+	chat, ok := s.chats[chatID]
+	if !ok {
+		return nil, badRequest("chat not found")
+	}
+	msg, ok := chat.messages[id]
+	if !ok {
+		return nil, badRequest("message not found")
+	}
+	return msg, nil
+}
+
+// messageIDs decodes message_ids parameter of bulk methods and checks it the way Bot API does
+func messageIDs(p url.Values) ([]int, *APIError) {
+	var ids []int
+	err := json.Unmarshal([]byte(p.Get("message_ids")), &ids)
+	if err != nil || len(ids) == 0 || len(ids) > tbot.MaxMessageIDs {
+		return nil, badRequest("message_ids are invalid")
+	}
+	for i := 1; i < len(ids); i++ {
+		if ids[i] <= ids[i-1] {
+			return nil, badRequest("message identifiers must be in strictly increasing order")
+		}
+	}
+	return ids, nil
+}
+
+func inlineMarkup(p url.Values) *tbot.InlineKeyboardMarkup {
+	markup := &tbot.InlineKeyboardMarkup{}
+	err := json.Unmarshal([]byte(p.Get("reply_markup")), markup)
+	if err != nil || markup.InlineKeyboard == nil {
+		return nil
+	}
+	return markup
+}
+
+// snapshot copies the stored message, it's used after the lock is released
+// and the stored message can be edited by concurrent calls meanwhile
+func snapshot(msg *tbot.Message) *tbot.Message {
+	m := *msg
+	return &m
+}
+
+func badRequest(description string) *APIError {
+	return &APIError{Code: http.StatusBadRequest, Description: "Bad Request: " + description}
+}
+
+func writeResponse(w http.ResponseWriter, result interface{}, apiErr *APIError) {
+	w.Header().Set("Content-Type", "application/json")
+	resp := apiResponse{OK: apiErr == nil, Result: result}
+	if apiErr != nil {
+		resp.ErrorCode = apiErr.Code
+		resp.Description = apiErr.Description
+		if apiErr.RetryAfter > 0 {
+			resp.Parameters = &responseParameters{RetryAfter: apiErr.RetryAfter}
+		}
+		w.WriteHeader(apiErr.Code)
+	}
+	json.NewEncoder(w).Encode(resp)
+}
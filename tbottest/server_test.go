@@ -0,0 +1,136 @@
+package tbottest_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yanzay/tbot/v2"
+	"github.com/yanzay/tbot/v2/tbottest"
+)
+
+const token = "TOKEN"
+
+func TestSendEditDelete(t *testing.T) {
+	api := tbottest.NewServer(token)
+	defer api.Close()
+	c := api.Client()
+
+	msg, err := c.SendMessage("10", "hello")
+	if err != nil {
+		t.Fatalf("error on sendMessage: %v", err)
+	}
+	if msg.MessageID != 1 || msg.Chat.ID != "10" {
+		t.Fatalf("wrong message: %+v", msg)
+	}
+	_, err = c.EditMessageText("10", msg.MessageID, "edited")
+	if err != nil {
+		t.Fatalf("error on editMessageText: %v", err)
+	}
+	stored, ok := api.Message("10", msg.MessageID)
+	if !ok || stored.Text != "edited" {
+		t.Fatalf("message is not edited: %+v", stored)
+	}
+	err = c.DeleteMessage("10", msg.MessageID)
+	if err != nil {
+		t.Fatalf("error on deleteMessage: %v", err)
+	}
+	err = c.DeleteMessage("10", msg.MessageID)
+	if err == nil {
+		t.Fatalf("expected error on deleting missing message")
+	}
+	calls := api.CallsTo("editMessageText")
+	if len(calls) != 1 || calls[0].Params.Get("text") != "edited" {
+		t.Fatalf("wrong recorded calls: %+v", calls)
+	}
+}
+
+func TestJSONCall(t *testing.T) {
+	api := tbottest.NewServer(token)
+	defer api.Close()
+	c := api.Client()
+
+	markup := tbot.OptInlineKeyboardMarkup(&tbot.InlineKeyboardMarkup{
+		InlineKeyboard: [][]tbot.InlineKeyboardButton{{{Text: "ok", CallbackData: "ok"}}},
+	})
+	msg, err := c.SendMessage(tbot.NewChatID(-100123), "hello", markup)
+	if err != nil {
+		t.Fatalf("error on sendMessage: %v", err)
+	}
+	if msg.Chat.ID != "-100123" || msg.Text != "hello" {
+		t.Fatalf("wrong message: %+v", msg)
+	}
+	call := api.CallsTo("sendMessage")[0]
+	if call.Body == nil {
+		t.Fatalf("call body is expected for JSON request")
+	}
+	if got := call.Params.Get("reply_markup"); got != `{"inline_keyboard":[[{"text":"ok","callback_data":"ok"}]]}` {
+		t.Errorf("wrong reply_markup param: %s", got)
+	}
+}
+
+func TestFailNext(t *testing.T) {
+	api := tbottest.NewServer(token)
+	defer api.Close()
+	c := api.Client()
+
+	api.FailNext("sendMessage", &tbottest.APIError{Code: 403, Description: "Forbidden: bot was blocked by the user"})
+	_, err := c.SendMessage("10", "hello")
+	if err == nil {
+		t.Fatalf("expected injected error")
+	}
+	_, err = c.SendMessage("10", "hello")
+	if err != nil {
+		t.Fatalf("error is returned twice: %v", err)
+	}
+}
+
+func TestServerEndToEnd(t *testing.T) {
+	api := tbottest.NewServer(token)
+	defer api.Close()
+	bot := tbot.New(token, tbot.WithBaseURL(api.URL()), tbot.WithHTTPClient(api.HTTPClient()))
+	bot.HandleMessage("/start", func(m *tbot.Message) {
+		bot.Client().SendMessage(m.Chat.ID, "welcome")
+	})
+	go bot.Start()
+	defer bot.Stop()
+
+	api.PushUpdate(&tbot.Update{Message: &tbot.Message{
+		MessageID: 1,
+		Text:      "/start",
+		Chat:      tbot.Chat{ID: "42", Type: "private"},
+	}})
+	call, err := api.WaitForCall("sendMessage", 5*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if call.Params.Get("chat_id") != "42" || call.Params.Get("text") != "welcome" {
+		t.Fatalf("wrong reply: %v", call.Params)
+	}
+}
+
+func TestConcurrentEdits(t *testing.T) {
+	api := tbottest.NewServer(token)
+	defer api.Close()
+	c := api.Client()
+	msg, err := c.SendMessage("10", "hello")
+	if err != nil {
+		t.Fatalf("error on sendMessage: %v", err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := c.EditMessageText("10", msg.MessageID, fmt.Sprintf("edit %d", i))
+			if err != nil {
+				t.Errorf("error on editMessageText: %v", err)
+			}
+			if stored, ok := api.Message("10", msg.MessageID); !ok || stored.Text == "" {
+				t.Errorf("message is not stored")
+			}
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,95 @@
+package tbot
+
+import "context"
+
+// Tracer receives tracing hooks from Server and Client, see WithTracer.
+// Package github.com/yanzay/tbot/v2/otel adapts it to OpenTelemetry.
+type Tracer interface {
+	// StartUpdate starts span covering middlewares and handlers processing the update
+	StartUpdate(ctx context.Context, update *Update) (context.Context, Span)
+	// StartRequest starts span for Bot API call, ctx is the context passed to Client.WithContext
+	StartRequest(ctx context.Context, method string) (context.Context, Span)
+}
+
+// Span is a traced operation started by Tracer
+type Span interface {
+	// SetAttribute adds key/value information to the span, value is a string, bool, int or int64
+	SetAttribute(key string, value interface{})
+	// SetError marks the span as failed
+	SetError(err error)
+	// End finishes the span
+	End()
+}
+
+type nopTracer struct{}
+
+func (nopTracer) StartUpdate(ctx context.Context, _ *Update) (context.Context, Span) {
+	return ctx, nopSpan{}
+}
+
+func (nopTracer) StartRequest(ctx context.Context, _ string) (context.Context, Span) {
+	return ctx, nopSpan{}
+}
+
+type nopSpan struct{}
+
+func (nopSpan) SetAttribute(string, interface{}) {}
+func (nopSpan) SetError(error)                   {}
+func (nopSpan) End()                             {}
+
+// updateContext is embedded into update payloads, so handlers have access to the context of the update
+type updateContext struct {
+	ctx context.Context
+}
+
+// Context returns context of the update being processed. Pass it to Client.WithContext
+// to make Bot API calls part of the update trace and to cancel them with the update.
+func (c *updateContext) Context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+// WithContext returns shallow copy of the update with ctx, it's intended for middlewares
+func (u *Update) WithContext(ctx context.Context) *Update {
+	up := *u
+	up.ctx = ctx
+	return &up
+}
+
+// propagateContext passes context of the update to its payload
+func (u *Update) propagateContext() {
+	ctx := u.Context()
+	for _, m := range []*Message{u.Message, u.EditedMessage, u.ChannelPost, u.EditedChannelPost} {
+		if m != nil {
+			m.ctx = ctx
+		}
+	}
+	if u.CallbackQuery != nil {
+		u.CallbackQuery.ctx = ctx
+		if u.CallbackQuery.Message != nil {
+			u.CallbackQuery.Message.ctx = ctx
+		}
+	}
+	switch {
+	case u.InlineQuery != nil:
+		u.InlineQuery.ctx = ctx
+	case u.ChosenInlineResult != nil:
+		u.ChosenInlineResult.ctx = ctx
+	case u.ShippingQuery != nil:
+		u.ShippingQuery.ctx = ctx
+	case u.PreCheckoutQuery != nil:
+		u.PreCheckoutQuery.ctx = ctx
+	case u.Poll != nil:
+		u.Poll.ctx = ctx
+	case u.PollAnswer != nil:
+		u.PollAnswer.ctx = ctx
+	case u.MyChatMember != nil:
+		u.MyChatMember.ctx = ctx
+	case u.ChatMember != nil:
+		u.ChatMember.ctx = ctx
+	case u.ChatJoinRequest != nil:
+		u.ChatJoinRequest.ctx = ctx
+	}
+}
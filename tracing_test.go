@@ -0,0 +1,76 @@
+package tbot_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/yanzay/tbot/v2"
+	"github.com/yanzay/tbot/v2/tbottest"
+)
+
+type spanKey struct{}
+
+type testSpan struct {
+	name   string
+	parent *testSpan
+	attrs  map[string]interface{}
+	err    error
+	ended  bool
+}
+
+func (s *testSpan) SetAttribute(key string, value interface{}) { s.attrs[key] = value }
+func (s *testSpan) SetError(err error)                         { s.err = err }
+func (s *testSpan) End()                                       { s.ended = true }
+
+type testTracer struct {
+	mu    sync.Mutex
+	spans []*testSpan
+}
+
+func (tr *testTracer) start(ctx context.Context, name string) (context.Context, tbot.Span) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	parent, _ := ctx.Value(spanKey{}).(*testSpan)
+	span := &testSpan{name: name, parent: parent, attrs: make(map[string]interface{})}
+	tr.spans = append(tr.spans, span)
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+func (tr *testTracer) StartUpdate(ctx context.Context, update *tbot.Update) (context.Context, tbot.Span) {
+	return tr.start(ctx, "update")
+}
+
+func (tr *testTracer) StartRequest(ctx context.Context, method string) (context.Context, tbot.Span) {
+	return tr.start(ctx, method)
+}
+
+func TestTracer(t *testing.T) {
+	tracer := &testTracer{}
+	d := tbottest.NewDriver(t, tbot.WithTracer(tracer))
+	defer d.Close()
+	d.Bot.HandleMessage("", func(m *tbot.Message) {
+		d.Bot.Client().WithContext(m.Context()).SendMessage(m.Chat.ID, "traced")
+		d.Bot.Client().SendMessage(m.Chat.ID, "untraced")
+	})
+	d.API.FailNext("sendMessage", &tbottest.APIError{Code: 400, Description: "Bad Request"})
+	user := tbottest.User(1, "alice")
+	d.SendText(user, tbottest.PrivateChat(user), "hi")
+
+	if len(tracer.spans) != 3 {
+		t.Fatalf("expected 3 spans, got %d", len(tracer.spans))
+	}
+	update, traced, untraced := tracer.spans[0], tracer.spans[1], tracer.spans[2]
+	if update.name != "update" || update.attrs["tbot.update_type"] != "message" || !update.ended {
+		t.Errorf("unexpected update span: %+v", update)
+	}
+	if traced.parent != update {
+		t.Errorf("API call span is not a child of the update span")
+	}
+	if traced.err == nil || traced.attrs["tbot.response_code"] != 400 || !traced.ended {
+		t.Errorf("unexpected failed call span: %+v", traced)
+	}
+	if untraced.parent != nil || untraced.err != nil {
+		t.Errorf("unexpected call span without context: %+v", untraced)
+	}
+}
@@ -0,0 +1,130 @@
+package tbot
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	defaultRequestTimeout = 30 * time.Second
+	defaultPollTimeout    = time.Hour
+	// pollTimeoutGrace is added to the long polling timeout to get deadline of getUpdates request
+	pollTimeoutGrace = 10 * time.Second
+)
+
+// transportOptions configure HTTP transport created by Server when WithHTTPClient isn't used
+type transportOptions struct {
+	set            bool
+	proxy          *url.URL
+	maxIdle        int
+	maxIdlePerHost int
+	idleTimeout    time.Duration
+	keepAlive      time.Duration
+}
+
+// WithRequestTimeout sets timeout of Bot API calls, 30 seconds by default, 0 means no timeout.
+// Uploads and long polling have their own timeouts.
+func WithRequestTimeout(timeout time.Duration) ServerOption {
+	return func(s *Server) {
+		s.requestTimeout = timeout
+	}
+}
+
+// WithUploadTimeout sets timeout of Bot API calls uploading files, no timeout by default
+func WithUploadTimeout(timeout time.Duration) ServerOption {
+	return func(s *Server) {
+		s.uploadTimeout = timeout
+	}
+}
+
+// WithPollTimeout sets long polling timeout, one hour by default.
+// The getUpdates request fails if there is no response in 10 seconds after it.
+func WithPollTimeout(timeout time.Duration) ServerOption {
+	return func(s *Server) {
+		s.pollTimeout = timeout
+	}
+}
+
+// WithPollingHTTPClient sets http client used for long polling only
+func WithPollingHTTPClient(client *http.Client) ServerOption {
+	return func(s *Server) {
+		s.pollHTTPClient = client
+	}
+}
+
+// WithUploadHTTPClient sets http client used for uploading files only
+func WithUploadHTTPClient(client *http.Client) ServerOption {
+	return func(s *Server) {
+		s.uploadClient = client
+	}
+}
+
+// WithProxy sends requests through HTTP, HTTPS or SOCKS5 proxy,
+// e.g. WithProxy(&url.URL{Scheme: "socks5", Host: "localhost:1080", User: url.UserPassword("user", "pass")}).
+// By default proxy is taken from HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+func WithProxy(proxy *url.URL) ServerOption {
+	return func(s *Server) {
+		s.transport.set = true
+		s.transport.proxy = proxy
+	}
+}
+
+// WithConnectionPool tunes connection pool: maximum number of idle connections in total and per host,
+// how long idle connections are kept and TCP keep-alive period. Zero values keep defaults of http.DefaultTransport.
+func WithConnectionPool(maxIdle, maxIdlePerHost int, idleTimeout, keepAlive time.Duration) ServerOption {
+	return func(s *Server) {
+		s.transport.set = true
+		s.transport.maxIdle = maxIdle
+		s.transport.maxIdlePerHost = maxIdlePerHost
+		s.transport.idleTimeout = idleTimeout
+		s.transport.keepAlive = keepAlive
+	}
+}
+
+// setupHTTPClients creates http clients which are not set with options
+func (s *Server) setupHTTPClients() {
+	if s.httpClient == nil {
+		s.httpClient = http.DefaultClient
+		if s.transport.set {
+			s.httpClient = &http.Client{Transport: s.transport.build()}
+		}
+	}
+	if s.pollHTTPClient == nil {
+		s.pollHTTPClient = s.httpClient
+	}
+	if s.uploadClient == nil {
+		s.uploadClient = s.httpClient
+	}
+}
+
+func (o transportOptions) build() *http.Transport {
+	keepAlive := 30 * time.Second
+	if o.keepAlive != 0 {
+		keepAlive = o.keepAlive
+	}
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: keepAlive}
+	t := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+	if o.proxy != nil {
+		t.Proxy = http.ProxyURL(o.proxy)
+	}
+	if o.maxIdle != 0 {
+		t.MaxIdleConns = o.maxIdle
+	}
+	if o.maxIdlePerHost != 0 {
+		t.MaxIdleConnsPerHost = o.maxIdlePerHost
+	}
+	if o.idleTimeout != 0 {
+		t.IdleConnTimeout = o.idleTimeout
+	}
+	return t
+}
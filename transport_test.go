@@ -0,0 +1,119 @@
+package tbot_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yanzay/tbot/v2"
+	"github.com/yanzay/tbot/v2/tbottest"
+)
+
+// recordingTransport records URLs of requests passed through it
+type recordingTransport struct {
+	mu   sync.Mutex
+	next http.RoundTripper
+	urls []*url.URL
+}
+
+func (t *recordingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	t.urls = append(t.urls, r.URL)
+	t.mu.Unlock()
+	return t.next.RoundTrip(r)
+}
+
+func (t *recordingTransport) recorded() []*url.URL {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]*url.URL(nil), t.urls...)
+}
+
+func TestRequestTimeout(t *testing.T) {
+	api := tbottest.NewServer("TOKEN")
+	defer api.Close()
+	api.Handle("getMe", func(tbottest.Call) (interface{}, *tbottest.APIError) {
+		time.Sleep(200 * time.Millisecond)
+		return &tbot.User{ID: 1}, nil
+	})
+	bot := tbot.New("TOKEN", tbot.WithBaseURL(api.URL()), tbot.WithHTTPClient(api.HTTPClient()),
+		tbot.WithRequestTimeout(50*time.Millisecond))
+	_, err := bot.Client().GetMe()
+	if err == nil {
+		t.Errorf("expected timeout error")
+	}
+}
+
+func TestSeparateHTTPClients(t *testing.T) {
+	api := tbottest.NewServer("TOKEN")
+	defer api.Close()
+	polling := &recordingTransport{next: api.HTTPClient().Transport}
+	uploads := &recordingTransport{next: api.HTTPClient().Transport}
+	bot := tbot.New("TOKEN",
+		tbot.WithBaseURL(api.URL()),
+		tbot.WithHTTPClient(api.HTTPClient()),
+		tbot.WithPollingHTTPClient(&http.Client{Transport: polling}),
+		tbot.WithUploadHTTPClient(&http.Client{Transport: uploads}),
+		tbot.WithPollTimeout(time.Second),
+	)
+	bot.HandleMessage("", func(m *tbot.Message) {
+		bot.Client().SendMessage(m.Chat.ID, "hi")
+	})
+	go bot.Start()
+	defer bot.Stop()
+	api.PushUpdate(&tbot.Update{Message: &tbot.Message{Text: "hello", Chat: tbot.Chat{ID: "1"}}})
+	if _, err := api.WaitForCall("sendMessage", time.Second); err != nil {
+		t.Fatal(err)
+	}
+	polled := polling.recorded()
+	if len(polled) == 0 {
+		t.Fatalf("getUpdates is not sent with polling client")
+	}
+	for _, u := range polled {
+		if !strings.HasSuffix(u.Path, "/getUpdates") || u.Query().Get("timeout") != "1" {
+			t.Errorf("unexpected polling request %s", u)
+		}
+	}
+
+	dir, err := ioutil.TempDir("", "tbot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "doc.txt")
+	ioutil.WriteFile(path, []byte("doc"), 0600)
+	_, err = bot.Client().SendDocumentFile("1", path)
+	if err != nil {
+		t.Fatalf("unable to send document: %v", err)
+	}
+	if uploaded := uploads.recorded(); len(uploaded) != 1 || !strings.HasSuffix(uploaded[0].Path, "/sendDocument") {
+		t.Errorf("expected one upload, got %v", uploaded)
+	}
+}
+
+func TestProxy(t *testing.T) {
+	var target string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		target = r.URL.Host
+		fmt.Fprint(w, `{"ok": true, "result": {"id": 1}}`)
+	}))
+	defer proxy.Close()
+	proxyURL, _ := url.Parse(proxy.URL)
+	bot := tbot.New("TOKEN", tbot.WithBaseURL("http://api.example"), tbot.WithProxy(proxyURL),
+		tbot.WithConnectionPool(10, 2, time.Minute, 0))
+	_, err := bot.Client().GetMe()
+	if err != nil {
+		t.Fatalf("unable to call through proxy: %v", err)
+	}
+	if target != "api.example" {
+		t.Errorf("request is not proxied, target %q", target)
+	}
+}
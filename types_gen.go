@@ -0,0 +1,1841 @@
+// Code generated by internal/gen from spec/botapi.json (Bot API 6.5); DO NOT EDIT.
+
+package tbot
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// User represents a Telegram user or bot
+type User struct {
+	ID                      int64  `json:"id"`                                    // Unique identifier for this user or bot
+	IsBot                   bool   `json:"is_bot"`                                // True, if this user is a bot
+	FirstName               string `json:"first_name"`                            // User's or bot's first name
+	LastName                string `json:"last_name,omitempty"`                   // User's or bot's last name
+	Username                string `json:"username,omitempty"`                    // User's or bot's username
+	LanguageCode            string `json:"language_code,omitempty"`               // IETF language tag of the user's language
+	IsPremium               bool   `json:"is_premium,omitempty"`                  // True, if this user is a Telegram Premium user
+	AddedToAttachmentMenu   bool   `json:"added_to_attachment_menu,omitempty"`    // True, if this user added the bot to the attachment menu
+	CanJoinGroups           bool   `json:"can_join_groups,omitempty"`             // True, if the bot can be invited to groups
+	CanReadAllGroupMessages bool   `json:"can_read_all_group_messages,omitempty"` // True, if privacy mode is disabled for the bot
+	SupportsInlineQueries   bool   `json:"supports_inline_queries,omitempty"`     // True, if the bot supports inline queries
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (u *User) UnmarshalJSON(data []byte) error {
+	type plain User
+	return unmarshalExtra(data, (*plain)(u), &u.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (u User) MarshalJSON() ([]byte, error) {
+	type plain User
+	return marshalExtra(plain(u), u.Extra)
+}
+
+// Chat represents a chat
+type Chat struct {
+	ID                                 ChatID           `json:"id"`                                                // Unique identifier for this chat
+	Type                               string           `json:"type"`                                              // Type of chat, can be either “private”, “group”, “supergroup” or “channel”
+	Title                              string           `json:"title,omitempty"`                                   // Title, for supergroups, channels and group chats
+	Username                           string           `json:"username,omitempty"`                                // Username, for private chats, supergroups and channels if available
+	FirstName                          string           `json:"first_name,omitempty"`                              // First name of the other party in a private chat
+	LastName                           string           `json:"last_name,omitempty"`                               // Last name of the other party in a private chat
+	IsForum                            bool             `json:"is_forum,omitempty"`                                // True, if the supergroup chat is a forum (has topics enabled)
+	Photo                              *ChatPhoto       `json:"photo,omitempty"`                                   // Chat photo
+	ActiveUsernames                    []string         `json:"active_usernames,omitempty"`                        // If non-empty, the list of all active chat usernames; for private chats, supergroups and channels
+	EmojiStatusCustomEmojiID           string           `json:"emoji_status_custom_emoji_id,omitempty"`            // Custom emoji identifier of emoji status of the other party in a private chat
+	Bio                                string           `json:"bio,omitempty"`                                     // Bio of the other party in a private chat
+	HasPrivateForwards                 bool             `json:"has_private_forwards,omitempty"`                    // True, if privacy settings of the other party in the private chat allows to use tg://user?id=<user_id> links only in chats with the user
+	HasRestrictedVoiceAndVideoMessages bool             `json:"has_restricted_voice_and_video_messages,omitempty"` // True, if the privacy settings of the other party restrict sending voice and video note messages in the private chat
+	JoinToSendMessages                 bool             `json:"join_to_send_messages,omitempty"`                   // True, if users need to join the supergroup before they can send messages
+	JoinByRequest                      bool             `json:"join_by_request,omitempty"`                         // True, if all users directly joining the supergroup need to be approved by supergroup administrators
+	Description                        string           `json:"description,omitempty"`                             // Description, for groups, supergroups and channel chats
+	InviteLink                         string           `json:"invite_link,omitempty"`                             // Primary invite link, for groups, supergroups and channel chats
+	PinnedMessage                      *Message         `json:"pinned_message,omitempty"`                          // The most recent pinned message (by sending date)
+	Permissions                        *ChatPermissions `json:"permissions,omitempty"`                             // Default chat member permissions, for groups and supergroups
+	SlowModeDelay                      int              `json:"slow_mode_delay,omitempty"`                         // For supergroups, the minimum allowed delay between consecutive messages sent by each unpriviledged user; in seconds
+	MessageAutoDeleteTime              int              `json:"message_auto_delete_time,omitempty"`                // The time after which all messages sent to the chat will be automatically deleted; in seconds
+	HasAggressiveAntiSpamEnabled       bool             `json:"has_aggressive_anti_spam_enabled,omitempty"`        // True, if aggressive anti-spam checks are enabled in the supergroup
+	HasHiddenMembers                   bool             `json:"has_hidden_members,omitempty"`                      // True, if non-administrators can only get the list of bots and administrators in the chat
+	HasProtectedContent                bool             `json:"has_protected_content,omitempty"`                   // True, if messages from the chat can't be forwarded to other chats
+	StickerSetName                     string           `json:"sticker_set_name,omitempty"`                        // For supergroups, name of group sticker set
+	CanSetStickerSet                   bool             `json:"can_set_sticker_set,omitempty"`                     // True, if the bot can change the group sticker set
+	LinkedChatID                       int64            `json:"linked_chat_id,omitempty"`                          // Unique identifier for the linked chat, i.e. the discussion group identifier for a channel and vice versa; for supergroups and channel chats
+	Location                           *ChatLocation    `json:"location,omitempty"`                                // For supergroups, the location to which the supergroup is connected
+	AllMembersAreAdministrators        bool             `json:"all_members_are_administrators,omitempty"`          // Deprecated: True if a group has 'All Members Are Admins' enabled, removed in Bot API 4.4
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (c *Chat) UnmarshalJSON(data []byte) error {
+	type plain Chat
+	return unmarshalExtra(data, (*plain)(c), &c.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (c Chat) MarshalJSON() ([]byte, error) {
+	type plain Chat
+	return marshalExtra(plain(c), c.Extra)
+}
+
+// Message represents a message
+type Message struct {
+	MessageID                     int                            `json:"message_id"`                                  // Unique message identifier inside this chat
+	MessageThreadID               int                            `json:"message_thread_id,omitempty"`                 // Unique identifier of a message thread to which the message belongs; for supergroups only
+	From                          *User                          `json:"from,omitempty"`                              // Sender of the message; empty for messages sent to channels
+	SenderChat                    *Chat                          `json:"sender_chat,omitempty"`                       // Sender of the message, sent on behalf of a chat
+	Date                          int64                          `json:"date"`                                        // Date the message was sent in Unix time
+	Chat                          Chat                           `json:"chat"`                                        // Conversation the message belongs to
+	ForwardFrom                   *User                          `json:"forward_from,omitempty"`                      // For forwarded messages, sender of the original message
+	ForwardFromChat               *Chat                          `json:"forward_from_chat,omitempty"`                 // For messages forwarded from channels or from anonymous administrators, information about the original sender chat
+	ForwardFromMessageID          int                            `json:"forward_from_message_id,omitempty"`           // For messages forwarded from channels, identifier of the original message in the channel
+	ForwardSignature              string                         `json:"forward_signature,omitempty"`                 // For forwarded messages that were originally sent in channels or by an anonymous chat administrator, signature of the message sender if present
+	ForwardSenderName             string                         `json:"forward_sender_name,omitempty"`               // Sender's name for messages forwarded from users who disallow adding a link to their account in forwarded messages
+	ForwardDate                   int64                          `json:"forward_date,omitempty"`                      // For forwarded messages, date the original message was sent in Unix time
+	IsTopicMessage                bool                           `json:"is_topic_message,omitempty"`                  // True, if the message is sent to a forum topic
+	IsAutomaticForward            bool                           `json:"is_automatic_forward,omitempty"`              // True, if the message is a channel post that was automatically forwarded to the connected discussion group
+	ReplyToMessage                *Message                       `json:"reply_to_message,omitempty"`                  // For replies, the original message
+	ViaBot                        *User                          `json:"via_bot,omitempty"`                           // Bot through which the message was sent
+	EditDate                      int64                          `json:"edit_date,omitempty"`                         // Date the message was last edited in Unix time
+	HasProtectedContent           bool                           `json:"has_protected_content,omitempty"`             // True, if the message can't be forwarded
+	MediaGroupID                  string                         `json:"media_group_id,omitempty"`                    // The unique identifier of a media message group this message belongs to
+	AuthorSignature               string                         `json:"author_signature,omitempty"`                  // Signature of the post author for messages in channels, or the custom title of an anonymous group administrator
+	Text                          string                         `json:"text,omitempty"`                              // For text messages, the actual UTF-8 text of the message
+	Entities                      []*MessageEntity               `json:"entities,omitempty"`                          // For text messages, special entities like usernames, URLs, bot commands, etc. that appear in the text
+	Animation                     *Animation                     `json:"animation,omitempty"`                         // Message is an animation, information about the animation
+	Audio                         *Audio                         `json:"audio,omitempty"`                             // Message is an audio file, information about the file
+	Document                      *Document                      `json:"document,omitempty"`                          // Message is a general file, information about the file
+	Photo                         []*PhotoSize                   `json:"photo,omitempty"`                             // Message is a photo, available sizes of the photo
+	Sticker                       *Sticker                       `json:"sticker,omitempty"`                           // Message is a sticker, information about the sticker
+	Video                         *Video                         `json:"video,omitempty"`                             // Message is a video, information about the video
+	VideoNote                     *VideoNote                     `json:"video_note,omitempty"`                        // Message is a video note, information about the video message
+	Voice                         *Voice                         `json:"voice,omitempty"`                             // Message is a voice message, information about the file
+	Caption                       string                         `json:"caption,omitempty"`                           // Caption for the animation, audio, document, photo, video or voice
+	CaptionEntities               []*MessageEntity               `json:"caption_entities,omitempty"`                  // For messages with a caption, special entities like usernames, URLs, bot commands, etc. that appear in the caption
+	HasMediaSpoiler               bool                           `json:"has_media_spoiler,omitempty"`                 // True, if the message media is covered by a spoiler animation
+	Contact                       *Contact                       `json:"contact,omitempty"`                           // Message is a shared contact, information about the contact
+	Dice                          *Dice                          `json:"dice,omitempty"`                              // Message is a dice with random value
+	Game                          *Game                          `json:"game,omitempty"`                              // Message is a game, information about the game
+	Poll                          *Poll                          `json:"poll,omitempty"`                              // Message is a native poll, information about the poll
+	Venue                         *Venue                         `json:"venue,omitempty"`                             // Message is a venue, information about the venue
+	Location                      *Location                      `json:"location,omitempty"`                          // Message is a shared location, information about the location
+	NewChatMembers                []*User                        `json:"new_chat_members,omitempty"`                  // New members that were added to the group or supergroup and information about them (the bot itself may be one of these members)
+	LeftChatMember                *User                          `json:"left_chat_member,omitempty"`                  // A member was removed from the group, information about them (this member may be the bot itself)
+	NewChatTitle                  string                         `json:"new_chat_title,omitempty"`                    // A chat title was changed to this value
+	NewChatPhoto                  []*PhotoSize                   `json:"new_chat_photo,omitempty"`                    // A chat photo was change to this value
+	DeleteChatPhoto               bool                           `json:"delete_chat_photo,omitempty"`                 // Service message: the chat photo was deleted
+	GroupChatCreated              bool                           `json:"group_chat_created,omitempty"`                // Service message: the group has been created
+	SupergroupChatCreated         bool                           `json:"supergroup_chat_created,omitempty"`           // Service message: the supergroup has been created
+	ChannelChatCreated            bool                           `json:"channel_chat_created,omitempty"`              // Service message: the channel has been created
+	MessageAutoDeleteTimerChanged *MessageAutoDeleteTimerChanged `json:"message_auto_delete_timer_changed,omitempty"` // Service message: auto-delete timer settings changed in the chat
+	MigrateToChatID               int64                          `json:"migrate_to_chat_id,omitempty"`                // The group has been migrated to a supergroup with the specified identifier
+	MigrateFromChatID             int64                          `json:"migrate_from_chat_id,omitempty"`              // The supergroup has been migrated from a group with the specified identifier
+	PinnedMessage                 *Message                       `json:"pinned_message,omitempty"`                    // Specified message was pinned
+	Invoice                       *Invoice                       `json:"invoice,omitempty"`                           // Message is an invoice for a payment, information about the invoice
+	SuccessfulPayment             *SuccessfulPayment             `json:"successful_payment,omitempty"`                // Message is a service message about a successful payment, information about the payment
+	UserShared                    *UserShared                    `json:"user_shared,omitempty"`                       // Service message: a user was shared with the bot
+	ChatShared                    *ChatShared                    `json:"chat_shared,omitempty"`                       // Service message: a chat was shared with the bot
+	ConnectedWebsite              string                         `json:"connected_website,omitempty"`                 // The domain name of the website on which the user has logged in
+	WriteAccessAllowed            *WriteAccessAllowed            `json:"write_access_allowed,omitempty"`              // Service message: the user allowed the bot added to the attachment menu to write messages
+	PassportData                  *PassportData                  `json:"passport_data,omitempty"`                     // Telegram Passport data
+	ProximityAlertTriggered       *ProximityAlertTriggered       `json:"proximity_alert_triggered,omitempty"`         // Service message
+	ForumTopicCreated             *ForumTopicCreated             `json:"forum_topic_created,omitempty"`               // Service message: forum topic created
+	ForumTopicEdited              *ForumTopicEdited              `json:"forum_topic_edited,omitempty"`                // Service message: forum topic edited
+	ForumTopicClosed              *ForumTopicClosed              `json:"forum_topic_closed,omitempty"`                // Service message: forum topic closed
+	ForumTopicReopened            *ForumTopicReopened            `json:"forum_topic_reopened,omitempty"`              // Service message: forum topic reopened
+	GeneralForumTopicHidden       *GeneralForumTopicHidden       `json:"general_forum_topic_hidden,omitempty"`        // Service message: the 'General' forum topic hidden
+	GeneralForumTopicUnhidden     *GeneralForumTopicUnhidden     `json:"general_forum_topic_unhidden,omitempty"`      // Service message: the 'General' forum topic unhidden
+	VideoChatScheduled            *VideoChatScheduled            `json:"video_chat_scheduled,omitempty"`              // Service message: video chat scheduled
+	VideoChatStarted              *VideoChatStarted              `json:"video_chat_started,omitempty"`                // Service message: video chat started
+	VideoChatEnded                *VideoChatEnded                `json:"video_chat_ended,omitempty"`                  // Service message: video chat ended
+	VideoChatParticipantsInvited  *VideoChatParticipantsInvited  `json:"video_chat_participants_invited,omitempty"`   // Service message: new participants invited to a video chat
+	WebAppData                    *WebAppData                    `json:"web_app_data,omitempty"`                      // Service message: data sent by a Web App
+	ReplyMarkup                   *InlineKeyboardMarkup          `json:"reply_markup,omitempty"`                      // Inline keyboard attached to the message
+
+	Extra ExtraFields `json:"-"`
+	updateContext
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (m *Message) UnmarshalJSON(data []byte) error {
+	type plain Message
+	return unmarshalExtra(data, (*plain)(m), &m.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (m Message) MarshalJSON() ([]byte, error) {
+	type plain Message
+	return marshalExtra(plain(m), m.Extra)
+}
+
+// MessageID represents a unique message identifier
+type MessageID struct {
+	MessageID int `json:"message_id"` // Unique message identifier
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (mi *MessageID) UnmarshalJSON(data []byte) error {
+	type plain MessageID
+	return unmarshalExtra(data, (*plain)(mi), &mi.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (mi MessageID) MarshalJSON() ([]byte, error) {
+	type plain MessageID
+	return marshalExtra(plain(mi), mi.Extra)
+}
+
+// MessageEntity represents one special entity in a text message. For example, hashtags, usernames,
+// URLs, etc
+type MessageEntity struct {
+	Type          string `json:"type"`                      // Type of the entity
+	Offset        int    `json:"offset"`                    // Offset in UTF-16 code units to the start of the entity
+	Length        int    `json:"length"`                    // Length of the entity in UTF-16 code units
+	URL           string `json:"url,omitempty"`             // For “text_link” only, URL that will be opened after user taps on the text
+	User          *User  `json:"user,omitempty"`            // For “text_mention” only, the mentioned user
+	Language      string `json:"language,omitempty"`        // For “pre” only, the programming language of the entity text
+	CustomEmojiID string `json:"custom_emoji_id,omitempty"` // For “custom_emoji” only, unique identifier of the custom emoji
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (me *MessageEntity) UnmarshalJSON(data []byte) error {
+	type plain MessageEntity
+	return unmarshalExtra(data, (*plain)(me), &me.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (me MessageEntity) MarshalJSON() ([]byte, error) {
+	type plain MessageEntity
+	return marshalExtra(plain(me), me.Extra)
+}
+
+// PhotoSize represents one size of a photo or a file / sticker thumbnail
+type PhotoSize struct {
+	FileID       string `json:"file_id"`             // Identifier for this file, which can be used to download or reuse the file
+	FileUniqueID string `json:"file_unique_id"`      // Unique identifier for this file, which is supposed to be the same over time and for different bots
+	Width        int    `json:"width"`               // Photo width
+	Height       int    `json:"height"`              // Photo height
+	FileSize     int    `json:"file_size,omitempty"` // File size in bytes
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (ps *PhotoSize) UnmarshalJSON(data []byte) error {
+	type plain PhotoSize
+	return unmarshalExtra(data, (*plain)(ps), &ps.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (ps PhotoSize) MarshalJSON() ([]byte, error) {
+	type plain PhotoSize
+	return marshalExtra(plain(ps), ps.Extra)
+}
+
+// Animation represents an animation file (GIF or H.264/MPEG-4 AVC video without sound)
+type Animation struct {
+	FileID       string     `json:"file_id"`             // Identifier for this file, which can be used to download or reuse the file
+	FileUniqueID string     `json:"file_unique_id"`      // Unique identifier for this file, which is supposed to be the same over time and for different bots
+	Width        int        `json:"width"`               // Video width as defined by sender
+	Height       int        `json:"height"`              // Video height as defined by sender
+	Duration     int        `json:"duration"`            // Duration of the video in seconds as defined by sender
+	Thumb        *PhotoSize `json:"thumb,omitempty"`     // Animation thumbnail as defined by sender
+	FileName     string     `json:"file_name,omitempty"` // Original animation filename as defined by sender
+	MimeType     string     `json:"mime_type,omitempty"` // MIME type of the file as defined by sender
+	FileSize     int        `json:"file_size,omitempty"` // File size in bytes
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (a *Animation) UnmarshalJSON(data []byte) error {
+	type plain Animation
+	return unmarshalExtra(data, (*plain)(a), &a.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (a Animation) MarshalJSON() ([]byte, error) {
+	type plain Animation
+	return marshalExtra(plain(a), a.Extra)
+}
+
+// Audio represents an audio file to be treated as music by the Telegram clients
+type Audio struct {
+	FileID       string     `json:"file_id"`             // Identifier for this file, which can be used to download or reuse the file
+	FileUniqueID string     `json:"file_unique_id"`      // Unique identifier for this file, which is supposed to be the same over time and for different bots
+	Duration     int        `json:"duration"`            // Duration of the audio in seconds as defined by sender
+	Performer    string     `json:"performer,omitempty"` // Performer of the audio as defined by sender or by audio tags
+	Title        string     `json:"title,omitempty"`     // Title of the audio as defined by sender or by audio tags
+	FileName     string     `json:"file_name,omitempty"` // Original filename as defined by sender
+	MIMEType     string     `json:"mime_type,omitempty"` // MIME type of the file as defined by sender
+	FileSize     int        `json:"file_size,omitempty"` // File size in bytes
+	Thumb        *PhotoSize `json:"thumb,omitempty"`     // Thumbnail of the album cover to which the music file belongs
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (a *Audio) UnmarshalJSON(data []byte) error {
+	type plain Audio
+	return unmarshalExtra(data, (*plain)(a), &a.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (a Audio) MarshalJSON() ([]byte, error) {
+	type plain Audio
+	return marshalExtra(plain(a), a.Extra)
+}
+
+// Document represents a general file (as opposed to photos, voice messages and audio files)
+type Document struct {
+	FileID       string     `json:"file_id"`             // Identifier for this file, which can be used to download or reuse the file
+	FileUniqueID string     `json:"file_unique_id"`      // Unique identifier for this file, which is supposed to be the same over time and for different bots
+	Thumb        *PhotoSize `json:"thumb,omitempty"`     // Document thumbnail as defined by sender
+	FileName     string     `json:"file_name,omitempty"` // Original filename as defined by sender
+	MIMEType     string     `json:"mime_type,omitempty"` // MIME type of the file as defined by sender
+	FileSize     int        `json:"file_size,omitempty"` // File size in bytes
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (d *Document) UnmarshalJSON(data []byte) error {
+	type plain Document
+	return unmarshalExtra(data, (*plain)(d), &d.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (d Document) MarshalJSON() ([]byte, error) {
+	type plain Document
+	return marshalExtra(plain(d), d.Extra)
+}
+
+// Video represents a video file
+type Video struct {
+	FileID       string     `json:"file_id"`             // Identifier for this file, which can be used to download or reuse the file
+	FileUniqueID string     `json:"file_unique_id"`      // Unique identifier for this file, which is supposed to be the same over time and for different bots
+	Width        int        `json:"width"`               // Video width as defined by sender
+	Height       int        `json:"height"`              // Video height as defined by sender
+	Duration     int        `json:"duration"`            // Duration of the video in seconds as defined by sender
+	Thumbnail    *PhotoSize `json:"thumb,omitempty"`     // Video thumbnail
+	FileName     string     `json:"file_name,omitempty"` // Original filename as defined by sender
+	MimeType     string     `json:"mime_type,omitempty"` // MIME type of the file as defined by sender
+	FileSize     int        `json:"file_size,omitempty"` // File size in bytes
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (v *Video) UnmarshalJSON(data []byte) error {
+	type plain Video
+	return unmarshalExtra(data, (*plain)(v), &v.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (v Video) MarshalJSON() ([]byte, error) {
+	type plain Video
+	return marshalExtra(plain(v), v.Extra)
+}
+
+// VideoNote represents a video message (available in Telegram apps as of v.4.0)
+type VideoNote struct {
+	FileID       string     `json:"file_id"`             // Identifier for this file, which can be used to download or reuse the file
+	FileUniqueID string     `json:"file_unique_id"`      // Unique identifier for this file, which is supposed to be the same over time and for different bots
+	Length       int        `json:"length"`              // Video width and height (diameter of the video message) as defined by sender
+	Duration     int        `json:"duration"`            // Duration of the video in seconds as defined by sender
+	Thumb        *PhotoSize `json:"thumb,omitempty"`     // Video thumbnail
+	FileSize     int        `json:"file_size,omitempty"` // File size in bytes
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (vn *VideoNote) UnmarshalJSON(data []byte) error {
+	type plain VideoNote
+	return unmarshalExtra(data, (*plain)(vn), &vn.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (vn VideoNote) MarshalJSON() ([]byte, error) {
+	type plain VideoNote
+	return marshalExtra(plain(vn), vn.Extra)
+}
+
+// Voice represents a voice note
+type Voice struct {
+	FileID       string `json:"file_id"`             // Identifier for this file, which can be used to download or reuse the file
+	FileUniqueID string `json:"file_unique_id"`      // Unique identifier for this file, which is supposed to be the same over time and for different bots
+	Duration     int    `json:"duration"`            // Duration of the audio in seconds as defined by sender
+	MimeType     string `json:"mime_type,omitempty"` // MIME type of the file as defined by sender
+	FileSize     int    `json:"file_size,omitempty"` // File size in bytes
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (v *Voice) UnmarshalJSON(data []byte) error {
+	type plain Voice
+	return unmarshalExtra(data, (*plain)(v), &v.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (v Voice) MarshalJSON() ([]byte, error) {
+	type plain Voice
+	return marshalExtra(plain(v), v.Extra)
+}
+
+// Contact represents a phone contact
+type Contact struct {
+	PhoneNumber string `json:"phone_number"`        // Contact's phone number
+	FirstName   string `json:"first_name"`          // Contact's first name
+	LastName    string `json:"last_name,omitempty"` // Contact's last name
+	UserID      int64  `json:"user_id,omitempty"`   // Contact's user identifier in Telegram
+	VCard       string `json:"vcard,omitempty"`     // Additional data about the contact in the form of a vCard
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (c *Contact) UnmarshalJSON(data []byte) error {
+	type plain Contact
+	return unmarshalExtra(data, (*plain)(c), &c.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (c Contact) MarshalJSON() ([]byte, error) {
+	type plain Contact
+	return marshalExtra(plain(c), c.Extra)
+}
+
+// Dice represents an animated emoji that displays a random value
+type Dice struct {
+	Emoji string `json:"emoji"` // Emoji on which the dice throw animation is based
+	Value int    `json:"value"` // Value of the dice, 1-6 for “🎲”, “🎯” and “🎳” base emoji, 1-5 for “🏀” and “⚽” base emoji, 1-64 for “🎰” base emoji
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (d *Dice) UnmarshalJSON(data []byte) error {
+	type plain Dice
+	return unmarshalExtra(data, (*plain)(d), &d.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (d Dice) MarshalJSON() ([]byte, error) {
+	type plain Dice
+	return marshalExtra(plain(d), d.Extra)
+}
+
+// PollOption contains information about one answer option in a poll
+type PollOption struct {
+	Text       string `json:"text"`        // Option text, 1-100 characters
+	VoterCount int    `json:"voter_count"` // Number of users that voted for this option
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (po *PollOption) UnmarshalJSON(data []byte) error {
+	type plain PollOption
+	return unmarshalExtra(data, (*plain)(po), &po.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (po PollOption) MarshalJSON() ([]byte, error) {
+	type plain PollOption
+	return marshalExtra(plain(po), po.Extra)
+}
+
+// PollAnswer represents an answer of a user in a non-anonymous poll
+type PollAnswer struct {
+	PollID    string `json:"poll_id"`    // Unique poll identifier
+	User      User   `json:"user"`       // The user, who changed the answer to the poll
+	OptionIDs []int  `json:"option_ids"` // 0-based identifiers of answer options, chosen by the user
+
+	Extra ExtraFields `json:"-"`
+	updateContext
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (pa *PollAnswer) UnmarshalJSON(data []byte) error {
+	type plain PollAnswer
+	return unmarshalExtra(data, (*plain)(pa), &pa.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (pa PollAnswer) MarshalJSON() ([]byte, error) {
+	type plain PollAnswer
+	return marshalExtra(plain(pa), pa.Extra)
+}
+
+// Poll contains information about a poll
+type Poll struct {
+	ID                    string           `json:"id"`                             // Unique poll identifier
+	Question              string           `json:"question"`                       // Poll question, 1-300 characters
+	Options               []PollOption     `json:"options"`                        // List of poll options
+	TotalVoterCount       int              `json:"total_voter_count"`              // Total number of users that voted in the poll
+	IsClosed              bool             `json:"is_closed"`                      // True, if the poll is closed
+	IsAnonymous           bool             `json:"is_anonymous"`                   // True, if the poll is anonymous
+	Type                  string           `json:"type"`                           // Poll type, currently can be “regular” or “quiz”
+	AllowsMultipleAnswers bool             `json:"allows_multiple_answers"`        // True, if the poll allows multiple answers
+	CorrectOptionID       int              `json:"correct_option_id,omitempty"`    // 0-based identifier of the correct answer option
+	Explanation           string           `json:"explanation,omitempty"`          // Text that is shown when a user chooses an incorrect answer or taps on the lamp icon in a quiz-style poll, 0-200 characters
+	ExplanationEntities   []*MessageEntity `json:"explanation_entities,omitempty"` // Special entities like usernames, URLs, bot commands, etc. that appear in the explanation
+	OpenPeriod            int              `json:"open_period,omitempty"`          // Amount of time in seconds the poll will be active after creation
+	CloseDate             int64            `json:"close_date,omitempty"`           // Point in time (Unix timestamp) when the poll will be automatically closed
+
+	Extra ExtraFields `json:"-"`
+	updateContext
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (p *Poll) UnmarshalJSON(data []byte) error {
+	type plain Poll
+	return unmarshalExtra(data, (*plain)(p), &p.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (p Poll) MarshalJSON() ([]byte, error) {
+	type plain Poll
+	return marshalExtra(plain(p), p.Extra)
+}
+
+// Location represents a point on the map
+type Location struct {
+	Longitude            float64 `json:"longitude"`                        // Longitude as defined by sender
+	Latitude             float64 `json:"latitude"`                         // Latitude as defined by sender
+	HorizontalAccuracy   float64 `json:"horizontal_accuracy,omitempty"`    // The radius of uncertainty for the location, measured in meters; 0-1500
+	LivePeriod           int     `json:"live_period,omitempty"`            // Time relative to the message sending date, during which the location can be updated; in seconds
+	Heading              int     `json:"heading,omitempty"`                // The direction in which user is moving, in degrees; 1-360
+	ProximityAlertRadius int     `json:"proximity_alert_radius,omitempty"` // The maximum distance for proximity alerts about approaching another chat member, in meters
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (l *Location) UnmarshalJSON(data []byte) error {
+	type plain Location
+	return unmarshalExtra(data, (*plain)(l), &l.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (l Location) MarshalJSON() ([]byte, error) {
+	type plain Location
+	return marshalExtra(plain(l), l.Extra)
+}
+
+// Venue represents a venue
+type Venue struct {
+	Location        Location `json:"location"`                    // Venue location
+	Title           string   `json:"title"`                       // Name of the venue
+	Address         string   `json:"address"`                     // Address of the venue
+	FoursquareID    string   `json:"foursquare_id,omitempty"`     // Foursquare identifier of the venue
+	FoursquareType  string   `json:"foursquare_type,omitempty"`   // Foursquare type of the venue
+	GooglePlaceID   string   `json:"google_place_id,omitempty"`   // Google Places identifier of the venue
+	GooglePlaceType string   `json:"google_place_type,omitempty"` // Google Places type of the venue
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (v *Venue) UnmarshalJSON(data []byte) error {
+	type plain Venue
+	return unmarshalExtra(data, (*plain)(v), &v.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (v Venue) MarshalJSON() ([]byte, error) {
+	type plain Venue
+	return marshalExtra(plain(v), v.Extra)
+}
+
+// WebAppData describes data sent from a Web App to the bot
+type WebAppData struct {
+	Data       string `json:"data"`        // The data
+	ButtonText string `json:"button_text"` // Text of the web_app keyboard button from which the Web App was opened
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (wad *WebAppData) UnmarshalJSON(data []byte) error {
+	type plain WebAppData
+	return unmarshalExtra(data, (*plain)(wad), &wad.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (wad WebAppData) MarshalJSON() ([]byte, error) {
+	type plain WebAppData
+	return marshalExtra(plain(wad), wad.Extra)
+}
+
+// ProximityAlertTriggered represents the content of a service message, sent whenever a user in the
+// chat triggers a proximity alert set by another user
+type ProximityAlertTriggered struct {
+	Traveler *User `json:"traveler"` // User that triggered the alert
+	Watcher  *User `json:"watcher"`  // User that set the alert
+	Distance int   `json:"distance"` // The distance between the users
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (pat *ProximityAlertTriggered) UnmarshalJSON(data []byte) error {
+	type plain ProximityAlertTriggered
+	return unmarshalExtra(data, (*plain)(pat), &pat.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (pat ProximityAlertTriggered) MarshalJSON() ([]byte, error) {
+	type plain ProximityAlertTriggered
+	return marshalExtra(plain(pat), pat.Extra)
+}
+
+// MessageAutoDeleteTimerChanged represents a service message about a change in auto-delete timer
+// settings
+type MessageAutoDeleteTimerChanged struct {
+	MessageAutoDeleteTime int `json:"message_auto_delete_time"` // New auto-delete time for messages in the chat; in seconds
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (madtc *MessageAutoDeleteTimerChanged) UnmarshalJSON(data []byte) error {
+	type plain MessageAutoDeleteTimerChanged
+	return unmarshalExtra(data, (*plain)(madtc), &madtc.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (madtc MessageAutoDeleteTimerChanged) MarshalJSON() ([]byte, error) {
+	type plain MessageAutoDeleteTimerChanged
+	return marshalExtra(plain(madtc), madtc.Extra)
+}
+
+// ForumTopicCreated represents a service message about a new forum topic created in the chat
+type ForumTopicCreated struct {
+	Name              string `json:"name"`                           // Name of the topic
+	IconColor         int    `json:"icon_color"`                     // Color of the topic icon in RGB format
+	IconCustomEmojiID string `json:"icon_custom_emoji_id,omitempty"` // Unique identifier of the custom emoji shown as the topic icon
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (ftc *ForumTopicCreated) UnmarshalJSON(data []byte) error {
+	type plain ForumTopicCreated
+	return unmarshalExtra(data, (*plain)(ftc), &ftc.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (ftc ForumTopicCreated) MarshalJSON() ([]byte, error) {
+	type plain ForumTopicCreated
+	return marshalExtra(plain(ftc), ftc.Extra)
+}
+
+// ForumTopicClosed represents a service message about a forum topic closed in the chat. Currently
+// holds no information
+type ForumTopicClosed struct {
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (ftc *ForumTopicClosed) UnmarshalJSON(data []byte) error {
+	type plain ForumTopicClosed
+	return unmarshalExtra(data, (*plain)(ftc), &ftc.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (ftc ForumTopicClosed) MarshalJSON() ([]byte, error) {
+	type plain ForumTopicClosed
+	return marshalExtra(plain(ftc), ftc.Extra)
+}
+
+// ForumTopicEdited represents a service message about an edited forum topic
+type ForumTopicEdited struct {
+	Name              string `json:"name,omitempty"`                 // New name of the topic, if it was edited
+	IconCustomEmojiID string `json:"icon_custom_emoji_id,omitempty"` // New identifier of the custom emoji shown as the topic icon, if it was edited; an empty string if the icon was removed
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (fte *ForumTopicEdited) UnmarshalJSON(data []byte) error {
+	type plain ForumTopicEdited
+	return unmarshalExtra(data, (*plain)(fte), &fte.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (fte ForumTopicEdited) MarshalJSON() ([]byte, error) {
+	type plain ForumTopicEdited
+	return marshalExtra(plain(fte), fte.Extra)
+}
+
+// ForumTopicReopened represents a service message about a forum topic reopened in the chat. Currently
+// holds no information
+type ForumTopicReopened struct {
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (ftr *ForumTopicReopened) UnmarshalJSON(data []byte) error {
+	type plain ForumTopicReopened
+	return unmarshalExtra(data, (*plain)(ftr), &ftr.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (ftr ForumTopicReopened) MarshalJSON() ([]byte, error) {
+	type plain ForumTopicReopened
+	return marshalExtra(plain(ftr), ftr.Extra)
+}
+
+// GeneralForumTopicHidden represents a service message about General forum topic hidden in the chat.
+// Currently holds no information
+type GeneralForumTopicHidden struct {
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (gfth *GeneralForumTopicHidden) UnmarshalJSON(data []byte) error {
+	type plain GeneralForumTopicHidden
+	return unmarshalExtra(data, (*plain)(gfth), &gfth.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (gfth GeneralForumTopicHidden) MarshalJSON() ([]byte, error) {
+	type plain GeneralForumTopicHidden
+	return marshalExtra(plain(gfth), gfth.Extra)
+}
+
+// GeneralForumTopicUnhidden represents a service message about General forum topic unhidden in the
+// chat. Currently holds no information
+type GeneralForumTopicUnhidden struct {
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (gftu *GeneralForumTopicUnhidden) UnmarshalJSON(data []byte) error {
+	type plain GeneralForumTopicUnhidden
+	return unmarshalExtra(data, (*plain)(gftu), &gftu.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (gftu GeneralForumTopicUnhidden) MarshalJSON() ([]byte, error) {
+	type plain GeneralForumTopicUnhidden
+	return marshalExtra(plain(gftu), gftu.Extra)
+}
+
+// UserShared contains information about the user whose identifier was shared with the bot using a
+// KeyboardButtonRequestUser button
+type UserShared struct {
+	RequestID int   `json:"request_id"` // Identifier of the request
+	UserID    int64 `json:"user_id"`    // Identifier of the shared user
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (us *UserShared) UnmarshalJSON(data []byte) error {
+	type plain UserShared
+	return unmarshalExtra(data, (*plain)(us), &us.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (us UserShared) MarshalJSON() ([]byte, error) {
+	type plain UserShared
+	return marshalExtra(plain(us), us.Extra)
+}
+
+// ChatShared contains information about the chat whose identifier was shared with the bot using a
+// KeyboardButtonRequestChat button
+type ChatShared struct {
+	RequestID int   `json:"request_id"` // Identifier of the request
+	ChatID    int64 `json:"chat_id"`    // Identifier of the shared chat
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (cs *ChatShared) UnmarshalJSON(data []byte) error {
+	type plain ChatShared
+	return unmarshalExtra(data, (*plain)(cs), &cs.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (cs ChatShared) MarshalJSON() ([]byte, error) {
+	type plain ChatShared
+	return marshalExtra(plain(cs), cs.Extra)
+}
+
+// WriteAccessAllowed represents a service message about a user allowing a bot added to the attachment
+// menu to write messages. Currently holds no information
+type WriteAccessAllowed struct {
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (waa *WriteAccessAllowed) UnmarshalJSON(data []byte) error {
+	type plain WriteAccessAllowed
+	return unmarshalExtra(data, (*plain)(waa), &waa.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (waa WriteAccessAllowed) MarshalJSON() ([]byte, error) {
+	type plain WriteAccessAllowed
+	return marshalExtra(plain(waa), waa.Extra)
+}
+
+// VideoChatScheduled represents a service message about a video chat scheduled in the chat
+type VideoChatScheduled struct {
+	StartDate int64 `json:"start_date"` // Point in time (Unix timestamp) when the video chat is supposed to be started by a chat administrator
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (vcs *VideoChatScheduled) UnmarshalJSON(data []byte) error {
+	type plain VideoChatScheduled
+	return unmarshalExtra(data, (*plain)(vcs), &vcs.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (vcs VideoChatScheduled) MarshalJSON() ([]byte, error) {
+	type plain VideoChatScheduled
+	return marshalExtra(plain(vcs), vcs.Extra)
+}
+
+// VideoChatStarted represents a service message about a video chat started in the chat. Currently
+// holds no information
+type VideoChatStarted struct {
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (vcs *VideoChatStarted) UnmarshalJSON(data []byte) error {
+	type plain VideoChatStarted
+	return unmarshalExtra(data, (*plain)(vcs), &vcs.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (vcs VideoChatStarted) MarshalJSON() ([]byte, error) {
+	type plain VideoChatStarted
+	return marshalExtra(plain(vcs), vcs.Extra)
+}
+
+// VideoChatEnded represents a service message about a video chat ended in the chat
+type VideoChatEnded struct {
+	Duration int `json:"duration"` // Video chat duration in seconds
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (vce *VideoChatEnded) UnmarshalJSON(data []byte) error {
+	type plain VideoChatEnded
+	return unmarshalExtra(data, (*plain)(vce), &vce.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (vce VideoChatEnded) MarshalJSON() ([]byte, error) {
+	type plain VideoChatEnded
+	return marshalExtra(plain(vce), vce.Extra)
+}
+
+// VideoChatParticipantsInvited represents a service message about new members invited to a video chat
+type VideoChatParticipantsInvited struct {
+	Users []*User `json:"users"` // New members that were invited to the video chat
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (vcpi *VideoChatParticipantsInvited) UnmarshalJSON(data []byte) error {
+	type plain VideoChatParticipantsInvited
+	return unmarshalExtra(data, (*plain)(vcpi), &vcpi.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (vcpi VideoChatParticipantsInvited) MarshalJSON() ([]byte, error) {
+	type plain VideoChatParticipantsInvited
+	return marshalExtra(plain(vcpi), vcpi.Extra)
+}
+
+// WebAppInfo describes a Web App
+type WebAppInfo struct {
+	URL string `json:"url"` // An HTTPS URL of a Web App to be opened with additional data as specified in Initializing Web Apps
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (wai *WebAppInfo) UnmarshalJSON(data []byte) error {
+	type plain WebAppInfo
+	return unmarshalExtra(data, (*plain)(wai), &wai.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (wai WebAppInfo) MarshalJSON() ([]byte, error) {
+	type plain WebAppInfo
+	return marshalExtra(plain(wai), wai.Extra)
+}
+
+// KeyboardButton represents one button of the reply keyboard. For simple text buttons String can be
+// used instead of this object to specify text of the button. Optional fields web_app, request_contact,
+// request_location, and request_poll are mutually exclusive
+type KeyboardButton struct {
+	Text            string                     `json:"text"`                       // Text of the button
+	RequestUser     *KeyboardButtonRequestUser `json:"request_user,omitempty"`     // If specified, pressing the button will open a list of suitable users
+	RequestChat     *KeyboardButtonRequestChat `json:"request_chat,omitempty"`     // If specified, pressing the button will open a list of suitable chats
+	RequestContact  bool                       `json:"request_contact,omitempty"`  // If True, the user's phone number will be sent as a contact when the button is pressed
+	RequestLocation bool                       `json:"request_location,omitempty"` // If True, the user's current location will be sent when the button is pressed
+	RequestPoll     *KeyboardButtonPollType    `json:"request_poll,omitempty"`     // If specified, the user will be asked to create a poll and send it to the bot when the button is pressed
+	WebApp          *WebAppInfo                `json:"web_app,omitempty"`          // If specified, the described Web App will be launched when the button is pressed
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (kb *KeyboardButton) UnmarshalJSON(data []byte) error {
+	type plain KeyboardButton
+	return unmarshalExtra(data, (*plain)(kb), &kb.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (kb KeyboardButton) MarshalJSON() ([]byte, error) {
+	type plain KeyboardButton
+	return marshalExtra(plain(kb), kb.Extra)
+}
+
+// KeyboardButtonRequestUser defines the criteria used to request a suitable user. The identifier of
+// the selected user will be shared with the bot when the corresponding button is pressed
+type KeyboardButtonRequestUser struct {
+	RequestID     int   `json:"request_id"`                // Signed 32-bit identifier of the request, which will be received back in the UserShared object
+	UserIsBot     *bool `json:"user_is_bot,omitempty"`     // Pass True to request a bot, pass False to request a regular user
+	UserIsPremium *bool `json:"user_is_premium,omitempty"` // Pass True to request a premium user, pass False to request a non-premium user
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (kbru *KeyboardButtonRequestUser) UnmarshalJSON(data []byte) error {
+	type plain KeyboardButtonRequestUser
+	return unmarshalExtra(data, (*plain)(kbru), &kbru.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (kbru KeyboardButtonRequestUser) MarshalJSON() ([]byte, error) {
+	type plain KeyboardButtonRequestUser
+	return marshalExtra(plain(kbru), kbru.Extra)
+}
+
+// KeyboardButtonRequestChat defines the criteria used to request a suitable chat. The identifier of
+// the selected chat will be shared with the bot when the corresponding button is pressed
+type KeyboardButtonRequestChat struct {
+	RequestID               int                      `json:"request_id"`                          // Signed 32-bit identifier of the request, which will be received back in the ChatShared object
+	ChatIsChannel           bool                     `json:"chat_is_channel"`                     // Pass True to request a channel chat, pass False to request a group or a supergroup chat
+	ChatIsForum             *bool                    `json:"chat_is_forum,omitempty"`             // Pass True to request a forum supergroup, pass False to request a non-forum chat
+	ChatHasUsername         *bool                    `json:"chat_has_username,omitempty"`         // Pass True to request a supergroup or a channel with a username, pass False to request a chat without a username
+	ChatIsCreated           bool                     `json:"chat_is_created,omitempty"`           // Pass True to request a chat owned by the user
+	UserAdministratorRights *ChatAdministratorRights `json:"user_administrator_rights,omitempty"` // A JSON-serialized object listing the required administrator rights of the user in the chat
+	BotAdministratorRights  *ChatAdministratorRights `json:"bot_administrator_rights,omitempty"`  // A JSON-serialized object listing the required administrator rights of the bot in the chat
+	BotIsMember             bool                     `json:"bot_is_member,omitempty"`             // Pass True to request a chat with the bot as a member
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (kbrc *KeyboardButtonRequestChat) UnmarshalJSON(data []byte) error {
+	type plain KeyboardButtonRequestChat
+	return unmarshalExtra(data, (*plain)(kbrc), &kbrc.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (kbrc KeyboardButtonRequestChat) MarshalJSON() ([]byte, error) {
+	type plain KeyboardButtonRequestChat
+	return marshalExtra(plain(kbrc), kbrc.Extra)
+}
+
+// KeyboardButtonPollType represents type of a poll, which is allowed to be created and sent when the
+// corresponding button is pressed
+type KeyboardButtonPollType struct {
+	Type string `json:"type,omitempty"` // If quiz is passed, the user will be allowed to create only polls in the quiz mode
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (kbpt *KeyboardButtonPollType) UnmarshalJSON(data []byte) error {
+	type plain KeyboardButtonPollType
+	return unmarshalExtra(data, (*plain)(kbpt), &kbpt.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (kbpt KeyboardButtonPollType) MarshalJSON() ([]byte, error) {
+	type plain KeyboardButtonPollType
+	return marshalExtra(plain(kbpt), kbpt.Extra)
+}
+
+// ChatPhoto represents a chat photo
+type ChatPhoto struct {
+	SmallFileID       string `json:"small_file_id"`        // File identifier of small (160x160) chat photo
+	SmallFileUniqueID string `json:"small_file_unique_id"` // Unique file identifier of small (160x160) chat photo, which is supposed to be the same over time and for different bots
+	BigFileID         string `json:"big_file_id"`          // File identifier of big (640x640) chat photo
+	BigFileUniqueID   string `json:"big_file_unique_id"`   // Unique file identifier of big (640x640) chat photo, which is supposed to be the same over time and for different bots
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (cp *ChatPhoto) UnmarshalJSON(data []byte) error {
+	type plain ChatPhoto
+	return unmarshalExtra(data, (*plain)(cp), &cp.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (cp ChatPhoto) MarshalJSON() ([]byte, error) {
+	type plain ChatPhoto
+	return marshalExtra(plain(cp), cp.Extra)
+}
+
+// ChatInviteLink represents an invite link for a chat
+type ChatInviteLink struct {
+	InviteLink              string `json:"invite_link"`                          // The invite link
+	Creator                 *User  `json:"creator"`                              // Creator of the link
+	CreatesJoinRequest      bool   `json:"creates_join_request"`                 // True, if users joining the chat via the link need to be approved by chat administrators
+	IsPrimary               bool   `json:"is_primary"`                           // True, if the link is primary
+	IsRevoked               bool   `json:"is_revoked"`                           // True, if the link is revoked
+	Name                    string `json:"name,omitempty"`                       // Invite link name
+	ExpireDate              int64  `json:"expire_date,omitempty"`                // Point in time (Unix timestamp) when the link will expire or has been expired
+	MemberLimit             int    `json:"member_limit,omitempty"`               // The maximum number of users that can be members of the chat simultaneously after joining the chat via this invite link; 1-99999
+	PendingJoinRequestCount int    `json:"pending_join_request_count,omitempty"` // Number of pending join requests created using this link
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (cil *ChatInviteLink) UnmarshalJSON(data []byte) error {
+	type plain ChatInviteLink
+	return unmarshalExtra(data, (*plain)(cil), &cil.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (cil ChatInviteLink) MarshalJSON() ([]byte, error) {
+	type plain ChatInviteLink
+	return marshalExtra(plain(cil), cil.Extra)
+}
+
+// ChatAdministratorRights represents the rights of an administrator in a chat
+type ChatAdministratorRights struct {
+	IsAnonymous         bool `json:"is_anonymous"`                // True, if the user's presence in the chat is hidden
+	CanManageChat       bool `json:"can_manage_chat"`             // True, if the administrator can access the chat event log, chat statistics, message statistics in channels, see channel members, see anonymous administrators in supergroups and ignore slow mode
+	CanDeleteMessages   bool `json:"can_delete_messages"`         // True, if the administrator can delete messages of other users
+	CanManageVideoChats bool `json:"can_manage_video_chats"`      // True, if the administrator can manage video chats
+	CanRestrictMembers  bool `json:"can_restrict_members"`        // True, if the administrator can restrict, ban or unban chat members
+	CanPromoteMembers   bool `json:"can_promote_members"`         // True, if the administrator can add new administrators with a subset of their own privileges or demote administrators that they have promoted, directly or indirectly (promoted by administrators that were appointed by the user)
+	CanChangeInfo       bool `json:"can_change_info"`             // True, if the user is allowed to change the chat title, photo and other settings
+	CanInviteUsers      bool `json:"can_invite_users"`            // True, if the user is allowed to invite new users to the chat
+	CanPostMessages     bool `json:"can_post_messages,omitempty"` // True, if the administrator can post in the channel; channels only
+	CanEditMessages     bool `json:"can_edit_messages,omitempty"` // True, if the administrator can edit messages of other users and can pin messages; channels only
+	CanPinMessages      bool `json:"can_pin_messages,omitempty"`  // True, if the user is allowed to pin messages; groups and supergroups only
+	CanManageTopics     bool `json:"can_manage_topics,omitempty"` // True, if the user is allowed to create, rename, close, and reopen forum topics; supergroups only
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (car *ChatAdministratorRights) UnmarshalJSON(data []byte) error {
+	type plain ChatAdministratorRights
+	return unmarshalExtra(data, (*plain)(car), &car.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (car ChatAdministratorRights) MarshalJSON() ([]byte, error) {
+	type plain ChatAdministratorRights
+	return marshalExtra(plain(car), car.Extra)
+}
+
+// ChatMember contains information about one member of a chat. Fields of all kinds of members are
+// merged into one struct
+//
+// Deprecated: use Variant to get the member as one of ChatMemberOwner, ChatMemberAdministrator,
+// ChatMemberMember, ChatMemberRestricted, ChatMemberLeft, ChatMemberBanned
+type ChatMember struct {
+	Status                string `json:"status"`                              // The member's status in the chat, can be “creator”, “administrator”, “member”, “restricted”, “left” or “kicked”
+	User                  User   `json:"user"`                                // Information about the user
+	IsAnonymous           bool   `json:"is_anonymous,omitempty"`              // True, if the user's presence in the chat is hidden
+	CustomTitle           string `json:"custom_title,omitempty"`              // Custom title for this user
+	CanBeEdited           bool   `json:"can_be_edited,omitempty"`             // True, if the bot is allowed to edit administrator privileges of that user
+	CanManageChat         bool   `json:"can_manage_chat,omitempty"`           // True, if the administrator can access the chat event log, chat statistics, message statistics in channels, see channel members, see anonymous administrators in supergroups and ignore slow mode
+	CanDeleteMessages     bool   `json:"can_delete_messages,omitempty"`       // True, if the administrator can delete messages of other users
+	CanManageVideoChats   bool   `json:"can_manage_video_chats,omitempty"`    // True, if the administrator can manage video chats
+	CanRestrictMembers    bool   `json:"can_restrict_members,omitempty"`      // True, if the administrator can restrict, ban or unban chat members
+	CanPromoteMembers     bool   `json:"can_promote_members,omitempty"`       // True, if the administrator can add new administrators with a subset of their own privileges or demote administrators that they have promoted, directly or indirectly (promoted by administrators that were appointed by the user)
+	CanChangeInfo         bool   `json:"can_change_info,omitempty"`           // True, if the user is allowed to change the chat title, photo and other settings
+	CanInviteUsers        bool   `json:"can_invite_users,omitempty"`          // True, if the user is allowed to invite new users to the chat
+	CanPostMessages       bool   `json:"can_post_messages,omitempty"`         // True, if the administrator can post in the channel; channels only
+	CanEditMessages       bool   `json:"can_edit_messages,omitempty"`         // True, if the administrator can edit messages of other users and can pin messages; channels only
+	CanPinMessages        bool   `json:"can_pin_messages,omitempty"`          // True, if the user is allowed to pin messages; groups and supergroups only
+	CanManageTopics       bool   `json:"can_manage_topics,omitempty"`         // True, if the user is allowed to create, rename, close, and reopen forum topics; supergroups only
+	IsMember              bool   `json:"is_member,omitempty"`                 // True, if the user is a member of the chat at the moment of the request
+	CanSendMessages       bool   `json:"can_send_messages,omitempty"`         // True, if the user is allowed to send text messages, contacts, invoices, locations and venues
+	CanSendAudios         bool   `json:"can_send_audios,omitempty"`           // True, if the user is allowed to send audios
+	CanSendDocuments      bool   `json:"can_send_documents,omitempty"`        // True, if the user is allowed to send documents
+	CanSendPhotos         bool   `json:"can_send_photos,omitempty"`           // True, if the user is allowed to send photos
+	CanSendVideos         bool   `json:"can_send_videos,omitempty"`           // True, if the user is allowed to send videos
+	CanSendVideoNotes     bool   `json:"can_send_video_notes,omitempty"`      // True, if the user is allowed to send video notes
+	CanSendVoiceNotes     bool   `json:"can_send_voice_notes,omitempty"`      // True, if the user is allowed to send voice notes
+	CanSendPolls          bool   `json:"can_send_polls,omitempty"`            // True, if the user is allowed to send polls
+	CanSendOtherMessages  bool   `json:"can_send_other_messages,omitempty"`   // True, if the user is allowed to send animations, games, stickers and use inline bots
+	CanAddWebPagePreviews bool   `json:"can_add_web_page_previews,omitempty"` // True, if the user is allowed to add web page previews to their messages
+	UntilDate             int    `json:"until_date,omitempty"`                // Date when restrictions will be lifted for this user; unix time
+	CanSendMediaMessages  bool   `json:"can_send_media_messages,omitempty"`   // Deprecated: True, if the user is allowed to send audios, documents, photos, videos, video notes and voice notes, replaced with granular permissions in Bot API 6.5
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (cm *ChatMember) UnmarshalJSON(data []byte) error {
+	type plain ChatMember
+	return unmarshalExtra(data, (*plain)(cm), &cm.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (cm ChatMember) MarshalJSON() ([]byte, error) {
+	type plain ChatMember
+	return marshalExtra(plain(cm), cm.Extra)
+}
+
+// ChatMemberVariant is one of ChatMemberOwner, ChatMemberAdministrator, ChatMemberMember,
+// ChatMemberRestricted, ChatMemberLeft, ChatMemberBanned
+type ChatMemberVariant interface {
+	chatMemberVariant()
+}
+
+func (*ChatMemberOwner) chatMemberVariant()         {}
+func (*ChatMemberAdministrator) chatMemberVariant() {}
+func (*ChatMemberMember) chatMemberVariant()        {}
+func (*ChatMemberRestricted) chatMemberVariant()    {}
+func (*ChatMemberLeft) chatMemberVariant()          {}
+func (*ChatMemberBanned) chatMemberVariant()        {}
+
+// UnmarshalChatMemberVariant decodes ChatMember as its subtype chosen by status
+func UnmarshalChatMemberVariant(data []byte) (ChatMemberVariant, error) {
+	var kind struct {
+		Value string `json:"status"`
+	}
+	err := json.Unmarshal(data, &kind)
+	if err != nil {
+		return nil, err
+	}
+	var v ChatMemberVariant
+	switch kind.Value {
+	case "creator":
+		v = &ChatMemberOwner{}
+	case "administrator":
+		v = &ChatMemberAdministrator{}
+	case "member":
+		v = &ChatMemberMember{}
+	case "restricted":
+		v = &ChatMemberRestricted{}
+	case "left":
+		v = &ChatMemberLeft{}
+	case "kicked":
+		v = &ChatMemberBanned{}
+	default:
+		return nil, fmt.Errorf("unknown ChatMember status %q", kind.Value)
+	}
+	return v, json.Unmarshal(data, v)
+}
+
+// Variant returns the member as one of ChatMemberOwner, ChatMemberAdministrator, ChatMemberMember,
+// ChatMemberRestricted, ChatMemberLeft, ChatMemberBanned chosen by status
+func (cm *ChatMember) Variant() (ChatMemberVariant, error) {
+	data, err := json.Marshal(cm)
+	if err != nil {
+		return nil, err
+	}
+	return UnmarshalChatMemberVariant(data)
+}
+
+// ChatMemberOwner represents a chat member that owns the chat and has all administrator privileges
+type ChatMemberOwner struct {
+	Status      string `json:"status"`                 // The member's status in the chat, always “creator”
+	User        User   `json:"user"`                   // Information about the user
+	IsAnonymous bool   `json:"is_anonymous"`           // True, if the user's presence in the chat is hidden
+	CustomTitle string `json:"custom_title,omitempty"` // Custom title for this user
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (cmo *ChatMemberOwner) UnmarshalJSON(data []byte) error {
+	type plain ChatMemberOwner
+	return unmarshalExtra(data, (*plain)(cmo), &cmo.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (cmo ChatMemberOwner) MarshalJSON() ([]byte, error) {
+	type plain ChatMemberOwner
+	return marshalExtra(plain(cmo), cmo.Extra)
+}
+
+// ChatMemberAdministrator represents a chat member that has some additional privileges
+type ChatMemberAdministrator struct {
+	Status              string `json:"status"`                      // The member's status in the chat, always “administrator”
+	User                User   `json:"user"`                        // Information about the user
+	CanBeEdited         bool   `json:"can_be_edited"`               // True, if the bot is allowed to edit administrator privileges of that user
+	IsAnonymous         bool   `json:"is_anonymous"`                // True, if the user's presence in the chat is hidden
+	CanManageChat       bool   `json:"can_manage_chat"`             // True, if the administrator can access the chat event log, chat statistics, message statistics in channels, see channel members, see anonymous administrators in supergroups and ignore slow mode
+	CanDeleteMessages   bool   `json:"can_delete_messages"`         // True, if the administrator can delete messages of other users
+	CanManageVideoChats bool   `json:"can_manage_video_chats"`      // True, if the administrator can manage video chats
+	CanRestrictMembers  bool   `json:"can_restrict_members"`        // True, if the administrator can restrict, ban or unban chat members
+	CanPromoteMembers   bool   `json:"can_promote_members"`         // True, if the administrator can add new administrators with a subset of their own privileges or demote administrators that they have promoted, directly or indirectly (promoted by administrators that were appointed by the user)
+	CanChangeInfo       bool   `json:"can_change_info"`             // True, if the user is allowed to change the chat title, photo and other settings
+	CanInviteUsers      bool   `json:"can_invite_users"`            // True, if the user is allowed to invite new users to the chat
+	CanPostMessages     bool   `json:"can_post_messages,omitempty"` // True, if the administrator can post in the channel; channels only
+	CanEditMessages     bool   `json:"can_edit_messages,omitempty"` // True, if the administrator can edit messages of other users and can pin messages; channels only
+	CanPinMessages      bool   `json:"can_pin_messages,omitempty"`  // True, if the user is allowed to pin messages; groups and supergroups only
+	CanManageTopics     bool   `json:"can_manage_topics,omitempty"` // True, if the user is allowed to create, rename, close, and reopen forum topics; supergroups only
+	CustomTitle         string `json:"custom_title,omitempty"`      // Custom title for this user
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (cma *ChatMemberAdministrator) UnmarshalJSON(data []byte) error {
+	type plain ChatMemberAdministrator
+	return unmarshalExtra(data, (*plain)(cma), &cma.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (cma ChatMemberAdministrator) MarshalJSON() ([]byte, error) {
+	type plain ChatMemberAdministrator
+	return marshalExtra(plain(cma), cma.Extra)
+}
+
+// ChatMemberMember represents a chat member that has no additional privileges or restrictions
+type ChatMemberMember struct {
+	Status string `json:"status"` // The member's status in the chat, always “member”
+	User   User   `json:"user"`   // Information about the user
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (cmm *ChatMemberMember) UnmarshalJSON(data []byte) error {
+	type plain ChatMemberMember
+	return unmarshalExtra(data, (*plain)(cmm), &cmm.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (cmm ChatMemberMember) MarshalJSON() ([]byte, error) {
+	type plain ChatMemberMember
+	return marshalExtra(plain(cmm), cmm.Extra)
+}
+
+// ChatMemberRestricted represents a chat member that is under certain restrictions in the chat.
+// Supergroups only
+type ChatMemberRestricted struct {
+	Status                string `json:"status"`                    // The member's status in the chat, always “restricted”
+	User                  User   `json:"user"`                      // Information about the user
+	IsMember              bool   `json:"is_member"`                 // True, if the user is a member of the chat at the moment of the request
+	CanSendMessages       bool   `json:"can_send_messages"`         // True, if the user is allowed to send text messages, contacts, invoices, locations and venues
+	CanSendAudios         bool   `json:"can_send_audios"`           // True, if the user is allowed to send audios
+	CanSendDocuments      bool   `json:"can_send_documents"`        // True, if the user is allowed to send documents
+	CanSendPhotos         bool   `json:"can_send_photos"`           // True, if the user is allowed to send photos
+	CanSendVideos         bool   `json:"can_send_videos"`           // True, if the user is allowed to send videos
+	CanSendVideoNotes     bool   `json:"can_send_video_notes"`      // True, if the user is allowed to send video notes
+	CanSendVoiceNotes     bool   `json:"can_send_voice_notes"`      // True, if the user is allowed to send voice notes
+	CanSendPolls          bool   `json:"can_send_polls"`            // True, if the user is allowed to send polls
+	CanSendOtherMessages  bool   `json:"can_send_other_messages"`   // True, if the user is allowed to send animations, games, stickers and use inline bots
+	CanAddWebPagePreviews bool   `json:"can_add_web_page_previews"` // True, if the user is allowed to add web page previews to their messages
+	CanChangeInfo         bool   `json:"can_change_info"`           // True, if the user is allowed to change the chat title, photo and other settings
+	CanInviteUsers        bool   `json:"can_invite_users"`          // True, if the user is allowed to invite new users to the chat
+	CanPinMessages        bool   `json:"can_pin_messages"`          // True, if the user is allowed to pin messages
+	CanManageTopics       bool   `json:"can_manage_topics"`         // True, if the user is allowed to create forum topics
+	UntilDate             int    `json:"until_date"`                // Date when restrictions will be lifted for this user; unix time
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (cmr *ChatMemberRestricted) UnmarshalJSON(data []byte) error {
+	type plain ChatMemberRestricted
+	return unmarshalExtra(data, (*plain)(cmr), &cmr.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (cmr ChatMemberRestricted) MarshalJSON() ([]byte, error) {
+	type plain ChatMemberRestricted
+	return marshalExtra(plain(cmr), cmr.Extra)
+}
+
+// ChatMemberLeft represents a chat member that isn't currently a member of the chat, but may join it
+// themselves
+type ChatMemberLeft struct {
+	Status string `json:"status"` // The member's status in the chat, always “left”
+	User   User   `json:"user"`   // Information about the user
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (cml *ChatMemberLeft) UnmarshalJSON(data []byte) error {
+	type plain ChatMemberLeft
+	return unmarshalExtra(data, (*plain)(cml), &cml.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (cml ChatMemberLeft) MarshalJSON() ([]byte, error) {
+	type plain ChatMemberLeft
+	return marshalExtra(plain(cml), cml.Extra)
+}
+
+// ChatMemberBanned represents a chat member that was banned in the chat and can't return to the chat
+// or view chat messages
+type ChatMemberBanned struct {
+	Status    string `json:"status"`     // The member's status in the chat, always “kicked”
+	User      User   `json:"user"`       // Information about the user
+	UntilDate int    `json:"until_date"` // Date when restrictions will be lifted for this user; unix time
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (cmb *ChatMemberBanned) UnmarshalJSON(data []byte) error {
+	type plain ChatMemberBanned
+	return unmarshalExtra(data, (*plain)(cmb), &cmb.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (cmb ChatMemberBanned) MarshalJSON() ([]byte, error) {
+	type plain ChatMemberBanned
+	return marshalExtra(plain(cmb), cmb.Extra)
+}
+
+// ChatPermissions describes actions that a non-administrator user is allowed to take in a chat
+type ChatPermissions struct {
+	CanSendMessages       bool `json:"can_send_messages,omitempty"`         // True, if the user is allowed to send text messages, contacts, invoices, locations and venues
+	CanSendAudios         bool `json:"can_send_audios,omitempty"`           // True, if the user is allowed to send audios
+	CanSendDocuments      bool `json:"can_send_documents,omitempty"`        // True, if the user is allowed to send documents
+	CanSendPhotos         bool `json:"can_send_photos,omitempty"`           // True, if the user is allowed to send photos
+	CanSendVideos         bool `json:"can_send_videos,omitempty"`           // True, if the user is allowed to send videos
+	CanSendVideoNotes     bool `json:"can_send_video_notes,omitempty"`      // True, if the user is allowed to send video notes
+	CanSendVoiceNotes     bool `json:"can_send_voice_notes,omitempty"`      // True, if the user is allowed to send voice notes
+	CanSendPolls          bool `json:"can_send_polls,omitempty"`            // True, if the user is allowed to send polls
+	CanSendOtherMessages  bool `json:"can_send_other_messages,omitempty"`   // True, if the user is allowed to send animations, games, stickers and use inline bots
+	CanAddWebPagePreviews bool `json:"can_add_web_page_previews,omitempty"` // True, if the user is allowed to add web page previews to their messages
+	CanChangeInfo         bool `json:"can_change_info,omitempty"`           // True, if the user is allowed to change the chat title, photo and other settings
+	CanInviteUsers        bool `json:"can_invite_users,omitempty"`          // True, if the user is allowed to invite new users to the chat
+	CanPinMessages        bool `json:"can_pin_messages,omitempty"`          // True, if the user is allowed to pin messages
+	CanManageTopics       bool `json:"can_manage_topics,omitempty"`         // True, if the user is allowed to create forum topics
+	CanSendMediaMessages  bool `json:"can_send_media_messages,omitempty"`   // Deprecated: True, if the user is allowed to send audios, documents, photos, videos, video notes and voice notes, replaced with granular permissions in Bot API 6.5
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (cp *ChatPermissions) UnmarshalJSON(data []byte) error {
+	type plain ChatPermissions
+	return unmarshalExtra(data, (*plain)(cp), &cp.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (cp ChatPermissions) MarshalJSON() ([]byte, error) {
+	type plain ChatPermissions
+	return marshalExtra(plain(cp), cp.Extra)
+}
+
+// ChatLocation represents a location to which a chat is connected
+type ChatLocation struct {
+	Location *Location `json:"location"` // The location to which the supergroup is connected
+	Address  string    `json:"address"`  // Location address; 1-64 characters, as defined by the chat owner
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (cl *ChatLocation) UnmarshalJSON(data []byte) error {
+	type plain ChatLocation
+	return unmarshalExtra(data, (*plain)(cl), &cl.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (cl ChatLocation) MarshalJSON() ([]byte, error) {
+	type plain ChatLocation
+	return marshalExtra(plain(cl), cl.Extra)
+}
+
+// ChatMemberUpdated represents changes in the status of a chat member
+type ChatMemberUpdated struct {
+	Chat          Chat            `json:"chat"`                  // Chat the user belongs to
+	From          *User           `json:"from"`                  // Performer of the action, which resulted in the change
+	Date          int64           `json:"date"`                  // Date the change was done in Unix time
+	OldChatMember *ChatMember     `json:"old_chat_member"`       // Previous information about the chat member
+	NewChatMember *ChatMember     `json:"new_chat_member"`       // New information about the chat member
+	InviteLink    *ChatInviteLink `json:"invite_link,omitempty"` // Chat invite link, which was used by the user to join the chat; for joining by invite link events only
+
+	Extra ExtraFields `json:"-"`
+	updateContext
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (cmu *ChatMemberUpdated) UnmarshalJSON(data []byte) error {
+	type plain ChatMemberUpdated
+	return unmarshalExtra(data, (*plain)(cmu), &cmu.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (cmu ChatMemberUpdated) MarshalJSON() ([]byte, error) {
+	type plain ChatMemberUpdated
+	return marshalExtra(plain(cmu), cmu.Extra)
+}
+
+// ChatJoinRequest represents a join request sent to a chat
+type ChatJoinRequest struct {
+	Chat       Chat            `json:"chat"`                  // Chat to which the request was sent
+	From       *User           `json:"from"`                  // User that sent the join request
+	UserChatID int64           `json:"user_chat_id"`          // Identifier of a private chat with the user who sent the join request
+	Date       int64           `json:"date"`                  // Date the request was sent in Unix time
+	Bio        string          `json:"bio,omitempty"`         // Bio of the user
+	InviteLink *ChatInviteLink `json:"invite_link,omitempty"` // Chat invite link that was used by the user to send the join request
+
+	Extra ExtraFields `json:"-"`
+	updateContext
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (cjr *ChatJoinRequest) UnmarshalJSON(data []byte) error {
+	type plain ChatJoinRequest
+	return unmarshalExtra(data, (*plain)(cjr), &cjr.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (cjr ChatJoinRequest) MarshalJSON() ([]byte, error) {
+	type plain ChatJoinRequest
+	return marshalExtra(plain(cjr), cjr.Extra)
+}
+
+// ForumTopic represents a forum topic
+type ForumTopic struct {
+	MessageThreadID   int    `json:"message_thread_id"`              // Unique identifier of the forum topic
+	Name              string `json:"name"`                           // Name of the topic
+	IconColor         int    `json:"icon_color"`                     // Color of the topic icon in RGB format
+	IconCustomEmojiID string `json:"icon_custom_emoji_id,omitempty"` // Unique identifier of the custom emoji shown as the topic icon
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (ft *ForumTopic) UnmarshalJSON(data []byte) error {
+	type plain ForumTopic
+	return unmarshalExtra(data, (*plain)(ft), &ft.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (ft ForumTopic) MarshalJSON() ([]byte, error) {
+	type plain ForumTopic
+	return marshalExtra(plain(ft), ft.Extra)
+}
+
+// Sticker represents a sticker
+type Sticker struct {
+	FileID           string        `json:"file_id"`                     // Identifier for this file, which can be used to download or reuse the file
+	FileUniqueID     string        `json:"file_unique_id"`              // Unique identifier for this file, which is supposed to be the same over time and for different bots
+	Type             string        `json:"type"`                        // Type of the sticker, currently one of “regular”, “mask”, “custom_emoji”
+	Width            int           `json:"width"`                       // Sticker width
+	Height           int           `json:"height"`                      // Sticker height
+	IsAnimated       bool          `json:"is_animated"`                 // True, if the sticker is animated
+	IsVideo          bool          `json:"is_video"`                    // True, if the sticker is a video sticker
+	Thumb            *PhotoSize    `json:"thumb,omitempty"`             // Sticker thumbnail in the .WEBP or .JPG format
+	Emoji            string        `json:"emoji,omitempty"`             // Emoji associated with the sticker
+	SetName          string        `json:"set_name,omitempty"`          // Name of the sticker set to which the sticker belongs
+	PremiumAnimation *File         `json:"premium_animation,omitempty"` // For premium regular stickers, premium animation for the sticker
+	MaskPosition     *MaskPosition `json:"mask_position,omitempty"`     // For mask stickers, the position where the mask should be placed
+	CustomEmojiID    string        `json:"custom_emoji_id,omitempty"`   // For custom emoji stickers, unique identifier of the custom emoji
+	FileSize         int           `json:"file_size,omitempty"`         // File size in bytes
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (s *Sticker) UnmarshalJSON(data []byte) error {
+	type plain Sticker
+	return unmarshalExtra(data, (*plain)(s), &s.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (s Sticker) MarshalJSON() ([]byte, error) {
+	type plain Sticker
+	return marshalExtra(plain(s), s.Extra)
+}
+
+// MaskPosition describes the position on faces where a mask should be placed by default
+type MaskPosition struct {
+	Point  string  `json:"point"`   // The part of the face relative to which the mask should be placed
+	XShift float32 `json:"x_shift"` // Shift by X-axis measured in widths of the mask scaled to the face size, from left to right
+	YShift float32 `json:"y_shift"` // Shift by Y-axis measured in heights of the mask scaled to the face size, from top to bottom
+	Scale  float32 `json:"scale"`   // Mask scaling coefficient
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (mp *MaskPosition) UnmarshalJSON(data []byte) error {
+	type plain MaskPosition
+	return unmarshalExtra(data, (*plain)(mp), &mp.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (mp MaskPosition) MarshalJSON() ([]byte, error) {
+	type plain MaskPosition
+	return marshalExtra(plain(mp), mp.Extra)
+}
+
+// InlineQuery represents an incoming inline query. When the user sends an empty query, your bot could
+// return some default or trending results
+type InlineQuery struct {
+	ID       string    `json:"id"`                  // Unique identifier for this query
+	From     *User     `json:"from"`                // Sender
+	Query    string    `json:"query"`               // Text of the query (up to 256 characters)
+	Offset   string    `json:"offset"`              // Offset of the results to be returned, can be controlled by the bot
+	ChatType string    `json:"chat_type,omitempty"` // Type of the chat from which the inline query was sent
+	Location *Location `json:"location,omitempty"`  // Sender location, only for bots that request user location
+
+	Extra ExtraFields `json:"-"`
+	updateContext
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (iq *InlineQuery) UnmarshalJSON(data []byte) error {
+	type plain InlineQuery
+	return unmarshalExtra(data, (*plain)(iq), &iq.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (iq InlineQuery) MarshalJSON() ([]byte, error) {
+	type plain InlineQuery
+	return marshalExtra(plain(iq), iq.Extra)
+}
+
+// ChosenInlineResult represents a result of an inline query that was chosen by the user and sent to
+// their chat partner
+type ChosenInlineResult struct {
+	ResultID        string    `json:"result_id"`                   // The unique identifier for the result that was chosen
+	From            *User     `json:"from"`                        // The user that chose the result
+	Location        *Location `json:"location,omitempty"`          // Sender location, only for bots that require user location
+	InlineMessageID string    `json:"inline_message_id,omitempty"` // Identifier of the sent inline message
+	Query           string    `json:"query"`                       // The query that was used to obtain the result
+
+	Extra ExtraFields `json:"-"`
+	updateContext
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (cir *ChosenInlineResult) UnmarshalJSON(data []byte) error {
+	type plain ChosenInlineResult
+	return unmarshalExtra(data, (*plain)(cir), &cir.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (cir ChosenInlineResult) MarshalJSON() ([]byte, error) {
+	type plain ChosenInlineResult
+	return marshalExtra(plain(cir), cir.Extra)
+}
+
+// CallbackQuery represents an incoming callback query from a callback button in an inline keyboard
+type CallbackQuery struct {
+	ID              string   `json:"id"`                          // Unique identifier for this query
+	From            *User    `json:"from"`                        // Sender
+	Message         *Message `json:"message,omitempty"`           // Message with the callback button that originated the query
+	InlineMessageID string   `json:"inline_message_id,omitempty"` // Identifier of the message sent via the bot in inline mode, that originated the query
+	ChatInstance    string   `json:"chat_instance"`               // Global identifier, uniquely corresponding to the chat to which the message with the callback button was sent
+	Data            string   `json:"data,omitempty"`              // Data associated with the callback button
+	GameShortName   string   `json:"game_short_name,omitempty"`   // Short name of a Game to be returned, serves as the unique identifier for the game
+
+	Extra ExtraFields `json:"-"`
+	updateContext
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (cq *CallbackQuery) UnmarshalJSON(data []byte) error {
+	type plain CallbackQuery
+	return unmarshalExtra(data, (*plain)(cq), &cq.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (cq CallbackQuery) MarshalJSON() ([]byte, error) {
+	type plain CallbackQuery
+	return marshalExtra(plain(cq), cq.Extra)
+}
+
+// Game represents a game. Use BotFather to create and edit games, their short names will act as unique
+// identifiers
+type Game struct {
+	Title        string           `json:"title"`                   // Title of the game
+	Description  string           `json:"description"`             // Description of the game
+	Photo        []*PhotoSize     `json:"photo"`                   // Photo that will be displayed in the game message in chats
+	Text         string           `json:"text,omitempty"`          // Brief description of the game or high scores included in the game message
+	TextEntities []*MessageEntity `json:"text_entities,omitempty"` // Special entities that appear in text, such as usernames, URLs, bot commands, etc
+	Animation    *Animation       `json:"animation,omitempty"`     // Animation that will be displayed in the game message in chats
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (g *Game) UnmarshalJSON(data []byte) error {
+	type plain Game
+	return unmarshalExtra(data, (*plain)(g), &g.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (g Game) MarshalJSON() ([]byte, error) {
+	type plain Game
+	return marshalExtra(plain(g), g.Extra)
+}
+
+// Invoice contains basic information about an invoice
+type Invoice struct {
+	Title          string `json:"title"`           // Product name
+	Description    string `json:"description"`     // Product description
+	StartParameter string `json:"start_parameter"` // Unique bot deep-linking parameter that can be used to generate this invoice
+	Currency       string `json:"currency"`        // Three-letter ISO 4217 currency code
+	TotalAmount    int    `json:"total_amount"`    // Total price in the smallest units of the currency (integer, not float/double)
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (i *Invoice) UnmarshalJSON(data []byte) error {
+	type plain Invoice
+	return unmarshalExtra(data, (*plain)(i), &i.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (i Invoice) MarshalJSON() ([]byte, error) {
+	type plain Invoice
+	return marshalExtra(plain(i), i.Extra)
+}
+
+// ShippingAddress represents a shipping address
+type ShippingAddress struct {
+	CountryCode string `json:"country_code"` // Two-letter ISO 3166-1 alpha-2 country code
+	State       string `json:"state"`        // State, if applicable
+	City        string `json:"city"`         // City
+	StreetLine1 string `json:"street_line1"` // First line for the address
+	StreetLine2 string `json:"street_line2"` // Second line for the address
+	PostCode    string `json:"post_code"`    // Address post code
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (sa *ShippingAddress) UnmarshalJSON(data []byte) error {
+	type plain ShippingAddress
+	return unmarshalExtra(data, (*plain)(sa), &sa.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (sa ShippingAddress) MarshalJSON() ([]byte, error) {
+	type plain ShippingAddress
+	return marshalExtra(plain(sa), sa.Extra)
+}
+
+// OrderInfo represents information about an order
+type OrderInfo struct {
+	Name            string           `json:"name,omitempty"`             // User name
+	PhoneNumber     string           `json:"phone_number,omitempty"`     // User's phone number
+	Email           string           `json:"email,omitempty"`            // User email
+	ShippingAddress *ShippingAddress `json:"shipping_address,omitempty"` // User shipping address
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (oi *OrderInfo) UnmarshalJSON(data []byte) error {
+	type plain OrderInfo
+	return unmarshalExtra(data, (*plain)(oi), &oi.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (oi OrderInfo) MarshalJSON() ([]byte, error) {
+	type plain OrderInfo
+	return marshalExtra(plain(oi), oi.Extra)
+}
+
+// SuccessfulPayment contains basic information about a successful payment
+type SuccessfulPayment struct {
+	Currency                string     `json:"currency"`                     // Three-letter ISO 4217 currency code
+	TotalAmount             int        `json:"total_amount"`                 // Total price in the smallest units of the currency (integer, not float/double)
+	InvoicePayload          string     `json:"invoice_payload"`              // Bot specified invoice payload
+	ShippingOptionID        string     `json:"shipping_option_id,omitempty"` // Identifier of the shipping option chosen by the user
+	OrderInfo               *OrderInfo `json:"order_info,omitempty"`         // Order information provided by the user
+	TelegramPaymentChargeID string     `json:"telegram_payment_charge_id"`   // Telegram payment identifier
+	ProviderPaymentChargeID string     `json:"provider_payment_charge_id"`   // Provider payment identifier
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (sp *SuccessfulPayment) UnmarshalJSON(data []byte) error {
+	type plain SuccessfulPayment
+	return unmarshalExtra(data, (*plain)(sp), &sp.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (sp SuccessfulPayment) MarshalJSON() ([]byte, error) {
+	type plain SuccessfulPayment
+	return marshalExtra(plain(sp), sp.Extra)
+}
+
+// ShippingQuery contains information about an incoming shipping query
+type ShippingQuery struct {
+	ID              string           `json:"id"`               // Unique query identifier
+	From            *User            `json:"from"`             // User who sent the query
+	InvoicePayload  string           `json:"invoice_payload"`  // Bot specified invoice payload
+	ShippingAddress *ShippingAddress `json:"shipping_address"` // User specified shipping address
+
+	Extra ExtraFields `json:"-"`
+	updateContext
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (sq *ShippingQuery) UnmarshalJSON(data []byte) error {
+	type plain ShippingQuery
+	return unmarshalExtra(data, (*plain)(sq), &sq.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (sq ShippingQuery) MarshalJSON() ([]byte, error) {
+	type plain ShippingQuery
+	return marshalExtra(plain(sq), sq.Extra)
+}
+
+// PreCheckoutQuery contains information about an incoming pre-checkout query
+type PreCheckoutQuery struct {
+	ID               string     `json:"id"`                           // Unique query identifier
+	From             *User      `json:"from"`                         // User who sent the query
+	Currency         string     `json:"currency"`                     // Three-letter ISO 4217 currency code
+	TotalAmount      int        `json:"total_amount"`                 // Total price in the smallest units of the currency (integer, not float/double)
+	InvoicePayload   string     `json:"invoice_payload"`              // Bot specified invoice payload
+	ShippingOptionID string     `json:"shipping_option_id,omitempty"` // Identifier of the shipping option chosen by the user
+	OrderInfo        *OrderInfo `json:"order_info,omitempty"`         // Order information provided by the user
+
+	Extra ExtraFields `json:"-"`
+	updateContext
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (pcq *PreCheckoutQuery) UnmarshalJSON(data []byte) error {
+	type plain PreCheckoutQuery
+	return unmarshalExtra(data, (*plain)(pcq), &pcq.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (pcq PreCheckoutQuery) MarshalJSON() ([]byte, error) {
+	type plain PreCheckoutQuery
+	return marshalExtra(plain(pcq), pcq.Extra)
+}
+
+// PassportData describes Telegram Passport data shared with the bot by the user
+type PassportData struct {
+	Data        []EncryptedPassportElement `json:"data"`        // Array with information about documents and other Telegram Passport elements that was shared with the bot
+	Credentials EncryptedCredentials       `json:"credentials"` // Encrypted credentials required to decrypt the data
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (pd *PassportData) UnmarshalJSON(data []byte) error {
+	type plain PassportData
+	return unmarshalExtra(data, (*plain)(pd), &pd.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (pd PassportData) MarshalJSON() ([]byte, error) {
+	type plain PassportData
+	return marshalExtra(plain(pd), pd.Extra)
+}
+
+// PassportFile represents a file uploaded to Telegram Passport. Currently all Telegram Passport files
+// are in JPEG format when decrypted and don't exceed 10MB
+type PassportFile struct {
+	FileID       string `json:"file_id"`        // Identifier for this file, which can be used to download or reuse the file
+	FileUniqueID string `json:"file_unique_id"` // Unique identifier for this file, which is supposed to be the same over time and for different bots
+	FileSize     int    `json:"file_size"`      // File size in bytes
+	FileDate     int    `json:"file_date"`      // Unix time when the file was uploaded
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (pf *PassportFile) UnmarshalJSON(data []byte) error {
+	type plain PassportFile
+	return unmarshalExtra(data, (*plain)(pf), &pf.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (pf PassportFile) MarshalJSON() ([]byte, error) {
+	type plain PassportFile
+	return marshalExtra(plain(pf), pf.Extra)
+}
+
+// EncryptedPassportElement describes documents or other Telegram Passport elements shared with the bot
+// by the user
+type EncryptedPassportElement struct {
+	Type        string          `json:"type"`                   // Element type
+	Data        string          `json:"data,omitempty"`         // Base64-encoded encrypted Telegram Passport element data provided by the user
+	PhoneNumber string          `json:"phone_number,omitempty"` // User's verified phone number, available only for “phone_number” type
+	Email       string          `json:"email,omitempty"`        // User's verified email address, available only for “email” type
+	Files       []*PassportFile `json:"files,omitempty"`        // Array of encrypted files with documents provided by the user
+	FrontSide   *PassportFile   `json:"front_side,omitempty"`   // Encrypted file with the front side of the document, provided by the user
+	ReverseSide *PassportFile   `json:"reverse_side,omitempty"` // Encrypted file with the reverse side of the document, provided by the user
+	Selfie      *PassportFile   `json:"selfie,omitempty"`       // Encrypted file with the selfie of the user holding a document, provided by the user
+	Translation []*PassportFile `json:"translation,omitempty"`  // Array of encrypted files with translated versions of documents provided by the user
+	Hash        string          `json:"hash"`                   // Base64-encoded element hash for using in PassportElementErrorUnspecified
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (epe *EncryptedPassportElement) UnmarshalJSON(data []byte) error {
+	type plain EncryptedPassportElement
+	return unmarshalExtra(data, (*plain)(epe), &epe.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (epe EncryptedPassportElement) MarshalJSON() ([]byte, error) {
+	type plain EncryptedPassportElement
+	return marshalExtra(plain(epe), epe.Extra)
+}
+
+// EncryptedCredentials describes data required for decrypting and authenticating
+// EncryptedPassportElement
+type EncryptedCredentials struct {
+	Data   string `json:"data"`   // Base64-encoded encrypted JSON-serialized data with unique user's payload, data hashes and secrets required for EncryptedPassportElement decryption and authentication
+	Hash   string `json:"hash"`   // Base64-encoded data hash for data authentication
+	Secret string `json:"secret"` // Base64-encoded secret, encrypted with the bot's public RSA key, required for data decryption
+
+	Extra ExtraFields `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (ec *EncryptedCredentials) UnmarshalJSON(data []byte) error {
+	type plain EncryptedCredentials
+	return unmarshalExtra(data, (*plain)(ec), &ec.Extra)
+}
+
+// MarshalJSON implements json.Marshaler
+func (ec EncryptedCredentials) MarshalJSON() ([]byte, error) {
+	type plain EncryptedCredentials
+	return marshalExtra(plain(ec), ec.Extra)
+}
@@ -0,0 +1,158 @@
+package tbot
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+/*
+ExtraFields holds fields of a Bot API object which are unknown to the library,
+e.g. added in a newer Bot API version, and optional fields explicitly sent with empty values.
+They are kept on decoding and written back on encoding, so json.Marshal of a decoded update
+produces the same object:
+
+	raw, _ := json.Marshal(update) // safe to queue, log or replay
+	effect := update.Message.Extra["message_effect_id"]
+*/
+type ExtraFields map[string]json.RawMessage
+
+type jsonField struct {
+	index     []int
+	omitEmpty bool
+}
+
+// knownFields caches JSON fields of struct types, map[reflect.Type]map[string]jsonField
+var knownFields sync.Map
+
+// unmarshalExtra decodes data into v, a pointer to a struct, and keeps fields which v can't encode back in extra
+func unmarshalExtra(data []byte, v interface{}, extra *ExtraFields) error {
+	err := json.Unmarshal(data, v)
+	if err != nil {
+		return err
+	}
+	*extra = nil
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return nil
+	}
+	fields := ExtraFields{}
+	err = json.Unmarshal(data, &fields)
+	if err != nil {
+		return err
+	}
+	value := reflect.ValueOf(v).Elem()
+	known := jsonFields(value.Type())
+	for name := range fields {
+		if f, ok := known[name]; ok && !f.omitted(value) {
+			delete(fields, name)
+		}
+	}
+	if len(fields) > 0 {
+		*extra = fields
+	}
+	return nil
+}
+
+// marshalExtra encodes v, a struct, adding extra fields in sorted order
+func marshalExtra(v interface{}, extra ExtraFields) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+	value := reflect.ValueOf(v)
+	known := jsonFields(value.Type())
+	names := make([]string, 0, len(extra))
+	for name := range extra {
+		if f, ok := known[name]; !ok || f.omitted(value) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	buf := &bytes.Buffer{}
+	buf.Write(data[:len(data)-1])
+	for i, name := range names {
+		if i > 0 || len(data) > 2 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(name)
+		buf.Write(key)
+		buf.WriteByte(':')
+		raw := extra[name]
+		if len(raw) == 0 {
+			raw = json.RawMessage("null")
+		}
+		buf.Write(raw)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// omitted reports whether the field of struct value is not encoded because of omitempty
+func (f jsonField) omitted(value reflect.Value) bool {
+	return f.omitEmpty && isEmptyValue(value.FieldByIndex(f.index))
+}
+
+// isEmptyValue reports whether v is empty the way encoding/json omitempty treats it
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
+
+// jsonFields returns fields encoded by encoding/json for struct type t
+func jsonFields(t reflect.Type) map[string]jsonField {
+	if known, ok := knownFields.Load(t); ok {
+		return known.(map[string]jsonField)
+	}
+	known := map[string]jsonField{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		if f.Anonymous && tag == "" && f.Type.Kind() == reflect.Struct {
+			for name, embedded := range jsonFields(f.Type) {
+				embedded.index = append([]int{i}, embedded.index...)
+				known[name] = embedded
+			}
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		opts := strings.Split(tag, ",")
+		name := opts[0]
+		if name == "" {
+			name = f.Name
+		}
+		field := jsonField{index: []int{i}}
+		for _, opt := range opts[1:] {
+			field.omitEmpty = field.omitEmpty || opt == "omitempty"
+		}
+		known[name] = field
+	}
+	knownFields.Store(t, known)
+	return known
+}
+
+// MarshalJSON implements json.Marshaler
+func (u Update) MarshalJSON() ([]byte, error) {
+	type plain Update
+	return marshalExtra(plain(u), u.Extra)
+}
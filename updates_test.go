@@ -0,0 +1,189 @@
+package tbot_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/yanzay/tbot/v2"
+)
+
+func TestUpdateChatMember(t *testing.T) {
+	data := `
+		{
+			"update_id": 1,
+			"chat_member": {
+				"chat": {"id": -100123, "type": "supergroup"},
+				"from": {"id": 1},
+				"date": 1600000000,
+				"old_chat_member": {"user": {"id": 2}, "status": "left"},
+				"new_chat_member": {"user": {"id": 2}, "status": "member"}
+			}
+		}
+	`
+	up := &tbot.Update{}
+	err := json.Unmarshal([]byte(data), up)
+	if err != nil {
+		t.Fatalf("unable to decode update: %v", err)
+	}
+	if up.ChatMember == nil {
+		t.Fatalf("empty chat_member")
+	}
+	if up.ChatMember.NewChatMember.Status != "member" {
+		t.Fatalf("wrong new status: %s", up.ChatMember.NewChatMember.Status)
+	}
+	v, err := up.ChatMember.NewChatMember.Variant()
+	if err != nil {
+		t.Fatalf("unable to get variant: %v", err)
+	}
+	if member, ok := v.(*tbot.ChatMemberMember); !ok || member.User.ID != 2 {
+		t.Errorf("unexpected variant %#v", v)
+	}
+	v, err = tbot.UnmarshalChatMemberVariant([]byte(`{"user": {"id": 3}, "status": "kicked", "until_date": 5}`))
+	if banned, ok := v.(*tbot.ChatMemberBanned); !ok || err != nil || banned.UntilDate != 5 {
+		t.Errorf("unexpected variant %#v: %v", v, err)
+	}
+	if _, err = tbot.UnmarshalChatMemberVariant([]byte(`{"status": "unknown"}`)); err == nil {
+		t.Errorf("expected error for unknown status")
+	}
+}
+
+func TestUpdateRaw(t *testing.T) {
+	data := `{"update_id": 2, "some_future_update": {"field": "value"}}`
+	up := &tbot.Update{}
+	err := json.Unmarshal([]byte(data), up)
+	if err != nil {
+		t.Fatalf("unable to decode update: %v", err)
+	}
+	if string(up.Raw()) != data {
+		t.Fatalf("raw update mismatch: %s", up.Raw())
+	}
+}
+
+func TestUpdateLargeIDs(t *testing.T) {
+	data := `
+		{
+			"update_id": 3,
+			"message": {
+				"message_id": 1,
+				"from": {"id": 7000000000, "first_name": "Big"},
+				"chat": {"id": -1009876543210, "type": "supergroup"},
+				"migrate_to_chat_id": -1009876543211
+			}
+		}
+	`
+	up := &tbot.Update{}
+	err := json.Unmarshal([]byte(data), up)
+	if err != nil {
+		t.Fatalf("unable to decode update: %v", err)
+	}
+	if up.Message.From.ID != 7000000000 {
+		t.Errorf("wrong user ID: %d", up.Message.From.ID)
+	}
+	if up.Message.Chat.ID != tbot.NewChatID(-1009876543210) {
+		t.Errorf("wrong chat ID: %s", up.Message.Chat.ID)
+	}
+	if up.Message.MigrateToChatID != -1009876543211 {
+		t.Errorf("wrong migrate_to_chat_id: %d", up.Message.MigrateToChatID)
+	}
+	out, err := json.Marshal(up.Message.Chat)
+	if err != nil {
+		t.Fatalf("unable to encode chat: %v", err)
+	}
+	if string(out) != `{"id":-1009876543210,"type":"supergroup"}` {
+		t.Errorf("unexpected chat json: %s", out)
+	}
+}
+
+func TestChatID(t *testing.T) {
+	id := tbot.NewChatID(-1001234567890)
+	if n, ok := id.Int64(); !ok || n != -1001234567890 || id.IsUsername() {
+		t.Errorf("unexpected numeric chat ID %s", id)
+	}
+	for _, name := range []string{"mychannel", "@mychannel"} {
+		id = tbot.ChannelUsername(name)
+		if _, ok := id.Int64(); ok || !id.IsUsername() || id != "@mychannel" {
+			t.Errorf("unexpected channel chat ID %s", id)
+		}
+	}
+}
+
+func TestUpdateRoundTrip(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "updates", "*.json"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no update samples: %v", err)
+	}
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			data, err := ioutil.ReadFile(file)
+			if err != nil {
+				t.Fatalf("unable to read sample: %v", err)
+			}
+			up := &tbot.Update{}
+			err = json.Unmarshal(data, up)
+			if err != nil {
+				t.Fatalf("unable to decode update: %v", err)
+			}
+			encoded, err := json.Marshal(up)
+			if err != nil {
+				t.Fatalf("unable to encode update: %v", err)
+			}
+			var want, got interface{}
+			json.Unmarshal(data, &want)
+			json.Unmarshal(encoded, &got)
+			if !reflect.DeepEqual(want, got) {
+				t.Errorf("update changed after round trip:\n%s\n%s", bytes.Join(bytes.Fields(data), []byte(" ")), encoded)
+			}
+
+			again := &tbot.Update{}
+			err = json.Unmarshal(encoded, again)
+			if err != nil {
+				t.Fatalf("unable to decode encoded update: %v", err)
+			}
+			reencoded, _ := json.Marshal(again)
+			if !bytes.Equal(encoded, reencoded) {
+				t.Errorf("encoding is not stable:\n%s\n%s", encoded, reencoded)
+			}
+		})
+	}
+}
+
+func TestUpdateExtraFields(t *testing.T) {
+	data := `{"update_id": 4, "message": {"message_id": 1, "date": 1, "chat": {"id": 1, "type": "private"}, "message_effect_id": "7"}}`
+	up := &tbot.Update{}
+	err := json.Unmarshal([]byte(data), up)
+	if err != nil {
+		t.Fatalf("unable to decode update: %v", err)
+	}
+	if string(up.Message.Extra["message_effect_id"]) != `"7"` {
+		t.Errorf("unknown field is not kept: %v", up.Message.Extra)
+	}
+	if up.Extra != nil || up.Message.Chat.Extra != nil {
+		t.Errorf("unexpected extra fields: %v %v", up.Extra, up.Message.Chat.Extra)
+	}
+}
+
+func TestUpdateForumTopic(t *testing.T) {
+	data, err := ioutil.ReadFile(filepath.Join("testdata", "updates", "message_forum_topic.json"))
+	if err != nil {
+		t.Fatalf("unable to read sample: %v", err)
+	}
+	up := &tbot.Update{}
+	err = json.Unmarshal(data, up)
+	if err != nil {
+		t.Fatalf("unable to decode update: %v", err)
+	}
+	m := up.Message
+	if !m.IsTopicMessage || m.MessageThreadID != 41 || !m.Chat.IsForum || m.SenderChat == nil {
+		t.Errorf("forum fields are not decoded: %+v", m)
+	}
+	if m.ForumTopicCreated == nil || m.ForumTopicCreated.Name != "Billing" || m.ForumTopicCreated.IconColor != 7322096 {
+		t.Errorf("unexpected forum_topic_created: %+v", m.ForumTopicCreated)
+	}
+	if m.Extra != nil || m.From.Extra != nil {
+		t.Errorf("unexpected extra fields: %v %v", m.Extra, m.From.Extra)
+	}
+}